@@ -1,22 +1,50 @@
 package bucket
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/meateam/upload-service/storage"
 )
 
+// deletePageSize is the number of objects listed and deleted at a time when emptying a bucket,
+// which is the maximal number of objects S3 deletes in a single request.
+const deletePageSize = 1000
+
+// ErrBucketNotFound is returned by GetBucketInfo if the bucket doesn't exist.
+var ErrBucketNotFound = errors.New("bucket not found")
+
+// Info is the details of a bucket.
+type Info struct {
+	// Name is the normalized name of the bucket.
+	Name string
+
+	// CreationDate is the time the bucket was created.
+	CreationDate time.Time
+
+	// ObjectCount is the number of objects in the bucket.
+	ObjectCount int64
+
+	// Size is the total size of the objects in the bucket in bytes.
+	Size int64
+
+	// UploadCount is the number of in-progress multipart uploads in the bucket.
+	UploadCount int64
+}
+
 // Service is a structure used for bucket operations on S3
 type Service struct {
-	s3Client *s3.S3
+	backend storage.Backend
 }
 
-// NewService creates a Service and returns it.
-func NewService(s3Client *s3.S3) *Service {
-	return &Service{s3Client: s3Client}
+// NewService creates a Service with the given storage backend and returns it.
+func NewService(backend storage.Backend) *Service {
+	return &Service{backend: backend}
 }
 
 // BucketExists returns true if a bucket exists and the S3Client has permission
@@ -31,7 +59,7 @@ func (s Service) BucketExists(ctx aws.Context, bucket *string) bool {
 		Bucket: aws.String(normalizedBucketName),
 	}
 
-	_, err := s.s3Client.HeadBucketWithContext(ctx, input)
+	_, err := s.backend.HeadBucket(ctx, input)
 
 	return (err == nil)
 }
@@ -49,9 +77,9 @@ func (s Service) CreateBucket(ctx aws.Context, bucket *string) (bool, error) {
 	}
 
 	// Create a new bucket using the CreateBucket call.
-	_, err := s.s3Client.CreateBucketWithContext(ctx, cparams)
+	_, err := s.backend.CreateBucket(ctx, cparams)
 	if err != nil {
-		return false, fmt.Errorf("failed to create bucket: %v", err)
+		return false, fmt.Errorf("failed to create bucket: %w", err)
 	}
 
 	return true, nil
@@ -66,3 +94,211 @@ func (s Service) NormalizeCephBucketName(bucketName string) string {
 	reg := regexp.MustCompile("[^a-zA-Z0-9]+")
 	return reg.ReplaceAllString(lowerCaseBucketName, "-")
 }
+
+// ListBuckets returns all of the buckets.
+func (s Service) ListBuckets(ctx aws.Context) ([]*s3.Bucket, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context is required")
+	}
+
+	output, err := s.backend.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	return output.Buckets, nil
+}
+
+// DeleteBucket deletes the bucket with the given bucket name.
+// If force is true then the bucket is emptied first, otherwise the bucket
+// must have no objects and no multipart uploads.
+// Returns the number of deleted objects and aborted multipart uploads.
+func (s Service) DeleteBucket(ctx aws.Context, bucket *string, force bool) (int64, int64, error) {
+	if ctx == nil {
+		return 0, 0, fmt.Errorf("context is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return 0, 0, fmt.Errorf("bucket name is required")
+	}
+
+	normalizedBucketName := aws.String(s.NormalizeCephBucketName(*bucket))
+
+	var deletedObjects, abortedUploads int64
+	if force {
+		var err error
+		deletedObjects, abortedUploads, err = s.EmptyBucket(ctx, normalizedBucketName)
+		if err != nil {
+			return deletedObjects, abortedUploads, err
+		}
+	}
+
+	_, err := s.backend.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: normalizedBucketName})
+	if err != nil {
+		return deletedObjects, abortedUploads, fmt.Errorf("failed to delete bucket %s: %w", *normalizedBucketName, err)
+	}
+
+	return deletedObjects, abortedUploads, nil
+}
+
+// EmptyBucket deletes all of the objects in the bucket with the given bucket name
+// and aborts all of its multipart uploads, a page at a time.
+// Returns the number of deleted objects and aborted multipart uploads.
+func (s Service) EmptyBucket(ctx aws.Context, bucket *string) (int64, int64, error) {
+	if ctx == nil {
+		return 0, 0, fmt.Errorf("context is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return 0, 0, fmt.Errorf("bucket name is required")
+	}
+
+	normalizedBucketName := aws.String(s.NormalizeCephBucketName(*bucket))
+
+	var deletedObjects int64
+	listInput := &s3.ListObjectsV2Input{
+		Bucket:  normalizedBucketName,
+		MaxKeys: aws.Int64(deletePageSize),
+	}
+	for {
+		// The listed objects are deleted, so each page is listed from the start of the bucket.
+		page, err := s.backend.ListObjectsV2(ctx, listInput)
+		if err != nil {
+			return deletedObjects, 0, fmt.Errorf("failed to list objects in bucket %s: %w", *normalizedBucketName, err)
+		}
+
+		if len(page.Contents) == 0 {
+			break
+		}
+
+		objects := make([]*s3.ObjectIdentifier, 0, len(page.Contents))
+		for _, object := range page.Contents {
+			objects = append(objects, &s3.ObjectIdentifier{Key: object.Key})
+		}
+
+		deleted, err := s.backend.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: normalizedBucketName,
+			Delete: &s3.Delete{Objects: objects, Quiet: aws.Bool(false)},
+		})
+		if err != nil {
+			return deletedObjects, 0, fmt.Errorf("failed to delete objects in bucket %s: %w", *normalizedBucketName, err)
+		}
+		deletedObjects += int64(len(deleted.Deleted))
+
+		if len(deleted.Errors) > 0 {
+			return deletedObjects, 0, fmt.Errorf(
+				"failed to delete object %s in bucket %s: %s",
+				aws.StringValue(deleted.Errors[0].Key),
+				*normalizedBucketName,
+				aws.StringValue(deleted.Errors[0].Message),
+			)
+		}
+
+		if !aws.BoolValue(page.IsTruncated) {
+			break
+		}
+	}
+
+	var abortedUploads int64
+	uploadsInput := &s3.ListMultipartUploadsInput{Bucket: normalizedBucketName}
+	for {
+		page, err := s.backend.ListMultipartUploads(ctx, uploadsInput)
+		if err != nil {
+			return deletedObjects, abortedUploads, fmt.Errorf("failed to list uploads in bucket %s: %w", *normalizedBucketName, err)
+		}
+
+		for _, upload := range page.Uploads {
+			_, err := s.backend.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   normalizedBucketName,
+				Key:      upload.Key,
+				UploadId: upload.UploadId,
+			})
+			if err != nil {
+				return deletedObjects, abortedUploads, fmt.Errorf(
+					"failed to abort upload %s in bucket %s: %w",
+					aws.StringValue(upload.UploadId),
+					*normalizedBucketName,
+					err,
+				)
+			}
+			abortedUploads++
+		}
+
+		if !aws.BoolValue(page.IsTruncated) {
+			break
+		}
+		uploadsInput.KeyMarker = page.NextKeyMarker
+		uploadsInput.UploadIdMarker = page.NextUploadIdMarker
+	}
+
+	return deletedObjects, abortedUploads, nil
+}
+
+// GetBucketInfo returns the details of the bucket with the given bucket name.
+// The bucket's objects and multipart uploads are listed to count them,
+// so it takes longer the more objects the bucket has.
+// Returns ErrBucketNotFound if the bucket doesn't exist.
+func (s Service) GetBucketInfo(ctx aws.Context, bucket *string) (*Info, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("context is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return nil, fmt.Errorf("bucket name is required")
+	}
+
+	buckets, err := s.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{Name: s.NormalizeCephBucketName(*bucket)}
+	found := false
+	for _, b := range buckets {
+		if aws.StringValue(b.Name) == info.Name {
+			info.CreationDate = aws.TimeValue(b.CreationDate)
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, ErrBucketNotFound
+	}
+
+	listInput := &s3.ListObjectsV2Input{Bucket: aws.String(info.Name)}
+	for {
+		page, err := s.backend.ListObjectsV2(ctx, listInput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects in bucket %s: %w", info.Name, err)
+		}
+
+		for _, object := range page.Contents {
+			info.ObjectCount++
+			info.Size += aws.Int64Value(object.Size)
+		}
+
+		if !aws.BoolValue(page.IsTruncated) {
+			break
+		}
+		listInput.ContinuationToken = page.NextContinuationToken
+	}
+
+	uploadsInput := &s3.ListMultipartUploadsInput{Bucket: aws.String(info.Name)}
+	for {
+		page, err := s.backend.ListMultipartUploads(ctx, uploadsInput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list uploads in bucket %s: %w", info.Name, err)
+		}
+
+		info.UploadCount += int64(len(page.Uploads))
+
+		if !aws.BoolValue(page.IsTruncated) {
+			break
+		}
+		uploadsInput.KeyMarker = page.NextKeyMarker
+		uploadsInput.UploadIdMarker = page.NextUploadIdMarker
+	}
+
+	return info, nil
+}
@@ -2,63 +2,32 @@ package bucket_test
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"os"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/meateam/upload-service/bucket"
 	"github.com/meateam/upload-service/internal/test"
+	"github.com/meateam/upload-service/object"
+	pb "github.com/meateam/upload-service/proto"
+	"github.com/meateam/upload-service/storage"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Declaring global variables.
-var s3Endpoint string
-var s3Client *s3.S3
+var backend storage.Backend = storage.NewMemoryBackend()
 var mu sync.Mutex
 
-func init() {
-	// Fetch env vars
-	s3AccessKey := os.Getenv("S3_ACCESS_KEY")
-	s3SecretKey := os.Getenv("S3_SECRET_KEY")
-	s3Endpoint = os.Getenv("S3_ENDPOINT")
-	s3Token := ""
-
-	// Configure to use S3 Server
-	s3Config := &aws.Config{
-		Credentials:      credentials.NewStaticCredentials(s3AccessKey, s3SecretKey, s3Token),
-		Endpoint:         aws.String(s3Endpoint),
-		Region:           aws.String("eu-east-1"),
-		DisableSSL:       aws.Bool(true),
-		S3ForcePathStyle: aws.Bool(true),
-	}
-
-	// Init real client.
-	newSession, err := session.NewSession(s3Config)
-	if err != nil {
-		log.Fatalf(err.Error())
-	}
-	s3Client = s3.New(newSession)
-
-	mu.Lock()
-	if err := test.EmptyAndDeleteBucket(s3Client, "testbucket"); err != nil {
-		log.Printf("test.EmptyAndDeleteBucket failed with error: %v", err)
-	}
-	if err := test.EmptyAndDeleteBucket(s3Client, "testbucket1"); err != nil {
-		log.Printf("test.EmptyAndDeleteBucket failed with error: %v", err)
-	}
-	if err := test.EmptyAndDeleteBucket(s3Client, "t874777-omer"); err != nil {
-		log.Printf("test.EmptyAndDeleteBucket failed with error: %v", err)
-	}
-	mu.Unlock()
-}
-
 func TestBucketService_CreateBucket(t *testing.T) {
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 	type args struct {
 		ctx    aws.Context
@@ -73,7 +42,7 @@ func TestBucketService_CreateBucket(t *testing.T) {
 	}{
 		{
 			name:   "create bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: aws.String("testbucket"),
@@ -83,7 +52,7 @@ func TestBucketService_CreateBucket(t *testing.T) {
 		},
 		{
 			name:   "create bucket - already exists",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: aws.String("testbucket"),
@@ -92,7 +61,7 @@ func TestBucketService_CreateBucket(t *testing.T) {
 		},
 		{
 			name:   "create bucket - nil bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: nil,
@@ -101,7 +70,7 @@ func TestBucketService_CreateBucket(t *testing.T) {
 		},
 		{
 			name:   "create bucket - empty bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: aws.String(""),
@@ -110,7 +79,7 @@ func TestBucketService_CreateBucket(t *testing.T) {
 		},
 		{
 			name:   "create bucket - invalid bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: aws.String("T874777@omer"),
@@ -121,7 +90,7 @@ func TestBucketService_CreateBucket(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := bucket.NewService(tt.fields.s3Client)
+			s := bucket.NewService(tt.fields.backend)
 			mu.Lock()
 			got, err := s.CreateBucket(tt.args.ctx, tt.args.bucket)
 			mu.Unlock()
@@ -137,7 +106,7 @@ func TestBucketService_CreateBucket(t *testing.T) {
 	}
 }
 func TestBucketService_BucketExists(t *testing.T) {
-	s := bucket.NewService(s3Client)
+	s := bucket.NewService(backend)
 
 	mu.Lock()
 	if _, err := s.CreateBucket(context.Background(), aws.String("testbucket")); err != nil {
@@ -145,7 +114,7 @@ func TestBucketService_BucketExists(t *testing.T) {
 	}
 	mu.Unlock()
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 	type args struct {
 		ctx    aws.Context
@@ -159,7 +128,7 @@ func TestBucketService_BucketExists(t *testing.T) {
 	}{
 		{
 			name:   "Bucket Exists",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: aws.String("testbucket"),
@@ -168,7 +137,7 @@ func TestBucketService_BucketExists(t *testing.T) {
 		},
 		{
 			name:   "Bucket doesn't Exists",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: aws.String("testbucket2"),
@@ -177,7 +146,7 @@ func TestBucketService_BucketExists(t *testing.T) {
 		},
 		{
 			name:   "Bucket nil",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				ctx:    context.Background(),
 				bucket: nil,
@@ -187,7 +156,7 @@ func TestBucketService_BucketExists(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := bucket.NewService(tt.fields.s3Client)
+			s := bucket.NewService(tt.fields.backend)
 
 			if got := s.BucketExists(tt.args.ctx, tt.args.bucket); got != tt.want {
 				t.Errorf("BucketService.BucketExists() = %v, want %v", got, tt.want)
@@ -195,3 +164,191 @@ func TestBucketService_BucketExists(t *testing.T) {
 		})
 	}
 }
+
+// fillBucket creates a bucket with the given number of 4 byte objects and multipart uploads.
+func fillBucket(t *testing.T, backend storage.Backend, bucketName string, objects int, uploads int) {
+	ctx := context.Background()
+	if _, err := backend.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(bucketName)}); err != nil {
+		t.Fatalf("CreateBucket failed with error: %v", err)
+	}
+
+	for i := 0; i < objects; i++ {
+		key := fmt.Sprintf("%04d", i)
+		_, err := backend.PutObject(ctx, &s3manager.UploadInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(key),
+			Body:   strings.NewReader(key),
+		})
+		if err != nil {
+			t.Fatalf("PutObject failed with error: %v", err)
+		}
+	}
+
+	for i := 0; i < uploads; i++ {
+		_, err := backend.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String("upload"),
+		})
+		if err != nil {
+			t.Fatalf("CreateMultipartUpload failed with error: %v", err)
+		}
+	}
+}
+
+func TestBucketService_DeleteBucket(t *testing.T) {
+	backend := storage.NewMemoryBackend()
+	fillBucket(t, backend, "fullbucket", 1500, 3)
+	fillBucket(t, backend, "emptybucket", 0, 0)
+
+	tests := []struct {
+		name               string
+		bucket             *string
+		force              bool
+		wantDeletedObjects int64
+		wantAbortedUploads int64
+		wantErr            bool
+	}{
+		{
+			name:    "delete bucket - not empty",
+			bucket:  aws.String("fullbucket"),
+			wantErr: true,
+		},
+		{
+			name:               "delete bucket - force",
+			bucket:             aws.String("FullBucket"),
+			force:              true,
+			wantDeletedObjects: 1500,
+			wantAbortedUploads: 3,
+		},
+		{
+			name:   "delete bucket - empty",
+			bucket: aws.String("emptybucket"),
+		},
+		{
+			name:    "delete bucket - does not exist",
+			bucket:  aws.String("emptybucket"),
+			force:   true,
+			wantErr: true,
+		},
+		{
+			name:    "delete bucket - nil bucket",
+			bucket:  nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := bucket.NewService(backend)
+			deletedObjects, abortedUploads, err := s.DeleteBucket(context.Background(), tt.bucket, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BucketService.DeleteBucket() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if deletedObjects != tt.wantDeletedObjects || abortedUploads != tt.wantAbortedUploads {
+				t.Errorf(
+					"BucketService.DeleteBucket() = %d, %d, want %d, %d",
+					deletedObjects,
+					abortedUploads,
+					tt.wantDeletedObjects,
+					tt.wantAbortedUploads,
+				)
+			}
+
+			if err == nil && s.BucketExists(context.Background(), tt.bucket) {
+				t.Errorf("BucketService.DeleteBucket() bucket %s still exists", *tt.bucket)
+			}
+		})
+	}
+}
+
+func TestBucketService_GetBucketInfo(t *testing.T) {
+	backend := storage.NewMemoryBackend()
+	fillBucket(t, backend, "infobucket", 3, 2)
+
+	s := bucket.NewService(backend)
+	got, err := s.GetBucketInfo(context.Background(), aws.String("InfoBucket"))
+	if err != nil {
+		t.Fatalf("BucketService.GetBucketInfo() error = %v", err)
+	}
+
+	if got.Name != "infobucket" || got.ObjectCount != 3 || got.Size != 12 || got.UploadCount != 2 {
+		t.Errorf("BucketService.GetBucketInfo() = %+v, want infobucket with 3 objects of size 12 and 2 uploads", got)
+	}
+
+	if got.CreationDate.IsZero() {
+		t.Errorf("BucketService.GetBucketInfo() CreationDate is zero")
+	}
+
+	if _, err := s.GetBucketInfo(context.Background(), aws.String("notexistbucket")); err != bucket.ErrBucketNotFound {
+		t.Errorf("BucketService.GetBucketInfo() error = %v, want %v", err, bucket.ErrBucketNotFound)
+	}
+}
+
+func TestHandler_BucketAdmin(t *testing.T) {
+	testServer := test.NewServer(nil)
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	conn, err := testServer.Dial(ctx)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewBucketAdminClient(conn)
+
+	created, err := client.CreateBucket(ctx, &pb.CreateBucketRequest{Bucket: "Admin@Bucket"})
+	if err != nil || created.GetBucket() != "admin-bucket" {
+		t.Fatalf("BucketAdmin.CreateBucket() = %v, error = %v, want admin-bucket", created, err)
+	}
+
+	if _, err := client.CreateBucket(ctx, &pb.CreateBucketRequest{Bucket: "admin-bucket"}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("BucketAdmin.CreateBucket() error = %v, wantCode %v", err, codes.AlreadyExists)
+	}
+
+	buckets, err := client.ListBuckets(ctx, &pb.ListBucketsRequest{})
+	if err != nil || len(buckets.GetBuckets()) != 1 || buckets.GetBuckets()[0].GetName() != "admin-bucket" {
+		t.Errorf("BucketAdmin.ListBuckets() = %v, error = %v, want admin-bucket", buckets, err)
+	}
+
+	fillBucket(t, testServer.GetBackend(), "full-bucket", 2, 1)
+
+	info, err := client.GetBucketInfo(ctx, &pb.GetBucketInfoRequest{Bucket: "full-bucket"})
+	if err != nil || info.GetObjectCount() != 2 || info.GetUploadCount() != 1 {
+		t.Errorf("BucketAdmin.GetBucketInfo() = %v, error = %v, want 2 objects and 1 upload", info, err)
+	}
+
+	_, err = client.GetBucketInfo(ctx, &pb.GetBucketInfoRequest{Bucket: "notexistbucket"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("BucketAdmin.GetBucketInfo() error = %v, wantCode %v", err, codes.NotFound)
+	}
+
+	if info := errorInfo(err); info == nil || info.GetReason() != object.ReasonBucketNotFound || info.GetDomain() != object.ErrorDomain {
+		t.Errorf("BucketAdmin.GetBucketInfo() error info = %v, want reason %s", info, object.ReasonBucketNotFound)
+	}
+
+	if _, err := client.DeleteBucket(ctx, &pb.DeleteBucketRequest{Bucket: "full-bucket"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("BucketAdmin.DeleteBucket() error = %v, wantCode %v", err, codes.FailedPrecondition)
+	}
+
+	deleted, err := client.DeleteBucket(ctx, &pb.DeleteBucketRequest{Bucket: "full-bucket", Force: true})
+	if err != nil || deleted.GetDeletedObjects() != 2 || deleted.GetAbortedUploads() != 1 {
+		t.Errorf("BucketAdmin.DeleteBucket() = %v, error = %v, want 2 deleted objects and 1 aborted upload", deleted, err)
+	}
+
+	if _, err := client.DeleteBucket(ctx, &pb.DeleteBucketRequest{Bucket: "full-bucket"}); status.Code(err) != codes.NotFound {
+		t.Errorf("BucketAdmin.DeleteBucket() error = %v, wantCode %v", err, codes.NotFound)
+	}
+}
+
+// errorInfo returns the google.rpc.ErrorInfo details of a gRPC error, or nil if it has none.
+func errorInfo(err error) *errdetails.ErrorInfo {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+
+	return nil
+}
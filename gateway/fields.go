@@ -0,0 +1,185 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// setFields sets the fields of message named by the keys of values, which are query parameters
+// or form fields. Returns an InvalidArgument error if a field doesn't exist or its value is invalid.
+func setFields(message proto.Message, values map[string][]string) error {
+	for name, fieldValues := range values {
+		if err := setField(message.ProtoReflect(), name, fieldValues); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid parameter %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setField sets the field of message named by name to values. A nested field is named by its path,
+// separated by dots, and a map entry by the map's name and the entry's key. The values of a repeated
+// field are appended to it, and the last value is set for any other field.
+// Repeated message fields can only be set in a JSON body.
+func setField(message protoreflect.Message, name string, values []string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	last := values[len(values)-1]
+	path := strings.Split(name, ".")
+	for i, fieldName := range path {
+		fields := message.Descriptor().Fields()
+		field := fields.ByJSONName(fieldName)
+		if field == nil {
+			field = fields.ByName(protoreflect.Name(fieldName))
+		}
+
+		if field == nil {
+			return fmt.Errorf("field %s does not exist", fieldName)
+		}
+
+		isLast := i == len(path)-1
+		switch {
+		case field.IsMap():
+			if i != len(path)-2 {
+				return fmt.Errorf("map field %s must be followed by a key", fieldName)
+			}
+
+			key, err := parseValue(field.MapKey(), path[i+1])
+			if err != nil {
+				return err
+			}
+
+			value, err := parseValue(field.MapValue(), last)
+			if err != nil {
+				return err
+			}
+
+			message.Mutable(field).Map().Set(key.MapKey(), value)
+
+			return nil
+		case field.IsList():
+			if !isLast || field.Message() != nil {
+				return fmt.Errorf("repeated field %s must be set in a JSON body", fieldName)
+			}
+
+			list := message.Mutable(field).List()
+			for _, v := range values {
+				value, err := parseValue(field, v)
+				if err != nil {
+					return err
+				}
+
+				list.Append(value)
+			}
+
+			return nil
+		case field.Message() != nil:
+			if isLast {
+				return fmt.Errorf("field %s is a message, its fields are set with %s.<field>", fieldName, fieldName)
+			}
+
+			message = message.Mutable(field).Message()
+		default:
+			if !isLast {
+				return fmt.Errorf("field %s has no fields", fieldName)
+			}
+
+			value, err := parseValue(field, last)
+			if err != nil {
+				return err
+			}
+
+			message.Set(field, value)
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// parseValue parses the value of a scalar field. Enums are given by their name or number,
+// and bytes are base64 encoded.
+func parseValue(field protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	var err error
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		var v bool
+		if v, err = strconv.ParseBool(s); err == nil {
+			return protoreflect.ValueOfBool(v), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var v int64
+		if v, err = strconv.ParseInt(s, 10, 32); err == nil {
+			return protoreflect.ValueOfInt32(int32(v)), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var v int64
+		if v, err = strconv.ParseInt(s, 10, 64); err == nil {
+			return protoreflect.ValueOfInt64(v), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var v uint64
+		if v, err = strconv.ParseUint(s, 10, 32); err == nil {
+			return protoreflect.ValueOfUint32(uint32(v)), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var v uint64
+		if v, err = strconv.ParseUint(s, 10, 64); err == nil {
+			return protoreflect.ValueOfUint64(v), nil
+		}
+	case protoreflect.FloatKind:
+		var v float64
+		if v, err = strconv.ParseFloat(s, 32); err == nil {
+			return protoreflect.ValueOfFloat32(float32(v)), nil
+		}
+	case protoreflect.DoubleKind:
+		var v float64
+		if v, err = strconv.ParseFloat(s, 64); err == nil {
+			return protoreflect.ValueOfFloat64(v), nil
+		}
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(s)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+
+		var v int64
+		if v, err = strconv.ParseInt(s, 10, 32); err == nil {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
+		}
+
+		err = fmt.Errorf("%q is not a value of %s", s, field.Enum().Name())
+	case protoreflect.BytesKind:
+		var v []byte
+		if v, err = base64.StdEncoding.DecodeString(s); err == nil {
+			return protoreflect.ValueOfBytes(v), nil
+		}
+	default:
+		err = fmt.Errorf("field %s of kind %s can't be set", field.Name(), field.Kind())
+	}
+
+	return protoreflect.Value{}, err
+}
+
+// setDefaultContentType sets the contentType field of message to contentType, if the message
+// has the field and it isn't set.
+func setDefaultContentType(message proto.Message, contentType string) {
+	reflection := message.ProtoReflect()
+	field := reflection.Descriptor().Fields().ByName("contentType")
+	if field == nil || contentType == "" || reflection.Has(field) {
+		return
+	}
+
+	reflection.Set(field, protoreflect.ValueOfString(contentType))
+}
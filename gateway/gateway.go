@@ -0,0 +1,569 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/meateam/upload-service/object"
+	pb "github.com/meateam/upload-service/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultBasePath is the default path under which the RPCs are served, each at the RPC's name.
+	DefaultBasePath = "/v1/"
+
+	// FileField is the name of the file part of a multipart/form-data upload.
+	FileField = "file"
+
+	// DefaultMaxPartSize is the default maximal size of an UploadPart file, the maximal size of an S3 part.
+	DefaultMaxPartSize = 5 << 30 // 5GB
+
+	// maxFieldSize is the maximal size of a multipart/form-data field that isn't the file.
+	maxFieldSize = 1 << 20 // 1MB
+
+	// maxRequestSize is the maximal size of a JSON request body.
+	maxRequestSize = 16 << 20 // 16MB
+)
+
+// marshalOptions are the options of the JSON encoding of responses, which follows the proto3 JSON mapping.
+var marshalOptions = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// route is an RPC served by the gateway.
+type route struct {
+	// methods are the HTTP methods the RPC can be requested with.
+	methods []string
+
+	serve http.HandlerFunc
+}
+
+// Handler is an HTTP handler of a REST/JSON gateway to the Upload service, for clients that can't use gRPC.
+// Every RPC is served at the base path followed by the RPC's name, such as /v1/StatObject.
+//
+// Requests are JSON encoded request messages in a POST request's body, and their fields can also be
+// set by query parameters named by the field's name. Nested fields are named by their path,
+// such as checksum.algorithm, and map entries by the map's name and the entry's key, such as metadata.name.
+// The RPCs that only read, ListObjects, StatObject, GeneratePresignedURL, GetUploadStatus,
+// ListMultipartUploads and DownloadObject, can also be requested with GET.
+// Responses are JSON encoded response messages, and errors are JSON encoded google.rpc.Status
+// messages with the HTTP status of their gRPC code.
+//
+// UploadMedia, UploadMultipart, UploadPart and UploadStream receive the file's data as the raw
+// request body, whose Content-Type is the file's content type if it isn't set, or as the file
+// part of a multipart/form-data body. The request's fields are given by query parameters,
+// or by the form's fields, which must precede the file. UploadMedia, UploadMultipart and
+// UploadStream stream the body into the storage, and UploadPart uploads a single part and
+// responds with its result. JSON request bodies are limited to 16MB.
+// DownloadObject responds with the object's content and its details in the headers,
+// and the other streaming RPCs respond with a newline delimited JSON stream of their messages.
+type Handler struct {
+	server   pb.UploadServer
+	logger   *logrus.Logger
+	basePath string
+	routes   map[string]route
+
+	// maxPartSize is the maximal size of an UploadPart file, which is read to memory.
+	maxPartSize int64
+}
+
+// Verify that Handler implements http.Handler.
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a Handler of the RPCs of server, which is served under basePath.
+// An empty basePath is set to DefaultBasePath.
+func NewHandler(server pb.UploadServer, logger *logrus.Logger, basePath string) *Handler {
+	if basePath == "" {
+		basePath = DefaultBasePath
+	}
+
+	if !strings.HasSuffix(basePath, "/") {
+		basePath += "/"
+	}
+
+	h := &Handler{
+		server:      server,
+		logger:      logger,
+		basePath:    basePath,
+		maxPartSize: DefaultMaxPartSize,
+	}
+
+	post := []string{http.MethodPost}
+	read := []string{http.MethodGet, http.MethodPost}
+	h.routes = map[string]route{
+		"UploadMedia":     {methods: post, serve: h.uploadMedia},
+		"UploadMultipart": {methods: post, serve: h.uploadMultipart},
+		"UploadPart":      {methods: post, serve: h.uploadPart},
+		"UploadStream":    {methods: post, serve: h.uploadStream},
+		"DownloadObject":  {methods: read, serve: h.downloadObject},
+		"UploadInit": {methods: post, serve: h.unary(
+			func() proto.Message { return &pb.UploadInitRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.UploadInit(ctx, request.(*pb.UploadInitRequest))
+			},
+		)},
+		"UploadComplete": {methods: post, serve: h.unary(
+			func() proto.Message { return &pb.UploadCompleteRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.UploadComplete(ctx, request.(*pb.UploadCompleteRequest))
+			},
+		)},
+		"UploadAbort": {methods: post, serve: h.unary(
+			func() proto.Message { return &pb.UploadAbortRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.UploadAbort(ctx, request.(*pb.UploadAbortRequest))
+			},
+		)},
+		"DeleteObjects": {methods: post, serve: h.unary(
+			func() proto.Message { return &pb.DeleteObjectsRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.DeleteObjects(ctx, request.(*pb.DeleteObjectsRequest))
+			},
+		)},
+		"CopyObject": {methods: post, serve: h.unary(
+			func() proto.Message { return &pb.CopyObjectRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.CopyObject(ctx, request.(*pb.CopyObjectRequest))
+			},
+		)},
+		"MoveObject": {methods: post, serve: h.unary(
+			func() proto.Message { return &pb.MoveObjectRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.MoveObject(ctx, request.(*pb.MoveObjectRequest))
+			},
+		)},
+		"ListObjects": {methods: read, serve: h.unary(
+			func() proto.Message { return &pb.ListObjectsRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.ListObjects(ctx, request.(*pb.ListObjectsRequest))
+			},
+		)},
+		"StatObject": {methods: read, serve: h.unary(
+			func() proto.Message { return &pb.StatObjectRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.StatObject(ctx, request.(*pb.StatObjectRequest))
+			},
+		)},
+		"GeneratePresignedURL": {methods: read, serve: h.unary(
+			func() proto.Message { return &pb.GeneratePresignedURLRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.GeneratePresignedURL(ctx, request.(*pb.GeneratePresignedURLRequest))
+			},
+		)},
+		"GetUploadStatus": {methods: read, serve: h.unary(
+			func() proto.Message { return &pb.GetUploadStatusRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.GetUploadStatus(ctx, request.(*pb.GetUploadStatusRequest))
+			},
+		)},
+		"ListMultipartUploads": {methods: read, serve: h.unary(
+			func() proto.Message { return &pb.ListMultipartUploadsRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.ListMultipartUploads(ctx, request.(*pb.ListMultipartUploadsRequest))
+			},
+		)},
+		"AbortMultipartUploads": {methods: post, serve: h.unary(
+			func() proto.Message { return &pb.AbortMultipartUploadsRequest{} },
+			func(ctx context.Context, request proto.Message) (proto.Message, error) {
+				return h.server.AbortMultipartUploads(ctx, request.(*pb.AbortMultipartUploadsRequest))
+			},
+		)},
+		"BatchCopyObjects": {methods: post, serve: h.stream(
+			func() proto.Message { return &pb.BatchObjectsRequest{} },
+			func(request proto.Message, stream *messageStream) error {
+				return h.server.BatchCopyObjects(request.(*pb.BatchObjectsRequest), batchStream{stream})
+			},
+		)},
+		"BatchMoveObjects": {methods: post, serve: h.stream(
+			func() proto.Message { return &pb.BatchObjectsRequest{} },
+			func(request proto.Message, stream *messageStream) error {
+				return h.server.BatchMoveObjects(request.(*pb.BatchObjectsRequest), batchStream{stream})
+			},
+		)},
+		"CopyPrefix": {methods: post, serve: h.stream(
+			func() proto.Message { return &pb.PrefixRequest{} },
+			func(request proto.Message, stream *messageStream) error {
+				return h.server.CopyPrefix(request.(*pb.PrefixRequest), prefixStream{stream})
+			},
+		)},
+		"MovePrefix": {methods: post, serve: h.stream(
+			func() proto.Message { return &pb.PrefixRequest{} },
+			func(request proto.Message, stream *messageStream) error {
+				return h.server.MovePrefix(request.(*pb.PrefixRequest), prefixStream{stream})
+			},
+		)},
+		"DeletePrefix": {methods: post, serve: h.stream(
+			func() proto.Message { return &pb.DeletePrefixRequest{} },
+			func(request proto.Message, stream *messageStream) error {
+				return h.server.DeletePrefix(request.(*pb.DeletePrefixRequest), prefixStream{stream})
+			},
+		)},
+	}
+
+	return h
+}
+
+// SetMaxPartSize sets the maximal size of an UploadPart file, which is read to memory before it's uploaded,
+// a size that isn't positive is set to DefaultMaxPartSize.
+func (h *Handler) SetMaxPartSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxPartSize
+	}
+
+	h.maxPartSize = size
+}
+
+// ServeHTTP serves the RPC named by the request's path.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, h.basePath) {
+		h.writeError(w, status.Errorf(codes.NotFound, "%s is not an RPC path", r.URL.Path))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, h.basePath)
+	rpc, ok := h.routes[name]
+	if !ok {
+		h.writeError(w, status.Errorf(codes.NotFound, "RPC %s does not exist", name))
+		return
+	}
+
+	for _, method := range rpc.methods {
+		if r.Method == method {
+			rpc.serve(w, r)
+			return
+		}
+	}
+
+	w.Header().Set("Allow", strings.Join(rpc.methods, ", "))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	w.Write(marshalStatus(status.Newf(codes.Unimplemented, "method %s is not allowed for RPC %s", r.Method, name)))
+}
+
+// unary returns the HTTP handler of a unary RPC, whose request is created by newRequest and
+// is sent to the RPC by call.
+func (h *Handler) unary(
+	newRequest func() proto.Message,
+	call func(context.Context, proto.Message) (proto.Message, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request := newRequest()
+		if err := decodeRequest(w, r, request); err != nil {
+			h.writeError(w, err)
+			return
+		}
+
+		response, err := call(r.Context(), request)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+
+		h.writeMessage(w, http.StatusOK, response)
+	}
+}
+
+// stream returns the HTTP handler of an RPC that streams its responses, whose request is created
+// by newRequest and is sent to the RPC by call. The responses are written as newline delimited JSON.
+func (h *Handler) stream(
+	newRequest func() proto.Message,
+	call func(proto.Message, *messageStream) error,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		request := newRequest()
+		if err := decodeRequest(w, r, request); err != nil {
+			h.writeError(w, err)
+			return
+		}
+
+		stream := &messageStream{serverStream: serverStream{ctx: r.Context()}, w: w}
+		if err := call(request, stream); err != nil {
+			stream.fail(h, err)
+		}
+	}
+}
+
+// uploadMedia serves UploadMedia, streaming the file from the request's body into UploadStream.
+func (h *Handler) uploadMedia(w http.ResponseWriter, r *http.Request) {
+	request := &pb.UploadMediaRequest{}
+	body, err := uploadBody(r, request)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	location, err := h.streamUpload(r, body, &pb.UploadStreamDetails{
+		Key:         request.GetKey(),
+		Bucket:      request.GetBucket(),
+		ContentType: request.GetContentType(),
+		Checksum:    request.GetChecksum(),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeMessage(w, http.StatusOK, &pb.UploadMediaResponse{Location: location})
+}
+
+// uploadMultipart serves UploadMultipart, streaming the file from the request's body into UploadStream.
+func (h *Handler) uploadMultipart(w http.ResponseWriter, r *http.Request) {
+	request := &pb.UploadMultipartRequest{}
+	body, err := uploadBody(r, request)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if len(request.GetMetadata()) == 0 {
+		h.writeError(w, status.Error(codes.InvalidArgument, "metadata is required"))
+		return
+	}
+
+	location, err := h.streamUpload(r, body, &pb.UploadStreamDetails{
+		Key:         request.GetKey(),
+		Bucket:      request.GetBucket(),
+		ContentType: request.GetContentType(),
+		Metadata:    request.GetMetadata(),
+		Checksum:    request.GetChecksum(),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeMessage(w, http.StatusOK, &pb.UploadMultipartResponse{Location: location})
+}
+
+// uploadPart serves UploadPart with a single part from the request's body, and responds with the
+// part's result. A part that failed to upload responds with the HTTP status of its error.
+func (h *Handler) uploadPart(w http.ResponseWriter, r *http.Request) {
+	request := &pb.UploadPartRequest{}
+	part, err := readUpload(w, r, request, h.maxPartSize)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		h.writeErrorStatus(w, http.StatusRequestEntityTooLarge,
+			status.Errorf(codes.InvalidArgument, "part is larger than %d bytes", tooLarge.Limit))
+		return
+	}
+
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	request.Part = part
+	stream := &uploadPartStream{serverStream: serverStream{ctx: r.Context()}, request: request}
+	if err := h.server.UploadPart(stream); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if stream.response == nil {
+		h.writeError(w, status.Errorf(codes.Internal, "part %d has no result", request.GetPartNumber()))
+		return
+	}
+
+	code := http.StatusOK
+	if partErr := stream.response.GetError(); partErr != nil {
+		code = object.HTTPStatus(status.Error(codes.Code(partErr.GetCode()), partErr.GetMessage()))
+	}
+
+	h.writeMessage(w, code, stream.response)
+}
+
+// uploadStream serves UploadStream, streaming the file from the request's body.
+func (h *Handler) uploadStream(w http.ResponseWriter, r *http.Request) {
+	details := &pb.UploadStreamDetails{}
+	body, err := uploadBody(r, details)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	location, err := h.streamUpload(r, body, details)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeMessage(w, http.StatusOK, &pb.UploadStreamResponse{Location: location})
+}
+
+// streamUpload streams body into UploadStream as the file of details, so the file is never
+// read whole into memory, and returns the uploaded file's location.
+func (h *Handler) streamUpload(r *http.Request, body io.Reader, details *pb.UploadStreamDetails) (string, error) {
+	stream := &uploadStream{serverStream: serverStream{ctx: r.Context()}, details: details, body: body}
+	if err := h.server.UploadStream(stream); err != nil {
+		return "", err
+	}
+
+	return stream.response.GetLocation(), nil
+}
+
+// downloadObject serves DownloadObject, responding with the object's content. The object's range
+// is given by the Range header if the request has no range.
+func (h *Handler) downloadObject(w http.ResponseWriter, r *http.Request) {
+	request := &pb.DownloadObjectRequest{}
+	if err := decodeRequest(w, r, request); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if request.GetRange() == "" {
+		request.Range = r.Header.Get("Range")
+	}
+
+	stream := &downloadStream{serverStream: serverStream{ctx: r.Context()}, w: w}
+	if err := h.server.DownloadObject(request, stream); err != nil {
+		if stream.started {
+			h.logger.Errorf("gateway: failed to download %s/%s: %v", request.GetBucket(), request.GetKey(), err)
+			return
+		}
+
+		h.writeError(w, err)
+	}
+}
+
+// writeMessage responds with status and the JSON encoding of message.
+func (h *Handler) writeMessage(w http.ResponseWriter, code int, message proto.Message) {
+	body, err := marshalOptions.Marshal(message)
+	if err != nil {
+		h.writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// writeError responds with the HTTP status of err and its JSON encoded google.rpc.Status.
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	h.writeErrorStatus(w, object.HTTPStatus(err), err)
+}
+
+// writeErrorStatus responds with the HTTP status code and the JSON encoded google.rpc.Status of err.
+func (h *Handler) writeErrorStatus(w http.ResponseWriter, code int, err error) {
+	if code >= http.StatusInternalServerError {
+		h.logger.Errorf("gateway: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(marshalStatus(status.Convert(err)))
+}
+
+// marshalStatus returns the JSON encoding of a gRPC status, with its details.
+func marshalStatus(st *status.Status) []byte {
+	body, err := marshalOptions.Marshal(st.Proto())
+	if err != nil {
+		// A status whose details can't be encoded is encoded without them.
+		body, _ = marshalOptions.Marshal(status.New(st.Code(), st.Message()).Proto())
+	}
+
+	return body
+}
+
+// decodeRequest decodes the JSON body of a POST request, of up to maxRequestSize bytes, into request,
+// and sets the request's fields named by the query parameters.
+func decodeRequest(w http.ResponseWriter, r *http.Request, request proto.Message) error {
+	if r.Method == http.MethodPost {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to read request body of at most %d bytes: %v", maxRequestSize, err)
+		}
+
+		if len(bytes.TrimSpace(body)) > 0 {
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "" && mediaType != "application/json" {
+				return status.Errorf(codes.InvalidArgument, "request body must be application/json, got %s", mediaType)
+			}
+
+			if err := protojson.Unmarshal(body, request); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+			}
+		}
+	}
+
+	return setFields(request, r.URL.Query())
+}
+
+// readUpload sets the fields of request from an upload request, and returns the uploaded file of up to
+// maxSize bytes, for UploadPart whose part is sent in a single message.
+// A larger file fails with an *http.MaxBytesError.
+func readUpload(w http.ResponseWriter, r *http.Request, request proto.Message, maxSize int64) ([]byte, error) {
+	body, err := uploadBody(r, request)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := ioutil.ReadAll(http.MaxBytesReader(w, ioutil.NopCloser(body), maxSize))
+	if errors.As(err, new(*http.MaxBytesError)) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to read file: %v", err)
+	}
+
+	return file, nil
+}
+
+// uploadBody sets the fields of request from an upload request's query parameters, and returns the
+// request's body. If the body is multipart/form-data, the fields are also set by the form's fields
+// before the file, and the file part is returned. The request's content type is set to the content type
+// of the body or the file if it's not set.
+func uploadBody(r *http.Request, request proto.Message) (io.Reader, error) {
+	if err := setFields(request, r.URL.Query()); err != nil {
+		return nil, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		setDefaultContentType(request, r.Header.Get("Content-Type"))
+		return r.Body, nil
+	}
+
+	form, err := r.MultipartReader()
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid multipart/form-data body: %v", err)
+	}
+
+	for {
+		part, err := form.NextPart()
+		if err == io.EOF {
+			return nil, status.Errorf(codes.InvalidArgument, "multipart/form-data body has no %s field", FileField)
+		}
+
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid multipart/form-data body: %v", err)
+		}
+
+		if part.FormName() == FileField {
+			setDefaultContentType(request, part.Header.Get("Content-Type"))
+			return part, nil
+		}
+
+		value, err := ioutil.ReadAll(io.LimitReader(part, maxFieldSize+1))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to read form field %s: %v", part.FormName(), err)
+		}
+
+		if len(value) > maxFieldSize {
+			return nil, status.Errorf(codes.InvalidArgument, "form field %s exceeds %d bytes", part.FormName(), maxFieldSize)
+		}
+
+		if err := setFields(request, map[string][]string{part.FormName(): {string(value)}}); err != nil {
+			return nil, err
+		}
+	}
+}
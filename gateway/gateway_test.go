@@ -0,0 +1,469 @@
+package gateway_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/meateam/upload-service/gateway"
+	"github.com/meateam/upload-service/object"
+	pb "github.com/meateam/upload-service/proto"
+	"github.com/meateam/upload-service/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestServer creates a gateway server of an upload handler of a new memory backend.
+func newTestServer() *httptest.Server {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	handler := object.NewHandler(object.NewService(storage.NewMemoryBackend()), logger)
+
+	return httptest.NewServer(gateway.NewHandler(handler, logger, ""))
+}
+
+// call sends a request to an RPC of the gateway, and returns its response's status and body.
+func call(t *testing.T, server *httptest.Server, method string, path string, contentType string, body io.Reader) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, server.URL+gateway.DefaultBasePath+path, body)
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s error = %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	return resp.StatusCode, respBody
+}
+
+// callJSON sends a request to an RPC of the gateway and decodes its response into response.
+// Fails the test if the response's status isn't 200 OK.
+func callJSON(t *testing.T, server *httptest.Server, method string, path string, request string, response proto.Message) {
+	t.Helper()
+
+	var body io.Reader
+	if request != "" {
+		body = strings.NewReader(request)
+	}
+
+	code, respBody := call(t, server, method, path, "application/json", body)
+	if code != http.StatusOK {
+		t.Fatalf("%s %s status = %d, want %d, body: %s", method, path, code, http.StatusOK, respBody)
+	}
+
+	if err := protojson.Unmarshal(respBody, response); err != nil {
+		t.Fatalf("%s %s response %s isn't a %T: %v", method, path, respBody, response, err)
+	}
+}
+
+// jsonString returns the JSON encoding of s.
+func jsonString(t *testing.T, s string) string {
+	t.Helper()
+
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	return string(encoded)
+}
+
+// formBody returns a multipart/form-data body of fields followed by a file, and its content type.
+func formBody(t *testing.T, fields [][2]string, file []byte) (io.Reader, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	for _, field := range fields {
+		if err := form.WriteField(field[0], field[1]); err != nil {
+			t.Fatalf("WriteField() error = %v", err)
+		}
+	}
+
+	part, err := form.CreateFormFile(gateway.FileField, "file.txt")
+	if err != nil {
+		t.Fatalf("CreateFormFile() error = %v", err)
+	}
+	part.Write(file)
+	form.Close()
+
+	return body, form.FormDataContentType()
+}
+
+func TestHandler_Upload(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	file := []byte("Hello, World!")
+	tests := []struct {
+		name     string
+		path     string
+		body     func() (io.Reader, string)
+		wantKey  string
+		wantType string
+		wantMeta map[string]string
+	}{
+		{
+			name: "UploadMedia with a raw body",
+			path: "UploadMedia?bucket=gatewaybucket&key=media.txt",
+			body: func() (io.Reader, string) {
+				return bytes.NewReader(file), "text/plain"
+			},
+			wantKey:  "media.txt",
+			wantType: "text/plain",
+		},
+		{
+			name: "UploadMedia with a checksum",
+			path: "UploadMedia?bucket=gatewaybucket&key=checksum.txt&checksum.algorithm=MD5&checksum.value=65a8e27d8879283831b664bd8b7f0ad4",
+			body: func() (io.Reader, string) {
+				return bytes.NewReader(file), "text/plain"
+			},
+			wantKey:  "checksum.txt",
+			wantType: "text/plain",
+		},
+		{
+			name: "UploadMultipart with a form",
+			path: "UploadMultipart",
+			body: func() (io.Reader, string) {
+				return formBody(t, [][2]string{
+					{"bucket", "gatewaybucket"},
+					{"key", "multipart.txt"},
+					{"contentType", "text/markdown"},
+					{"metadata.owner", "user"},
+				}, file)
+			},
+			wantKey:  "multipart.txt",
+			wantType: "text/markdown",
+			wantMeta: map[string]string{"Owner": "user"},
+		},
+		{
+			name: "UploadStream with a form whose file sets the content type",
+			path: "UploadStream?bucket=gatewaybucket",
+			body: func() (io.Reader, string) {
+				return formBody(t, [][2]string{{"key", "stream.txt"}}, file)
+			},
+			wantKey:  "stream.txt",
+			wantType: "application/octet-stream",
+		},
+		{
+			name: "UploadStream with a raw body",
+			path: "UploadStream?bucket=gatewaybucket&key=rawstream.txt&metadata.owner=user",
+			body: func() (io.Reader, string) {
+				return bytes.NewReader(file), "text/csv"
+			},
+			wantKey:  "rawstream.txt",
+			wantType: "text/csv",
+			wantMeta: map[string]string{"Owner": "user"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType := tt.body()
+			code, respBody := call(t, server, http.MethodPost, tt.path, contentType, body)
+			if code != http.StatusOK {
+				t.Fatalf("POST %s status = %d, want %d, body: %s", tt.path, code, http.StatusOK, respBody)
+			}
+
+			stat := &pb.StatObjectResponse{}
+			callJSON(t, server, http.MethodGet, "StatObject?bucket=gatewaybucket&key="+tt.wantKey, "", stat)
+			if stat.GetContentLength() != int64(len(file)) || stat.GetContentType() != tt.wantType {
+				t.Errorf("StatObject = %d bytes of %s, want %d bytes of %s",
+					stat.GetContentLength(), stat.GetContentType(), len(file), tt.wantType)
+			}
+
+			for name, value := range tt.wantMeta {
+				if stat.GetMetadata()[name] != value {
+					t.Errorf("StatObject metadata = %v, want %s = %s", stat.GetMetadata(), name, value)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_ResumableUpload(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	init := &pb.UploadInitResponse{}
+	callJSON(t, server, http.MethodPost, "UploadInit", `{"bucket":"gatewaybucket","key":"resumable.txt","contentType":"text/plain","owner":"user"}`, init)
+
+	query := "?bucket=gatewaybucket&key=resumable.txt&uploadId=" + init.GetUploadId()
+	code, body := call(t, server, http.MethodPost, "UploadPart"+query+"&partNumber=1&owner=other", "", strings.NewReader("data"))
+	if code != http.StatusForbidden {
+		t.Errorf("UploadPart of another owner status = %d, want %d, body: %s", code, http.StatusForbidden, body)
+	}
+
+	part := &pb.UploadPartResponse{}
+	code, body = call(t, server, http.MethodPost, "UploadPart"+query+"&partNumber=1&owner=user", "", strings.NewReader("data"))
+	if code != http.StatusOK {
+		t.Fatalf("UploadPart status = %d, want %d, body: %s", code, http.StatusOK, body)
+	}
+
+	if err := protojson.Unmarshal(body, part); err != nil || part.GetETag() == "" || part.GetSize() != 4 {
+		t.Fatalf("UploadPart response = %s, want a part of 4 bytes with an ETag", body)
+	}
+
+	uploadStatus := &pb.GetUploadStatusResponse{}
+	callJSON(t, server, http.MethodGet, "GetUploadStatus"+query+"&owner=user", "", uploadStatus)
+	if len(uploadStatus.GetParts()) != 1 || uploadStatus.GetParts()[0].GetETag() != part.GetETag() {
+		t.Errorf("GetUploadStatus parts = %v, want the uploaded part", uploadStatus.GetParts())
+	}
+
+	complete := &pb.UploadCompleteResponse{}
+	callJSON(t, server, http.MethodPost, "UploadComplete", `{"bucket":"gatewaybucket","key":"resumable.txt","owner":"user","uploadId":"`+
+		init.GetUploadId()+`","parts":[{"partNumber":1,"eTag":`+jsonString(t, part.GetETag())+`}]}`, complete)
+	if complete.GetContentLength() != 4 {
+		t.Errorf("UploadComplete ContentLength = %d, want 4", complete.GetContentLength())
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+gateway.DefaultBasePath+"DownloadObject?bucket=gatewaybucket&key=resumable.txt", nil)
+	req.Header.Set("Range", "bytes=1-2")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("DownloadObject error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	content, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusPartialContent || string(content) != "at" || resp.Header.Get("Content-Type") != "text/plain" {
+		t.Errorf("DownloadObject = %d %q of %s, want %d %q of text/plain",
+			resp.StatusCode, content, resp.Header.Get("Content-Type"), http.StatusPartialContent, "at")
+	}
+}
+
+func TestHandler_UploadPartTooLarge(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	handler := gateway.NewHandler(object.NewHandler(object.NewService(storage.NewMemoryBackend()), logger), logger, "")
+	handler.SetMaxPartSize(4)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	init := &pb.UploadInitResponse{}
+	callJSON(t, server, http.MethodPost, "UploadInit", `{"bucket":"gatewaybucket","key":"large.txt","owner":"user"}`, init)
+
+	query := "UploadPart?bucket=gatewaybucket&key=large.txt&partNumber=1&owner=user&uploadId=" + init.GetUploadId()
+	code, body := call(t, server, http.MethodPost, query, "", strings.NewReader("data"))
+	if code != http.StatusOK {
+		t.Fatalf("UploadPart of the maximal size status = %d, want %d, body: %s", code, http.StatusOK, body)
+	}
+
+	code, body = call(t, server, http.MethodPost, query, "", strings.NewReader("large"))
+	if code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("UploadPart of a larger part status = %d, want %d, body: %s", code, http.StatusRequestEntityTooLarge, body)
+	}
+
+	st := &spb.Status{}
+	if err := protojson.Unmarshal(body, st); err != nil || codes.Code(st.GetCode()) != codes.InvalidArgument {
+		t.Errorf("UploadPart of a larger part error = %s, want %v", body, codes.InvalidArgument)
+	}
+}
+
+func TestHandler_Stream(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	for _, key := range []string{"dir/a", "dir/b", "other"} {
+		code, body := call(t, server, http.MethodPost, "UploadMedia?bucket=gatewaybucket&key="+key, "", strings.NewReader(key))
+		if code != http.StatusOK {
+			t.Fatalf("UploadMedia status = %d, body: %s", code, body)
+		}
+	}
+
+	code, body := call(t, server, http.MethodPost, "DeletePrefix", "application/json", strings.NewReader(`{"bucket":"gatewaybucket","prefix":"dir/"}`))
+	if code != http.StatusOK {
+		t.Fatalf("DeletePrefix status = %d, want %d, body: %s", code, http.StatusOK, body)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	progress := &pb.PrefixProgress{}
+	if err := protojson.Unmarshal([]byte(lines[len(lines)-1]), progress); err != nil {
+		t.Fatalf("DeletePrefix last line %s isn't a progress: %v", lines[len(lines)-1], err)
+	}
+
+	if !progress.GetDone() || progress.GetProcessed() != 2 {
+		t.Errorf("DeletePrefix progress = %v, want 2 objects processed", progress)
+	}
+
+	list := &pb.ListObjectsResponse{}
+	callJSON(t, server, http.MethodGet, "ListObjects?bucket=gatewaybucket", "", list)
+
+	var keys []string
+	for _, obj := range list.GetObjects() {
+		keys = append(keys, obj.GetKey())
+	}
+
+	if want := []string{"other"}; !cmp.Equal(keys, want) {
+		t.Errorf("ListObjects keys = %v, want %v", keys, want)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	// Missing objects are looked up in a bucket that exists, reading doesn't create it.
+	code, body := call(t, server, http.MethodPost, "UploadMedia?bucket=gatewaybucket&key=existing.txt", "", strings.NewReader("data"))
+	if code != http.StatusOK {
+		t.Fatalf("UploadMedia status = %d, want %d, body: %s", code, http.StatusOK, body)
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantCode    codes.Code
+		wantReason  string
+	}{
+		{
+			name:       "unknown RPC",
+			method:     http.MethodPost,
+			path:       "Unknown",
+			wantStatus: http.StatusNotFound,
+			wantCode:   codes.NotFound,
+		},
+		{
+			name:       "GET of an RPC that writes",
+			method:     http.MethodGet,
+			path:       "UploadInit?bucket=gatewaybucket&key=file",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantCode:   codes.Unimplemented,
+		},
+		{
+			name:        "invalid JSON body",
+			method:      http.MethodPost,
+			path:        "UploadInit",
+			contentType: "application/json",
+			body:        `{"bucket":`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codes.InvalidArgument,
+		},
+		{
+			name:        "body that isn't JSON",
+			method:      http.MethodPost,
+			path:        "UploadInit",
+			contentType: "text/plain",
+			body:        "bucket",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codes.InvalidArgument,
+		},
+		{
+			name:       "unknown query parameter",
+			method:     http.MethodGet,
+			path:       "StatObject?bucket=gatewaybucket&missing=1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "invalid query parameter value",
+			method:     http.MethodGet,
+			path:       "ListObjects?bucket=gatewaybucket&pageSize=many",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "repeated message query parameter",
+			method:     http.MethodPost,
+			path:       "UploadComplete?parts=1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:        "form without a file",
+			method:      http.MethodPost,
+			path:        "UploadMedia",
+			contentType: "multipart/form-data; boundary=boundary",
+			body:        "--boundary\r\nContent-Disposition: form-data; name=\"key\"\r\n\r\nfile\r\n--boundary--\r\n",
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codes.InvalidArgument,
+		},
+		{
+			name:        "request body too large",
+			method:      http.MethodPost,
+			path:        "DeleteObjects",
+			contentType: "application/json",
+			body:        `{"bucket":"gatewaybucket"}` + strings.Repeat(" ", 16<<20),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codes.InvalidArgument,
+		},
+		{
+			name:       "UploadMultipart without metadata",
+			method:     http.MethodPost,
+			path:       "UploadMultipart?bucket=gatewaybucket&key=nometadata.txt",
+			body:       "data",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "missing object",
+			method:     http.MethodGet,
+			path:       "StatObject?bucket=gatewaybucket&key=missing",
+			wantStatus: http.StatusNotFound,
+			wantCode:   codes.NotFound,
+			wantReason: object.ReasonObjectNotFound,
+		},
+		{
+			name:       "download of a missing object",
+			method:     http.MethodGet,
+			path:       "DownloadObject?bucket=gatewaybucket&key=missing",
+			wantStatus: http.StatusNotFound,
+			wantCode:   codes.NotFound,
+			wantReason: object.ReasonObjectNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := call(t, server, tt.method, tt.path, tt.contentType, strings.NewReader(tt.body))
+			if code != tt.wantStatus {
+				t.Errorf("%s %s status = %d, want %d, body: %s", tt.method, tt.path, code, tt.wantStatus, body)
+			}
+
+			st := &spb.Status{}
+			if err := protojson.Unmarshal(body, st); err != nil {
+				t.Fatalf("error body %s isn't a google.rpc.Status: %v", body, err)
+			}
+
+			if codes.Code(st.GetCode()) != tt.wantCode {
+				t.Errorf("error code = %v, want %v", codes.Code(st.GetCode()), tt.wantCode)
+			}
+
+			if tt.wantReason == "" {
+				return
+			}
+
+			info := &errdetails.ErrorInfo{}
+			if len(st.GetDetails()) == 0 || st.GetDetails()[0].UnmarshalTo(info) != nil || info.GetReason() != tt.wantReason {
+				t.Errorf("error details = %v, want reason %s", st.GetDetails(), tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	pb "github.com/meateam/upload-service/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// uploadChunkSize is the size of the chunks UploadStream receives from a request's body.
+const uploadChunkSize = 1 << 20 // 1MB
+
+// serverStream is a grpc.ServerStream of an HTTP request, which is sent to and received from
+// by the typed Send and Recv methods of the streams that embed it.
+type serverStream struct {
+	ctx context.Context
+}
+
+// SetHeader does nothing, the gateway doesn't send gRPC metadata.
+func (s *serverStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+// SendHeader does nothing, the gateway doesn't send gRPC metadata.
+func (s *serverStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+// SetTrailer does nothing, the gateway doesn't send gRPC metadata.
+func (s *serverStream) SetTrailer(metadata.MD) {}
+
+// Context returns the context of the HTTP request.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg is unimplemented, messages are sent with the stream's Send method.
+func (s *serverStream) SendMsg(interface{}) error {
+	return status.Error(codes.Unimplemented, "SendMsg is not supported by the gateway")
+}
+
+// RecvMsg is unimplemented, messages are received with the stream's Recv method.
+func (s *serverStream) RecvMsg(interface{}) error {
+	return status.Error(codes.Unimplemented, "RecvMsg is not supported by the gateway")
+}
+
+// uploadPartStream is an UploadPart stream of a single part.
+type uploadPartStream struct {
+	serverStream
+	request  *pb.UploadPartRequest
+	response *pb.UploadPartResponse
+}
+
+// Recv returns the stream's part, and io.EOF after it.
+func (s *uploadPartStream) Recv() (*pb.UploadPartRequest, error) {
+	if s.request == nil {
+		return nil, io.EOF
+	}
+
+	request := s.request
+	s.request = nil
+
+	return request, nil
+}
+
+// Send keeps the part's result.
+func (s *uploadPartStream) Send(response *pb.UploadPartResponse) error {
+	s.response = response
+	return nil
+}
+
+// uploadStream is an UploadStream stream of a file's details, followed by the chunks of its body.
+type uploadStream struct {
+	serverStream
+	details  *pb.UploadStreamDetails
+	body     io.Reader
+	err      error
+	response *pb.UploadStreamResponse
+}
+
+// Recv returns the file's details, then the chunks of the body and io.EOF once it's read.
+func (s *uploadStream) Recv() (*pb.UploadStreamRequest, error) {
+	if s.details != nil {
+		details := s.details
+		s.details = nil
+
+		return &pb.UploadStreamRequest{Data: &pb.UploadStreamRequest_Details{Details: details}}, nil
+	}
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	chunk := make([]byte, uploadChunkSize)
+	n, err := io.ReadFull(s.body, chunk)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	// The error is returned after the data that was read before it.
+	s.err = err
+	if n == 0 {
+		return nil, s.err
+	}
+
+	return &pb.UploadStreamRequest{Data: &pb.UploadStreamRequest_Chunk{Chunk: chunk[:n]}}, nil
+}
+
+// SendAndClose keeps the upload's response.
+func (s *uploadStream) SendAndClose(response *pb.UploadStreamResponse) error {
+	s.response = response
+	return nil
+}
+
+// downloadStream is a DownloadObject stream that writes the object's content to the HTTP response.
+type downloadStream struct {
+	serverStream
+	w       http.ResponseWriter
+	started bool
+}
+
+// Send writes a chunk of the object. The object's details in the first message are written as
+// the response's headers, and its user metadata as X-Object-Meta- headers.
+func (s *downloadStream) Send(response *pb.DownloadObjectResponse) error {
+	if !s.started {
+		header := s.w.Header()
+		header.Set("Content-Type", response.GetContentType())
+		header.Set("Content-Length", strconv.FormatInt(response.GetContentLength(), 10))
+		header.Set("ETag", response.GetETag())
+		for name, value := range response.GetMetadata() {
+			header.Set("X-Object-Meta-"+name, value)
+		}
+
+		if checksum := response.GetChecksum(); checksum != nil {
+			header.Set("X-Object-Checksum", checksum.GetAlgorithm().String()+" "+checksum.GetValue())
+		}
+
+		code := http.StatusOK
+		if response.GetContentRange() != "" {
+			header.Set("Content-Range", response.GetContentRange())
+			code = http.StatusPartialContent
+		}
+
+		s.w.WriteHeader(code)
+		s.started = true
+	}
+
+	_, err := s.w.Write(response.GetChunk())
+
+	return err
+}
+
+// messageStream is a stream of response messages, written as newline delimited JSON.
+type messageStream struct {
+	serverStream
+	w       http.ResponseWriter
+	started bool
+}
+
+// send writes a message of the stream, and flushes it to the client.
+func (s *messageStream) send(message proto.Message) error {
+	body, err := marshalOptions.Marshal(message)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode response: %v", err)
+	}
+
+	if !s.started {
+		s.w.Header().Set("Content-Type", "application/x-ndjson")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+
+	if _, err := s.w.Write(append(body, '\n')); err != nil {
+		return err
+	}
+
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+// fail responds with an error of the RPC. An error after the stream started is written as
+// the stream's last message, an object whose error field is the error's google.rpc.Status.
+func (s *messageStream) fail(h *Handler, err error) {
+	if !s.started {
+		h.writeError(s.w, err)
+		return
+	}
+
+	h.logger.Errorf("gateway: stream failed: %v", err)
+	s.w.Write([]byte(`{"error":`))
+	s.w.Write(marshalStatus(status.Convert(err)))
+	s.w.Write([]byte("}\n"))
+}
+
+// batchStream is a BatchCopyObjects or BatchMoveObjects stream.
+type batchStream struct {
+	*messageStream
+}
+
+// Send writes the result of an object of the batch.
+func (s batchStream) Send(result *pb.BatchObjectResult) error {
+	return s.send(result)
+}
+
+// prefixStream is a CopyPrefix, MovePrefix or DeletePrefix stream.
+type prefixStream struct {
+	*messageStream
+}
+
+// Send writes the progress of the prefix operation.
+func (s prefixStream) Send(progress *pb.PrefixProgress) error {
+	return s.send(progress)
+}
@@ -10,8 +10,11 @@ require (
 	github.com/meateam/elasticsearch-logger v1.1.3-0.20190901111807-4e8b84fb9fda
 	github.com/sirupsen/logrus v1.4.2
 	github.com/spf13/viper v1.4.0
+	go.elastic.co/apm v1.5.0
 	go.elastic.co/apm/module/apmhttp v1.5.0
+	go.etcd.io/bbolt v1.3.5
 	golang.org/x/net v0.0.0-20190912160710-24e19bdeb0f2 // indirect
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.27.0
 	google.golang.org/protobuf v1.25.0
 )
@@ -23,3 +26,11 @@ replace github.com/meateam/upload-service/object => ./object
 replace github.com/meateam/upload-service/internal/test => ./internal/test
 
 replace github.com/meateam/upload-service/server => ./server
+
+replace github.com/meateam/upload-service/session => ./session
+
+replace github.com/meateam/upload-service/storage => ./storage
+
+replace github.com/meateam/upload-service/tus => ./tus
+
+replace github.com/meateam/upload-service/gateway => ./gateway
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+
+	"github.com/meateam/upload-service/server"
+	"github.com/meateam/upload-service/session"
+	"github.com/meateam/upload-service/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+// Server is an UploadServer on top of an in-memory storage backend, served over bufconn,
+// used to test the upload service without any external services.
+type Server struct {
+	*server.UploadServer
+	backend  *storage.MemoryBackend
+	listener *bufconn.Listener
+}
+
+// NewServer creates an UploadServer with an in-memory storage backend and session store,
+// and serves it over bufconn.
+// If logger is nil then the server's logs are discarded.
+func NewServer(logger *logrus.Logger) *Server {
+	if logger == nil {
+		logger = logrus.New()
+		logger.SetOutput(ioutil.Discard)
+	}
+
+	backend := storage.NewMemoryBackend()
+	testServer := &Server{
+		UploadServer: server.NewServerWithSessionStore(logger, backend, session.NewMemoryStore()),
+		backend:      backend,
+		listener:     bufconn.Listen(bufSize),
+	}
+
+	go testServer.Serve(testServer.listener)
+
+	return testServer
+}
+
+// GetBackend returns the in-memory storage backend of the server.
+func (s *Server) GetBackend() *storage.MemoryBackend {
+	return s.backend
+}
+
+// Dialer dials the server over bufconn, it can be used with grpc.WithContextDialer.
+func (s *Server) Dialer(context.Context, string) (net.Conn, error) {
+	return s.listener.Dial()
+}
+
+// Dial creates a client connection to the server.
+func (s *Server) Dial(ctx context.Context) (*grpc.ClientConn, error) {
+	return grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(s.Dialer), grpc.WithInsecure())
+}
@@ -1,7 +1,23 @@
 package main
 
-import "github.com/meateam/upload-service/server"
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/meateam/upload-service/server"
+)
 
 func main() {
-	server.NewServer(nil).Serve(nil)
+	uploadServer := server.NewServer(nil)
+
+	// Stop the server gracefully when the process is interrupted or terminated.
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+		uploadServer.GracefulStop()
+	}()
+
+	uploadServer.Serve(nil)
 }
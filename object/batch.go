@@ -0,0 +1,135 @@
+package object
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+const (
+	// DefaultBatchConcurrency is the default limit of concurrent copies or moves of a batch.
+	DefaultBatchConcurrency = 16
+
+	// MaxBatchItems is the maximal number of objects in a batch.
+	MaxBatchItems = 10000
+)
+
+// BatchItem is an object to copy or move in a batch, from the source bucket and key
+// to the destination bucket and key.
+type BatchItem struct {
+	BucketSrc  string
+	BucketDest string
+	KeySrc     string
+	KeyDest    string
+}
+
+// BatchResult is the result of copying or moving an object in a batch.
+type BatchResult struct {
+	// Index is the index of the object in the batch.
+	Index int
+
+	// Item is the object that was copied or moved.
+	Item BatchItem
+
+	// MoveState is the final state of a move, unset for copies.
+	MoveState MoveState
+
+	// Err is the error of the copy or move, nil if it succeeded.
+	Err error
+}
+
+// BatchCopyObjects copies the batch's objects, up to concurrency objects concurrently,
+// and sends the result of each object to the returned channel as soon as it's copied.
+// The channel is closed once all of the objects were copied, or ctx is done, in which case
+// the objects that weren't copied yet aren't sent.
+func (s *Service) BatchCopyObjects(ctx aws.Context, items []BatchItem, concurrency int) (<-chan *BatchResult, error) {
+	return s.runBatch(ctx, items, concurrency, s.copyBatchItem)
+}
+
+// BatchMoveObjects moves the batch's objects, up to concurrency objects concurrently,
+// and sends the result of each object to the returned channel as soon as it's moved.
+// Each object is moved the same as MoveObject moves it, and its result holds the move's final state.
+// The channel is closed once all of the objects were moved, or ctx is done, in which case
+// the objects that weren't moved yet aren't sent.
+func (s *Service) BatchMoveObjects(ctx aws.Context, items []BatchItem, concurrency int) (<-chan *BatchResult, error) {
+	return s.runBatch(ctx, items, concurrency, s.moveBatchItem)
+}
+
+// copyBatchItem copies the object of result's item and sets result's error.
+func (s *Service) copyBatchItem(ctx aws.Context, result *BatchResult) {
+	_, result.Err = s.CopyObject(
+		ctx,
+		aws.String(result.Item.BucketSrc),
+		aws.String(result.Item.BucketDest),
+		aws.String(result.Item.KeySrc),
+		aws.String(result.Item.KeyDest),
+	)
+}
+
+// moveBatchItem moves the object of result's item and sets result's move state and error.
+func (s *Service) moveBatchItem(ctx aws.Context, result *BatchResult) {
+	move, err := s.MoveObject(
+		ctx,
+		aws.String(result.Item.BucketSrc),
+		aws.String(result.Item.BucketDest),
+		aws.String(result.Item.KeySrc),
+		aws.String(result.Item.KeyDest),
+	)
+	result.MoveState = move.State
+	result.Err = err
+}
+
+// runBatch validates the batch's items and runs op on each of them, up to concurrency
+// items concurrently, and sends each item's result to the returned channel once op returns.
+// Concurrency lower than 1 is set to DefaultBatchConcurrency.
+func (s *Service) runBatch(
+	ctx aws.Context,
+	items []BatchItem,
+	concurrency int,
+	op func(ctx aws.Context, result *BatchResult),
+) (<-chan *BatchResult, error) {
+	if len(items) == 0 {
+		return nil, invalidArgument("items are required")
+	}
+
+	if len(items) > MaxBatchItems {
+		return nil, invalidArgument("batch has %d items, at most %d are allowed", len(items), MaxBatchItems)
+	}
+
+	if concurrency < 1 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make(chan *BatchResult)
+	go func() {
+		defer close(results)
+
+		sem := make(chan struct{}, concurrency)
+		wg := sync.WaitGroup{}
+
+		defer wg.Wait()
+
+		for i, item := range items {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Add(1)
+			go func(result *BatchResult) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				op(ctx, result)
+
+				select {
+				case results <- result:
+				case <-ctx.Done():
+				}
+			}(&BatchResult{Index: i, Item: item})
+		}
+	}()
+
+	return results, nil
+}
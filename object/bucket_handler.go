@@ -0,0 +1,104 @@
+package object
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/meateam/upload-service/bucket"
+	pb "github.com/meateam/upload-service/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// BucketHandler handles bucket administration requests. Its errors are *Error,
+// the same as the upload handler's.
+type BucketHandler struct {
+	service *bucket.Service
+	logger  *logrus.Logger
+}
+
+// NewBucketHandler creates a BucketHandler and returns it.
+func NewBucketHandler(service *bucket.Service, logger *logrus.Logger) *BucketHandler {
+	return &BucketHandler{service: service, logger: logger}
+}
+
+// GetService returns the internal bucket service.
+func (h BucketHandler) GetService() *bucket.Service {
+	return h.service
+}
+
+// ListBuckets is the request handler for listing all of the buckets.
+func (h BucketHandler) ListBuckets(
+	ctx context.Context,
+	request *pb.ListBucketsRequest,
+) (*pb.ListBucketsResponse, error) {
+	buckets, err := h.service.ListBuckets(ctx)
+	if err != nil {
+		return nil, wrapError(err, "failed to list buckets")
+	}
+
+	response := &pb.ListBucketsResponse{Buckets: make([]*pb.Bucket, 0, len(buckets))}
+	for _, bucket := range buckets {
+		response.Buckets = append(response.Buckets, &pb.Bucket{
+			Name:         aws.StringValue(bucket.Name),
+			CreationDate: aws.TimeValue(bucket.CreationDate).Unix(),
+		})
+	}
+
+	return response, nil
+}
+
+// CreateBucket is the request handler for creating a bucket.
+// Responds with an AlreadyExists status if the bucket already exists.
+func (h BucketHandler) CreateBucket(
+	ctx context.Context,
+	request *pb.CreateBucketRequest,
+) (*pb.CreateBucketResponse, error) {
+	if request.GetBucket() == "" {
+		return nil, invalidArgument("bucket name is required")
+	}
+
+	if _, err := h.service.CreateBucket(ctx, aws.String(request.GetBucket())); err != nil {
+		return nil, wrapError(err, "failed to create bucket %s", request.GetBucket())
+	}
+
+	return &pb.CreateBucketResponse{Bucket: h.service.NormalizeCephBucketName(request.GetBucket())}, nil
+}
+
+// DeleteBucket is the request handler for deleting a bucket, and emptying it first if force is set.
+// Responds with a NotFound status if the bucket doesn't exist, and a FailedPrecondition status
+// if the bucket is not empty and force is not set.
+func (h BucketHandler) DeleteBucket(
+	ctx context.Context,
+	request *pb.DeleteBucketRequest,
+) (*pb.DeleteBucketResponse, error) {
+	deletedObjects, abortedUploads, err := h.service.DeleteBucket(
+		ctx,
+		aws.String(request.GetBucket()),
+		request.GetForce(),
+	)
+	if err != nil {
+		return nil, wrapBucketError(err, "failed to delete bucket %s", request.GetBucket())
+	}
+
+	return &pb.DeleteBucketResponse{DeletedObjects: deletedObjects, AbortedUploads: abortedUploads}, nil
+}
+
+// GetBucketInfo is the request handler for getting a bucket's details.
+// Responds with a NotFound status if the bucket doesn't exist.
+func (h BucketHandler) GetBucketInfo(
+	ctx context.Context,
+	request *pb.GetBucketInfoRequest,
+) (*pb.GetBucketInfoResponse, error) {
+	info, err := h.service.GetBucketInfo(ctx, aws.String(request.GetBucket()))
+	if err != nil {
+		return nil, wrapBucketError(err, "failed to get bucket %s", request.GetBucket())
+	}
+
+	return &pb.GetBucketInfoResponse{
+		Name:         info.Name,
+		CreationDate: info.CreationDate.Unix(),
+		ObjectCount:  info.ObjectCount,
+		Size:         info.Size,
+		UploadCount:  info.UploadCount,
+	}, nil
+}
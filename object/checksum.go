@@ -0,0 +1,132 @@
+package object
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"google.golang.org/grpc/codes"
+)
+
+// Checksum algorithms of uploaded content.
+const (
+	ChecksumMD5    = "MD5"
+	ChecksumSHA256 = "SHA256"
+	ChecksumCRC32C = "CRC32C"
+)
+
+// checksumMetadataPrefix is the prefix of the metadata key an object's verified checksum
+// is stored under, followed by the checksum's algorithm.
+const checksumMetadataPrefix = "Checksum-"
+
+// checksumAlgorithms are the supported checksum algorithms, in order of preference
+// when an object has more than one checksum.
+var checksumAlgorithms = []string{ChecksumSHA256, ChecksumMD5, ChecksumCRC32C}
+
+// checksumRegexps match the hex encoded checksums of each algorithm.
+var checksumRegexps = map[string]*regexp.Regexp{
+	ChecksumMD5:    regexp.MustCompile(`^[0-9a-fA-F]{32}$`),
+	ChecksumSHA256: regexp.MustCompile(`^[0-9a-fA-F]{64}$`),
+	ChecksumCRC32C: regexp.MustCompile(`^[0-9a-fA-F]{8}$`),
+}
+
+// crc32cTable is the Castagnoli polynomial table of CRC32C checksums.
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Checksum is a checksum of content.
+type Checksum struct {
+	// Algorithm is the checksum's algorithm, one of the Checksum constants.
+	Algorithm string
+
+	// Value is the hex encoded checksum.
+	Value string
+}
+
+// validate returns an error if the checksum's algorithm isn't supported or its value is invalid.
+func (c *Checksum) validate() error {
+	valueRegexp, ok := checksumRegexps[c.Algorithm]
+	if !ok {
+		return invalidArgument("checksum algorithm must be %s, %s or %s", ChecksumMD5, ChecksumSHA256, ChecksumCRC32C)
+	}
+
+	if !valueRegexp.MatchString(c.Value) {
+		return invalidArgument("checksum %s is invalid, expected a hex encoded %s", c.Value, c.Algorithm)
+	}
+
+	return nil
+}
+
+// newHash returns a hash that computes checksums of the checksum's algorithm.
+func (c *Checksum) newHash() hash.Hash {
+	switch c.Algorithm {
+	case ChecksumMD5:
+		return md5.New()
+	case ChecksumCRC32C:
+		return crc32.New(crc32cTable)
+	default:
+		return sha256.New()
+	}
+}
+
+// verify returns a DataLoss error if the checksum doesn't match the checksum computed
+// by h, which was created by newHash. subject is what the checksum was computed of.
+func (c *Checksum) verify(h hash.Hash, subject string) error {
+	actual := hex.EncodeToString(h.Sum(nil))
+	if strings.EqualFold(actual, c.Value) {
+		return nil
+	}
+
+	violation := Violation{
+		Type:        "CHECKSUM_MISMATCH",
+		Subject:     subject,
+		Description: fmt.Sprintf("%s has %s checksum %s, expected %s", subject, c.Algorithm, actual, c.Value),
+	}
+
+	return &Error{
+		Code:       codes.DataLoss,
+		Reason:     ReasonChecksumMismatch,
+		Message:    fmt.Sprintf("checksum mismatch: %s", violation.Description),
+		Metadata:   map[string]string{"algorithm": c.Algorithm},
+		Violations: []Violation{violation},
+	}
+}
+
+// metadataKey returns the metadata key the checksum is stored under.
+func (c *Checksum) metadataKey() string {
+	return http.CanonicalHeaderKey(checksumMetadataPrefix + c.Algorithm)
+}
+
+// validateMetadata returns an error if metadata given by a client has a key reserved for
+// verified checksums, so an unverified checksum isn't stored as if it was verified.
+func validateMetadata(metadata map[string]*string) error {
+	for key := range metadata {
+		if len(key) >= len(checksumMetadataPrefix) && strings.EqualFold(key[:len(checksumMetadataPrefix)], checksumMetadataPrefix) {
+			return invalidArgument("metadata key %s is reserved for verified checksums", key)
+		}
+	}
+
+	return nil
+}
+
+// ChecksumFromMetadata returns the verified checksum stored in an object's metadata,
+// or nil if it has none.
+func ChecksumFromMetadata(metadata map[string]*string) *Checksum {
+	for _, algorithm := range checksumAlgorithms {
+		checksum := &Checksum{Algorithm: algorithm}
+		for key, value := range metadata {
+			if strings.EqualFold(key, checksum.metadataKey()) && aws.StringValue(value) != "" {
+				checksum.Value = aws.StringValue(value)
+				return checksum
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package object
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// MaxCopyObjectSize is the maximal size of an object that S3 copies with a single request,
+	// larger objects are copied in parts.
+	MaxCopyObjectSize = 5 << 30 // 5GB
+
+	// DefaultCopyPartSize is the default size of the parts of a multipart copy.
+	DefaultCopyPartSize = 512 << 20 // 512MB
+
+	// minCopyPartSize is the minimal size of a part, other than the last, in a multipart upload.
+	minCopyPartSize = 5 << 20 // 5MB
+
+	// maxCopyParts is the maximal number of parts in a multipart upload.
+	maxCopyParts = 10000
+
+	// copyPartConcurrency is the maximal number of parts of a multipart copy copied concurrently.
+	copyPartConcurrency = 8
+
+	// cleanupTimeout is the time allowed to clean up after a failed operation, such as aborting
+	// a failed copy's upload. Cleanups aren't bound to the request, which may be done already.
+	cleanupTimeout = 30 * time.Second
+)
+
+// CopyVerification is how a copy was verified against its source.
+type CopyVerification string
+
+// The verifications of a copy.
+const (
+	// CopyVerifiedSize is the verification of a copy whose source has no stored checksum, and
+	// whose md5 sum can't be compared by ETag since the source or the copy was assembled from
+	// parts, so only the copy's size was verified.
+	CopyVerifiedSize CopyVerification = "SIZE_ONLY"
+
+	// CopyVerifiedMD5 is the verification of a copy whose size and md5 sum, which is the ETag
+	// of an object that wasn't uploaded in parts, were verified.
+	CopyVerifiedMD5 CopyVerification = "SIZE_AND_MD5"
+
+	// CopyVerifiedChecksum is the verification of a copy whose size and the checksum stored
+	// in its source's metadata were verified.
+	CopyVerifiedChecksum CopyVerification = "SIZE_AND_CHECKSUM"
+)
+
+// copyVerified copies the source object, whose HeadObject output is source, to the destination
+// bucket and key and verifies the copy with verifyCopy. A copy that doesn't match its source
+// fails with DataLoss and is left in place. Returns how the copy was verified.
+func (s *Service) copyVerified(
+	ctx aws.Context,
+	source *s3.HeadObjectOutput,
+	bucketSrc *string,
+	bucketDest *string,
+	keySrc *string,
+	keyDest *string,
+) (CopyVerification, error) {
+	// Parse the location of the object to URL
+	objectToCopy := url.QueryEscape(*bucketSrc + "/" + *keySrc)
+
+	// S3 copies objects larger than 5GB only in parts.
+	// A copy assembled from parts has an ETag of its own, so it's compared to the source's
+	// only when it's copied in a single request.
+	var copyETag string
+	if aws.Int64Value(source.ContentLength) > s.copyThreshold {
+		if err := s.copyObjectMultipart(ctx, source, objectToCopy, bucketDest, keyDest); err != nil {
+			return "", wrapError(err, "failed to copy object")
+		}
+	} else {
+		copyObjectinput := &s3.CopyObjectInput{
+			Bucket:     bucketDest,
+			CopySource: aws.String(objectToCopy), // (CopySource field expected url)
+			Key:        keyDest,
+		}
+
+		if _, err := s.backend.CopyObject(ctx, copyObjectinput); err != nil {
+			return "", wrapError(err, "failed to copy object")
+		}
+
+		// A single part copy's ETag is the md5 sum of its content, the same as
+		// the source's ETag, unless the source was uploaded in parts.
+		if !isMultipartETag(source.ETag) {
+			copyETag = aws.StringValue(source.ETag)
+		}
+	}
+
+	verification, err := s.verifyCopy(ctx, source, copyETag, bucketDest, keyDest)
+	if err != nil {
+		return "", wrapError(
+			err,
+			"failed to copy object %s from source bucket, %s, because something went wrong in the process of copying the object to bucket %s",
+			*keySrc,
+			*bucketSrc,
+			*bucketDest)
+	}
+
+	return verification, nil
+}
+
+// copyObjectMultipart copies the source object in parts with UploadPartCopy, copying up to
+// copyPartConcurrency parts concurrently. copySource is the url encoded "<bucket>/<key>" of
+// the source. Each part is copied only if the source's ETag didn't change since source was
+// fetched. The upload is aborted if the copy fails or ctx is done before every part is copied.
+func (s *Service) copyObjectMultipart(
+	ctx aws.Context,
+	source *s3.HeadObjectOutput,
+	copySource string,
+	bucket *string,
+	key *string,
+) error {
+	upload, err := s.backend.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      bucket,
+		Key:         key,
+		ContentType: source.ContentType,
+		Metadata:    source.Metadata,
+	})
+	if err != nil {
+		return wrapError(err, "failed to init copy to %s/%s", *bucket, *key)
+	}
+
+	size := aws.Int64Value(source.ContentLength)
+	partSize := s.copyPartSize
+	if size > partSize*maxCopyParts {
+		partSize = (size + maxCopyParts - 1) / maxCopyParts
+	}
+	partCount := (size + partSize - 1) / partSize
+
+	// Stop copying the rest of the parts once a part failed.
+	copyCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	parts := make([]*s3.CompletedPart, partCount)
+	errs := make(chan error, partCount)
+	sem := make(chan struct{}, copyPartConcurrency)
+	wg := sync.WaitGroup{}
+
+	for partNumber := int64(1); partNumber <= partCount && copyCtx.Err() == nil; partNumber++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(partNumber int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := (partNumber - 1) * partSize
+			end := start + partSize - 1
+			if end >= size {
+				end = size - 1
+			}
+
+			output, err := s.backend.UploadPartCopy(copyCtx, &s3.UploadPartCopyInput{
+				Bucket:            bucket,
+				Key:               key,
+				UploadId:          upload.UploadId,
+				PartNumber:        aws.Int64(partNumber),
+				CopySource:        aws.String(copySource),
+				CopySourceRange:   aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+				CopySourceIfMatch: source.ETag,
+			})
+			if err != nil {
+				errs <- wrapError(err, "failed to copy part %d", partNumber)
+				cancel()
+				return
+			}
+
+			parts[partNumber-1] = &s3.CompletedPart{
+				ETag:       output.CopyPartResult.ETag,
+				PartNumber: aws.Int64(partNumber),
+			}
+		}(partNumber)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		s.abortCopy(bucket, key, upload.UploadId)
+		return err
+	}
+
+	// Parts stop being copied once ctx is done, even if none of them failed.
+	if err := copyCtx.Err(); err != nil {
+		s.abortCopy(bucket, key, upload.UploadId)
+		return wrapError(err, "failed to copy to %s/%s", *bucket, *key)
+	}
+
+	for i, part := range parts {
+		if part == nil {
+			s.abortCopy(bucket, key, upload.UploadId)
+			return newError(codes.Internal, ReasonInternal, "failed to copy to %s/%s: part %d wasn't copied", *bucket, *key, i+1)
+		}
+	}
+
+	_, err = s.backend.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          bucket,
+		Key:             key,
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		s.abortCopy(bucket, key, upload.UploadId)
+		return wrapError(err, "failed to complete copy to %s/%s", *bucket, *key)
+	}
+
+	return nil
+}
+
+// abortCopy aborts the multipart upload of a failed multipart copy, even if the copy's
+// request is done. Its error is ignored since the copy's error is the one returned.
+func (s *Service) abortCopy(bucket *string, key *string, uploadID *string) {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	_, _ = s.backend.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   bucket,
+		Key:      key,
+		UploadId: uploadID,
+	})
+}
+
+// verifyCopy verifies that a copy of source has the source's size and checksum, and fails
+// with DataLoss if it doesn't. eTag is the md5 sum of the source's content, which the copy's ETag
+// must equal, it isn't verified if it's empty. A checksum stored in the source's metadata must
+// be stored in the copy's metadata as well. Returns how the copy was verified.
+func (s *Service) verifyCopy(
+	ctx aws.Context,
+	source *s3.HeadObjectOutput,
+	eTag string,
+	bucket *string,
+	key *string,
+) (CopyVerification, error) {
+	copied, err := s.backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: bucket, Key: key})
+	if err != nil {
+		return "", wrapError(err, "failed to verify copy %s/%s", *bucket, *key)
+	}
+
+	var violations []Violation
+	sourceSize, copySize := aws.Int64Value(source.ContentLength), aws.Int64Value(copied.ContentLength)
+	if copySize != sourceSize {
+		violations = append(violations, Violation{
+			Type:        "SIZE_MISMATCH",
+			Subject:     "size",
+			Description: fmt.Sprintf("copy has %d bytes, expected %d", copySize, sourceSize),
+		})
+	}
+
+	if eTag != "" && trimETag(copied.ETag) != trimETag(&eTag) {
+		violations = append(violations, Violation{
+			Type:        "CHECKSUM_MISMATCH",
+			Subject:     "eTag",
+			Description: fmt.Sprintf("copy has ETag %s, expected %s", aws.StringValue(copied.ETag), eTag),
+		})
+	}
+
+	verification := CopyVerifiedSize
+	if eTag != "" {
+		verification = CopyVerifiedMD5
+	}
+
+	if sourceChecksum := ChecksumFromMetadata(source.Metadata); sourceChecksum != nil {
+		verification = CopyVerifiedChecksum
+		copyChecksum := ChecksumFromMetadata(copied.Metadata)
+		if copyChecksum == nil || *copyChecksum != *sourceChecksum {
+			violations = append(violations, Violation{
+				Type:        "CHECKSUM_MISMATCH",
+				Subject:     "checksum",
+				Description: fmt.Sprintf("copy doesn't have the source's %s checksum %s", sourceChecksum.Algorithm, sourceChecksum.Value),
+			})
+		}
+	}
+
+	if len(violations) == 0 {
+		return verification, nil
+	}
+
+	return "", &Error{
+		Code:       codes.DataLoss,
+		Reason:     ReasonChecksumMismatch,
+		Message:    fmt.Sprintf("copy doesn't match its source: %s", describeViolations(violations)),
+		Violations: violations,
+	}
+}
+
+// isMultipartETag returns true if eTag is the ETag of an object uploaded in parts,
+// which isn't the md5 sum of the object's content.
+func isMultipartETag(eTag *string) bool {
+	return strings.Contains(aws.StringValue(eTag), "-")
+}
@@ -0,0 +1,290 @@
+package object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/protobuf/proto"
+	"github.com/meateam/upload-service/bucket"
+	"github.com/meateam/upload-service/storage"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details of the service's errors.
+const ErrorDomain = "upload-service.meateam"
+
+// Reasons of the service's errors, set in their google.rpc.ErrorInfo details.
+const (
+	ReasonInvalidArgument     = "INVALID_ARGUMENT"
+	ReasonObjectNotFound      = "OBJECT_NOT_FOUND"
+	ReasonBucketNotFound      = "BUCKET_NOT_FOUND"
+	ReasonUploadNotFound      = "UPLOAD_NOT_FOUND"
+	ReasonAlreadyExists       = "ALREADY_EXISTS"
+	ReasonFailedPrecondition  = "FAILED_PRECONDITION"
+	ReasonRangeNotSatisfiable = "RANGE_NOT_SATISFIABLE"
+	ReasonChecksumMismatch    = "CHECKSUM_MISMATCH"
+	ReasonUploadMismatch      = "UPLOAD_MISMATCH"
+	ReasonUploadExpired       = "UPLOAD_EXPIRED"
+	ReasonMoveRolledBack      = "MOVE_ROLLED_BACK"
+	ReasonMoveIncomplete      = "MOVE_INCOMPLETE"
+	ReasonPermissionDenied    = "PERMISSION_DENIED"
+	ReasonNotSupported        = "NOT_SUPPORTED"
+	ReasonCanceled            = "CANCELED"
+	ReasonStorageUnavailable  = "STORAGE_UNAVAILABLE"
+	ReasonInternal            = "INTERNAL"
+)
+
+// errorInfoStorageCode is the key of the storage backend's error code in the metadata
+// of an error's google.rpc.ErrorInfo details.
+const errorInfoStorageCode = "storageCode"
+
+// Error is an error of the object service. It is converted to a gRPC status with its code
+// and a google.rpc.ErrorInfo detail with its reason and metadata, when it's returned by
+// a request handler.
+type Error struct {
+	// Code is the gRPC status code of the error.
+	Code codes.Code
+
+	// Reason is the reason of the error, one of the Reason constants.
+	Reason string
+
+	// Message is the error's message.
+	Message string
+
+	// Metadata is additional details of the error.
+	Metadata map[string]string
+
+	// Err is the error that caused the error, if any.
+	Err error
+
+	// Violations are the failed preconditions that caused the error, if any.
+	// They are sent as a google.rpc.PreconditionFailure detail.
+	Violations []Violation
+}
+
+// Violation is a single failed precondition of a request.
+type Violation struct {
+	// Type is the type of the violation, for example PART_MISSING.
+	Type string
+
+	// Subject is what failed the precondition, for example "part 3".
+	Subject string
+
+	// Description describes how the precondition failed.
+	Description string
+}
+
+// Error returns the error's message.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error that caused the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the gRPC status of the error, with its google.rpc.ErrorInfo details.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Message)
+	details := []proto.Message{&errdetails.ErrorInfo{
+		Reason:   e.Reason,
+		Domain:   ErrorDomain,
+		Metadata: e.Metadata,
+	}}
+
+	if len(e.Violations) > 0 {
+		violations := make([]*errdetails.PreconditionFailure_Violation, 0, len(e.Violations))
+		for _, violation := range e.Violations {
+			violations = append(violations, &errdetails.PreconditionFailure_Violation{
+				Type:        violation.Type,
+				Subject:     violation.Subject,
+				Description: violation.Description,
+			})
+		}
+
+		details = append(details, &errdetails.PreconditionFailure{Violations: violations})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+// HTTPStatus returns the HTTP status code of an error of the service, by its gRPC status code.
+// A nil error is http.StatusOK.
+func HTTPStatus(err error) int {
+	switch status.Code(err) {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// The non-standard status of a client that closed the request.
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.OutOfRange:
+		return http.StatusRequestedRangeNotSatisfiable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// invalidArgument returns an InvalidArgument error with the formatted message.
+func invalidArgument(format string, args ...interface{}) error {
+	return &Error{
+		Code:    codes.InvalidArgument,
+		Reason:  ReasonInvalidArgument,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// newError returns an error with the given code, reason and formatted message.
+func newError(code codes.Code, reason string, format string, args ...interface{}) error {
+	return &Error{
+		Code:    code,
+		Reason:  reason,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// wrapError returns an error with the formatted message followed by err's message,
+// and the code and reason that match err.
+// If err is an *Error then its code, reason, metadata and violations are kept, otherwise they
+// are determined by the storage backend's error code, a gRPC status or a context error.
+func wrapError(err error, format string, args ...interface{}) error {
+	message := fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err)
+
+	var svcErr *Error
+	if errors.As(err, &svcErr) {
+		return &Error{
+			Code:       svcErr.Code,
+			Reason:     svcErr.Reason,
+			Message:    message,
+			Metadata:   svcErr.Metadata,
+			Err:        err,
+			Violations: svcErr.Violations,
+		}
+	}
+
+	code, reason, metadata := classifyError(err)
+
+	return &Error{
+		Code:     code,
+		Reason:   reason,
+		Message:  message,
+		Metadata: metadata,
+		Err:      err,
+	}
+}
+
+// classifyError returns the gRPC status code, reason and metadata of an error
+// that is not an *Error.
+func classifyError(err error) (codes.Code, string, map[string]string) {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		code, reason := classifyStorageError(awsErr)
+		return code, reason, map[string]string{errorInfoStorageCode: awsErr.Code()}
+	}
+
+	if errors.Is(err, bucket.ErrBucketNotFound) {
+		return codes.NotFound, ReasonBucketNotFound, nil
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return codes.Canceled, ReasonCanceled, nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return codes.DeadlineExceeded, ReasonCanceled, nil
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st.Code(), ReasonInternal, nil
+	}
+
+	return codes.Internal, ReasonInternal, nil
+}
+
+// classifyStorageError returns the gRPC status code and reason of a storage backend error.
+func classifyStorageError(err awserr.Error) (codes.Code, string) {
+	switch err.Code() {
+	case storage.ErrCodeNotFound, s3.ErrCodeNoSuchKey:
+		return codes.NotFound, ReasonObjectNotFound
+	case s3.ErrCodeNoSuchBucket:
+		return codes.NotFound, ReasonBucketNotFound
+	case s3.ErrCodeNoSuchUpload:
+		return codes.NotFound, ReasonUploadNotFound
+	case storage.ErrCodeInvalidArgument,
+		storage.ErrCodeInvalidBucketName,
+		storage.ErrCodeKeyTooLong,
+		storage.ErrCodeMalformedXML,
+		request.InvalidParameterErrCode,
+		request.ParamRequiredErrCode,
+		request.ParamMinLenErrCode,
+		request.ParamMinValueErrCode:
+		return codes.InvalidArgument, ReasonInvalidArgument
+	case storage.ErrCodeInvalidRange:
+		return codes.OutOfRange, ReasonRangeNotSatisfiable
+	case storage.ErrCodeInvalidPart,
+		storage.ErrCodeInvalidPartOrder,
+		storage.ErrCodeEntityTooSmall,
+		storage.ErrCodeBucketNotEmpty,
+		storage.ErrCodePreconditionFailed:
+		return codes.FailedPrecondition, ReasonFailedPrecondition
+	case s3.ErrCodeBucketAlreadyExists, s3.ErrCodeBucketAlreadyOwnedByYou:
+		return codes.AlreadyExists, ReasonAlreadyExists
+	case "AccessDenied", "InvalidAccessKeyId", "SignatureDoesNotMatch":
+		return codes.PermissionDenied, ReasonPermissionDenied
+	case request.CanceledErrorCode:
+		return codes.Canceled, ReasonCanceled
+	case request.ErrCodeResponseTimeout, request.ErrCodeRead, "RequestError",
+		"ServiceUnavailable", "SlowDown", "RequestTimeout":
+		return codes.Unavailable, ReasonStorageUnavailable
+	case storage.ErrCodeInternalError:
+		return codes.Internal, ReasonInternal
+	}
+
+	// Any other server side failure of the storage is an outage.
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() >= http.StatusInternalServerError {
+		return codes.Unavailable, ReasonStorageUnavailable
+	}
+
+	return codes.Internal, ReasonInternal
+}
+
+// wrapBucketError is the same as wrapError, except that a NotFound error is reported
+// as a missing bucket, for errors of HeadBucket that has no error code of its own.
+func wrapBucketError(err error, format string, args ...interface{}) error {
+	wrapped := wrapError(err, format, args...)
+	if svcErr, ok := wrapped.(*Error); ok && svcErr.Code == codes.NotFound {
+		svcErr.Reason = ReasonBucketNotFound
+	}
+
+	return wrapped
+}
@@ -3,25 +3,61 @@ package object
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/meateam/upload-service/proto"
 )
 
+// downloadChunkSize is the size of each chunk streamed by DownloadObject.
+const downloadChunkSize = 1 << 20 // 1MB per chunk
+
 // Handler handles object operation requests by uploading the file's data to aws-s3 Object Storage.
 type Handler struct {
-	service *Service
-	logger  *logrus.Logger
+	service          *Service
+	logger           *logrus.Logger
+	partLimiter      *PartLimiter
+	batchConcurrency int
 }
 
-// NewHandler creates a Handler and returns it.
+// NewHandler creates a Handler with the default part upload limits and returns it.
 func NewHandler(service *Service, logger *logrus.Logger) *Handler {
-	return &Handler{service: service, logger: logger}
+	return NewHandlerWithPartLimiter(
+		service,
+		logger,
+		NewPartLimiter(DefaultPartStreamConcurrency, DefaultPartGlobalConcurrency),
+	)
+}
+
+// NewHandlerWithPartLimiter creates a Handler that limits concurrent part uploads
+// with partLimiter and returns it.
+func NewHandlerWithPartLimiter(service *Service, logger *logrus.Logger, partLimiter *PartLimiter) *Handler {
+	return &Handler{
+		service:          service,
+		logger:           logger,
+		partLimiter:      partLimiter,
+		batchConcurrency: DefaultBatchConcurrency,
+	}
+}
+
+// SetBatchConcurrency sets the limit of concurrent copies or moves of each batch,
+// limits lower than 1 are set to DefaultBatchConcurrency.
+func (h *Handler) SetBatchConcurrency(concurrency int) {
+	if concurrency < 1 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	h.batchConcurrency = concurrency
 }
 
 // GetService returns the internal upload service.
@@ -29,18 +65,25 @@ func (h Handler) GetService() *Service {
 	return h.service
 }
 
+// GetPartLimiter returns the limiter of concurrent part uploads.
+func (h Handler) GetPartLimiter() *PartLimiter {
+	return h.partLimiter
+}
+
 // UploadMedia is the request handler for file upload, it is responsible for getting the file
-// from the request's body and uploading it to the bucket of the user who uploaded it
+// from the request's body and uploading it to the bucket of the user who uploaded it.
+// If the request has a checksum, the file is verified against it and stored with it.
 func (h Handler) UploadMedia(
 	ctx context.Context,
 	request *pb.UploadMediaRequest,
 ) (*pb.UploadMediaResponse, error) {
-	location, err := h.service.UploadFile(ctx,
+	location, err := h.service.UploadFileWithChecksum(ctx,
 		bytes.NewReader(request.GetFile()),
 		aws.String(request.GetKey()),
 		aws.String(request.GetBucket()),
 		aws.String(request.GetContentType()),
-		nil)
+		nil,
+		checksumFromProto(request.GetChecksum()))
 
 	if err != nil {
 		return nil, err
@@ -50,22 +93,24 @@ func (h Handler) UploadMedia(
 }
 
 // UploadMultipart is the request handler for file upload, it is responsible for getting the file
-// from the request's body and uploading it to the bucket of the user who uploaded it
+// from the request's body and uploading it to the bucket of the user who uploaded it.
+// If the request has a checksum, the file is verified against it and stored with it.
 func (h Handler) UploadMultipart(
 	ctx context.Context,
 	request *pb.UploadMultipartRequest,
 ) (*pb.UploadMultipartResponse, error) {
 	metadata := request.GetMetadata()
 	if len(metadata) == 0 {
-		return nil, fmt.Errorf("metadata is required")
+		return nil, invalidArgument("metadata is required")
 	}
 
-	location, err := h.service.UploadFile(ctx,
+	location, err := h.service.UploadFileWithChecksum(ctx,
 		bytes.NewReader(request.GetFile()),
 		aws.String(request.GetKey()),
 		aws.String(request.GetBucket()),
 		aws.String(request.GetContentType()),
-		aws.StringMap(request.GetMetadata()))
+		aws.StringMap(request.GetMetadata()),
+		checksumFromProto(request.GetChecksum()))
 
 	if err != nil {
 		return nil, err
@@ -75,16 +120,26 @@ func (h Handler) UploadMultipart(
 }
 
 // UploadInit is the request handler for initiating resumable upload.
+// The upload's session is started with the request's owner, size, part count, checksum and TTL,
+// which the upload's parts and completion are checked against.
+// Responds with the upload's id and the time it expires.
 func (h Handler) UploadInit(
 	ctx context.Context,
 	request *pb.UploadInitRequest,
 ) (*pb.UploadInitResponse, error) {
-	result, err := h.service.UploadInit(
+	result, uploadSession, err := h.service.UploadInitWithOptions(
 		ctx,
 		aws.String(request.GetKey()),
 		aws.String(request.GetBucket()),
 		aws.String(request.GetContentType()),
-		aws.StringMap(request.GetMetadata()))
+		aws.StringMap(request.GetMetadata()),
+		&UploadOptions{
+			Owner:     request.GetOwner(),
+			Size:      request.GetSize(),
+			PartCount: request.GetPartCount(),
+			Checksum:  checksumFromProto(request.GetChecksum()),
+			TTL:       time.Duration(request.GetTtl()) * time.Second,
+		})
 
 	if err != nil {
 		return nil, err
@@ -94,17 +149,40 @@ func (h Handler) UploadInit(
 		UploadId: *result.UploadId,
 		Key:      *result.Key,
 		Bucket:   *result.Bucket,
+		Expires:  uploadSession.Expires.Unix(),
 	}
 
 	return response, nil
 }
 
 // UploadPart is the request handler for multipart file upload.
-// It is fetching file parts from a RPC stream and uploads them concurrently.
-// Responds with a stream of upload status for each part streamed.
+// It is fetching file parts from a RPC stream and uploads them concurrently,
+// up to the handler's per-stream and global limits of concurrent part uploads.
+// The next part is not received from the stream while a limit is reached,
+// which applies backpressure to the client through the stream's flow control.
+// A global slot is taken only once a part is received, so streams waiting for their
+// client's next part don't hold global slots that other streams need.
+// Responds with a stream of upload results, one for each part streamed, which
+// hold the part's number, ETag, size and checksum, or its error if it failed.
+// A part whose request has a checksum that doesn't match its data fails with DataLoss,
+// and a part whose request's owner isn't the upload's owner fails with PermissionDenied.
 func (h Handler) UploadPart(stream pb.Upload_UploadPartServer) error {
 	wg := sync.WaitGroup{}
+	streamSlots := h.partLimiter.newStreamSlots()
+
+	// Responses are sent from the uploading goroutines, and a stream
+	// must not be sent to concurrently.
+	sendMu := sync.Mutex{}
+	send := func(resp *pb.UploadPartResponse) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		return stream.Send(resp)
+	}
+
 	for {
+		// Wait for a slot of this stream before receiving the next part.
+		streamSlots <- struct{}{}
 		part, err := stream.Recv()
 
 		if err == io.EOF {
@@ -113,39 +191,62 @@ func (h Handler) UploadPart(stream pb.Upload_UploadPartServer) error {
 		}
 
 		if err != nil {
-			errResponse := &pb.UploadPartResponse{Code: 500, Message: fmt.Sprintf("failed fetching part: %v", err)}
-			if err := stream.Send(errResponse); err != nil {
+			wg.Wait()
+			errResponse := &pb.UploadPartResponse{
+				Code:    500,
+				Message: fmt.Sprintf("failed fetching part: %v", err),
+				Error:   errorStatus(err),
+			}
+			if err := send(errResponse); err != nil {
 				return err
 			}
+
+			return err
+		}
+
+		// Wait for a global slot before receiving more parts.
+		if err := h.partLimiter.acquire(stream.Context()); err != nil {
+			wg.Wait()
+			return err
 		}
 
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			defer func() { <-streamSlots }()
+			defer h.partLimiter.release()
+
+			checksum := sha256.Sum256(part.GetPart())
+			var result *s3.UploadPartOutput
+			err := h.service.CheckUploadOwner(aws.String(part.GetUploadId()), part.GetOwner())
+			if err == nil {
+				result, err = h.service.UploadPartWithChecksum(
+					stream.Context(),
+					aws.String(part.GetUploadId()),
+					aws.String(part.GetKey()),
+					aws.String(part.GetBucket()),
+					aws.Int64(part.GetPartNumber()),
+					bytes.NewReader(part.GetPart()),
+					checksumFromProto(part.GetChecksum()))
+			}
 
-			result, err := h.service.UploadPart(
-				stream.Context(),
-				aws.String(part.GetUploadId()),
-				aws.String(part.GetKey()),
-				aws.String(part.GetBucket()),
-				aws.Int64(part.GetPartNumber()),
-				bytes.NewReader(part.GetPart()))
-
-			var resp *pb.UploadPartResponse
+			resp := &pb.UploadPartResponse{
+				PartNumber: part.GetPartNumber(),
+				Size:       int64(len(part.GetPart())),
+				Checksum:   hex.EncodeToString(checksum[:]),
+			}
 
 			if err != nil {
-				resp = &pb.UploadPartResponse{
-					Code:    500,
-					Message: fmt.Sprintf("failed uploading part: %v", err),
-				}
+				resp.Code = 500
+				resp.Message = fmt.Sprintf("failed uploading part %d: %v", part.GetPartNumber(), err)
+				resp.Error = errorStatus(err)
 			} else {
-				resp = &pb.UploadPartResponse{
-					Code:    200,
-					Message: fmt.Sprintf("successfully uploaded part %s", *result.ETag),
-				}
+				resp.Code = 200
+				resp.Message = fmt.Sprintf("successfully uploaded part %s", *result.ETag)
+				resp.ETag = *result.ETag
 			}
 
-			if err := stream.Send(resp); err != nil {
+			if err := send(resp); err != nil {
 				h.logger.Errorf("failed to send response in stream:  %v", err)
 			}
 		}()
@@ -153,15 +254,39 @@ func (h Handler) UploadPart(stream pb.Upload_UploadPartServer) error {
 }
 
 // UploadComplete is the request handler for completing and assembling previously uploaded file parts.
-// Responds with the location of the assembled file.
+// If the request lists the parts, size or checksum of the file, they are verified against the uploaded parts.
+// Responds with the assembled file's size and content type, or with a FailedPrecondition status
+// that details the mismatches if the uploaded parts don't match, or DataLoss if the checksum doesn't match.
+// The parts are also checked against the upload's session, and a request whose owner isn't
+// the upload's owner fails with PermissionDenied.
 func (h Handler) UploadComplete(
 	ctx context.Context,
 	request *pb.UploadCompleteRequest,
 ) (*pb.UploadCompleteResponse, error) {
+	if err := h.service.CheckUploadOwner(aws.String(request.GetUploadId()), request.GetOwner()); err != nil {
+		return nil, err
+	}
+
+	parts := make([]*s3.CompletedPart, 0, len(request.GetParts()))
+	for _, part := range request.GetParts() {
+		parts = append(parts, &s3.CompletedPart{
+			PartNumber: aws.Int64(part.GetPartNumber()),
+			ETag:       aws.String(part.GetETag()),
+		})
+	}
+
+	var size *int64
+	if request.GetSize() != 0 {
+		size = aws.Int64(request.GetSize())
+	}
+
 	_, err := h.service.UploadComplete(ctx,
 		aws.String(request.GetUploadId()),
 		aws.String(request.GetKey()),
-		aws.String(request.GetBucket()))
+		aws.String(request.GetBucket()),
+		parts,
+		size,
+		aws.String(request.GetChecksum()))
 	if err != nil {
 		return nil, err
 	}
@@ -174,11 +299,72 @@ func (h Handler) UploadComplete(
 	return &pb.UploadCompleteResponse{ContentLength: *obj.ContentLength, ContentType: *obj.ContentType}, nil
 }
 
+// GetUploadStatus is the request handler for the status of a resumable upload in progress.
+// Responds with the upload's details and the parts uploaded so far, so a client that lost
+// its UploadPart stream can resend only the parts that are missing. A request whose owner
+// isn't the upload's owner fails with PermissionDenied.
+func (h Handler) GetUploadStatus(
+	ctx context.Context,
+	request *pb.GetUploadStatusRequest,
+) (*pb.GetUploadStatusResponse, error) {
+	if err := h.service.CheckUploadOwner(aws.String(request.GetUploadId()), request.GetOwner()); err != nil {
+		return nil, err
+	}
+
+	uploadStatus, err := h.service.GetUploadStatus(
+		ctx,
+		aws.String(request.GetUploadId()),
+		aws.String(request.GetKey()),
+		aws.String(request.GetBucket()))
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	parts := make([]*pb.UploadedPart, 0, len(uploadStatus.Parts))
+	for _, part := range uploadStatus.Parts {
+		size += aws.Int64Value(part.Size)
+		parts = append(parts, &pb.UploadedPart{
+			PartNumber:   aws.Int64Value(part.PartNumber),
+			Size:         aws.Int64Value(part.Size),
+			ETag:         aws.StringValue(part.ETag),
+			LastModified: aws.TimeValue(part.LastModified).Unix(),
+			Checksum:     checksumToProto(uploadStatus.PartChecksums[aws.Int64Value(part.PartNumber)]),
+		})
+	}
+
+	var expires int64
+	if !uploadStatus.Expires.IsZero() {
+		expires = uploadStatus.Expires.Unix()
+	}
+
+	return &pb.GetUploadStatusResponse{
+		UploadId:     uploadStatus.UploadID,
+		Key:          uploadStatus.Key,
+		Bucket:       uploadStatus.Bucket,
+		ContentType:  uploadStatus.ContentType,
+		Metadata:     uploadStatus.Metadata,
+		Initiated:    uploadStatus.Initiated.Unix(),
+		Parts:        parts,
+		Size:         size,
+		Owner:        uploadStatus.Owner,
+		ExpectedSize: uploadStatus.ExpectedSize,
+		PartCount:    uploadStatus.PartCount,
+		Expires:      expires,
+		Checksum:     checksumToProto(uploadStatus.Checksum),
+	}, nil
+}
+
 // UploadAbort is the request handler for aborting and freeing previously uploaded parts.
+// A request whose owner isn't the upload's owner fails with PermissionDenied.
 func (h Handler) UploadAbort(
 	ctx context.Context,
 	request *pb.UploadAbortRequest,
 ) (*pb.UploadAbortResponse, error) {
+	if err := h.service.CheckUploadOwner(aws.String(request.GetUploadId()), request.GetOwner()); err != nil {
+		return nil, err
+	}
+
 	abortStatus, err := h.service.UploadAbort(
 		ctx,
 		aws.String(request.GetUploadId()),
@@ -192,8 +378,95 @@ func (h Handler) UploadAbort(
 	return &pb.UploadAbortResponse{Status: abortStatus}, nil
 }
 
-// DeleteObjects is the request handler for deleting objects.
-// It responds with a slice of deleted object keys and a slice of failed object keys.
+// ListMultipartUploads is the request handler for listing the resumable uploads in progress in a bucket.
+// Responds with a page of the uploads whose key begins with the request's prefix and that were
+// initiated more than the request's olderThan seconds ago, and the token of the next page.
+func (h Handler) ListMultipartUploads(
+	ctx context.Context,
+	request *pb.ListMultipartUploadsRequest,
+) (*pb.ListMultipartUploadsResponse, error) {
+	if request.GetOlderThan() < 0 {
+		return nil, invalidArgument("olderThan must not be negative")
+	}
+
+	var initiatedBefore time.Time
+	if request.GetOlderThan() > 0 {
+		initiatedBefore = time.Now().Add(-time.Duration(request.GetOlderThan()) * time.Second)
+	}
+
+	list, err := h.service.ListMultipartUploads(
+		ctx,
+		aws.String(request.GetBucket()),
+		aws.String(request.GetPrefix()),
+		initiatedBefore,
+		aws.String(request.GetPageToken()),
+		aws.Int64(request.GetPageSize()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	uploads := make([]*pb.MultipartUploadInfo, 0, len(list.Uploads))
+	for _, upload := range list.Uploads {
+		var expires int64
+		if !upload.Expires.IsZero() {
+			expires = upload.Expires.Unix()
+		}
+
+		uploads = append(uploads, &pb.MultipartUploadInfo{
+			UploadId:    upload.UploadID,
+			Key:         upload.Key,
+			Initiated:   upload.Initiated.Unix(),
+			ContentType: upload.ContentType,
+			Owner:       upload.Owner,
+			Expires:     expires,
+		})
+	}
+
+	return &pb.ListMultipartUploadsResponse{Uploads: uploads, NextPageToken: list.NextPageToken}, nil
+}
+
+// AbortMultipartUploads is the request handler for aborting many resumable uploads in a bucket.
+// The uploads are aborted concurrently. Responds with the uploads that were aborted,
+// and the uploads that failed to abort with their errors.
+func (h Handler) AbortMultipartUploads(
+	ctx context.Context,
+	request *pb.AbortMultipartUploadsRequest,
+) (*pb.AbortMultipartUploadsResponse, error) {
+	uploads := make([]*MultipartUpload, 0, len(request.GetUploads()))
+	for _, upload := range request.GetUploads() {
+		uploads = append(uploads, &MultipartUpload{UploadID: upload.GetUploadId(), Key: upload.GetKey()})
+	}
+
+	results, err := h.service.AbortMultipartUploads(ctx, aws.String(request.GetBucket()), uploads)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.AbortMultipartUploadsResponse{
+		Aborted: make([]*pb.MultipartUploadInfo, 0, len(results)),
+		Failed:  []*pb.AbortMultipartUploadFailure{},
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			response.Failed = append(response.Failed, &pb.AbortMultipartUploadFailure{
+				UploadId: result.UploadID,
+				Key:      result.Key,
+				Error:    errorStatus(result.Err),
+			})
+
+			continue
+		}
+
+		response.Aborted = append(response.Aborted, &pb.MultipartUploadInfo{UploadId: result.UploadID, Key: result.Key})
+	}
+
+	return response, nil
+}
+
+// DeleteObjects is the request handler for deleting objects, any number of them.
+// It responds with a slice of deleted object keys and a slice of failed object keys,
+// and the error code and message of each failed key.
 func (h Handler) DeleteObjects(
 	ctx context.Context,
 	request *pb.DeleteObjectsRequest,
@@ -213,23 +486,27 @@ func (h Handler) DeleteObjects(
 	}
 
 	failedKeys := make([]string, 0, len(deleteResponse.Errors))
+	deleteErrors := make([]*pb.DeleteError, 0, len(deleteResponse.Errors))
 	for _, erroredObject := range deleteResponse.Errors {
 		failedKeys = append(failedKeys, *(erroredObject.Key))
+		deleteErrors = append(deleteErrors, &pb.DeleteError{
+			Key:     aws.StringValue(erroredObject.Key),
+			Code:    aws.StringValue(erroredObject.Code),
+			Message: aws.StringValue(erroredObject.Message),
+		})
 	}
 
-	return &pb.DeleteObjectsResponse{Deleted: deletedKeys, Failed: failedKeys}, nil
+	return &pb.DeleteObjectsResponse{Deleted: deletedKeys, Failed: failedKeys, Errors: deleteErrors}, nil
 }
 
-
 // CopyObject - copy an object from source to destination bucket
-// Returns the ETag of the new object. The ETag reflects only changes to the
-// contents of an object, not its metadata. The source and destination ETag
-// is identical for a successfully copied object.
+// Responds with the copied key and how the copy was verified, which is SIZE_ONLY
+// if the source has no stored checksum and the copy's md5 sum couldn't be compared.
 func (h Handler) CopyObject(
 	ctx context.Context,
 	request *pb.CopyObjectRequest,
 ) (*pb.CopyObjectResponse, error) {
-	_, err := h.service.CopyObject(
+	verification, err := h.service.CopyObjectWithVerification(
 		ctx,
 		aws.String(request.GetBucketSrc()),
 		aws.String(request.GetBucketDest()),
@@ -240,16 +517,21 @@ func (h Handler) CopyObject(
 		return nil, err
 	}
 
-	
-	return &pb.CopyObjectResponse{ Copied: request.GetKeySrc() }, nil
+	return &pb.CopyObjectResponse{
+		Copied:       request.GetKeySrc(),
+		Verification: pb.CopyVerification(pb.CopyVerification_value[string(verification)]),
+	}, nil
 }
 
-// MoveObject - copy an object from source to destination bucket and delete the source
+// MoveObject is the request handler for moving an object from source to destination bucket.
+// The object is copied and verified before the source is deleted, and the move is rolled back
+// if the source can't be deleted. Responds with the move's final state, or with an Aborted status
+// whose metadata has the final state if the move failed after the object was copied.
 func (h Handler) MoveObject(
 	ctx context.Context,
 	request *pb.MoveObjectRequest,
 ) (*pb.MoveObjectResponse, error) {
-	_, err := h.service.CopyObject(
+	result, err := h.service.MoveObject(
 		ctx,
 		aws.String(request.GetBucketSrc()),
 		aws.String(request.GetBucketDest()),
@@ -257,18 +539,446 @@ func (h Handler) MoveObject(
 		aws.String(request.GetKeyDest()),
 	)
 	if err != nil {
+		if result != nil && result.State != MoveStateNotMoved {
+			h.logger.WithField("moveState", result.State).Errorf(
+				"failed to move %s/%s to %s/%s: %s",
+				request.GetBucketSrc(),
+				request.GetKeySrc(),
+				request.GetBucketDest(),
+				request.GetKeyDest(),
+				strings.Join(result.Journal, "; "),
+			)
+		}
+
 		return nil, err
 	}
-	
-	// Delete the object from the source bucket
-	deleteResponse, err := h.service.DeleteObjects(
-		ctx,
+
+	return &pb.MoveObjectResponse{
+		Moved:        request.GetKeySrc(),
+		State:        pb.MoveState(pb.MoveState_value[string(result.State)]),
+		Verification: pb.CopyVerification(pb.CopyVerification_value[string(result.Verification)]),
+	}, nil
+}
+
+// BatchCopyObjects is the request handler for copying many objects.
+// The objects are copied concurrently, up to the request's concurrency and the handler's limit.
+// Responds with a stream of results, one for each object as soon as it's copied.
+func (h Handler) BatchCopyObjects(request *pb.BatchObjectsRequest, stream pb.Upload_BatchCopyObjectsServer) error {
+	return h.streamBatch(request, stream, h.service.BatchCopyObjects)
+}
+
+// BatchMoveObjects is the request handler for moving many objects.
+// The objects are moved concurrently, up to the request's concurrency and the handler's limit,
+// each the same as MoveObject moves it. Responds with a stream of results, one for each object
+// as soon as it's moved, which hold the move's final state.
+func (h Handler) BatchMoveObjects(request *pb.BatchObjectsRequest, stream pb.Upload_BatchMoveObjectsServer) error {
+	return h.streamBatch(request, stream, h.service.BatchMoveObjects)
+}
+
+// batchStream is a stream of the results of a batch.
+type batchStream interface {
+	Context() context.Context
+	Send(*pb.BatchObjectResult) error
+}
+
+// streamBatch runs the request's batch with run and sends the result of each object to stream.
+// The batch is canceled if a result can't be sent.
+func (h Handler) streamBatch(
+	request *pb.BatchObjectsRequest,
+	stream batchStream,
+	run func(ctx aws.Context, items []BatchItem, concurrency int) (<-chan *BatchResult, error),
+) error {
+	items := make([]BatchItem, 0, len(request.GetItems()))
+	for _, item := range request.GetItems() {
+		items = append(items, BatchItem{
+			BucketSrc:  item.GetBucketSrc(),
+			BucketDest: item.GetBucketDest(),
+			KeySrc:     item.GetKeySrc(),
+			KeyDest:    item.GetKeyDest(),
+		})
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	results, err := run(ctx, items, h.concurrency(request.GetConcurrency()))
+	if err != nil {
+		return err
+	}
+
+	var sendErr error
+	for result := range results {
+		if sendErr != nil {
+			continue
+		}
+
+		response := &pb.BatchObjectResult{
+			Index: int32(result.Index),
+			Item:  request.GetItems()[result.Index],
+			State: pb.MoveState(pb.MoveState_value[string(result.MoveState)]),
+		}
+		if result.Err != nil {
+			response.Error = errorStatus(result.Err)
+		}
+
+		if sendErr = stream.Send(response); sendErr != nil {
+			cancel()
+		}
+	}
+
+	if sendErr != nil {
+		return sendErr
+	}
+
+	if err := stream.Context().Err(); err != nil {
+		return wrapError(err, "batch stopped before all of its objects finished")
+	}
+
+	return nil
+}
+
+// CopyPrefix is the request handler for copying every object under a prefix.
+// The objects are copied page by page, up to the request's concurrency and the handler's limit
+// concurrently. Responds with a stream of the copy's progress after each page, whose last
+// continuation token resumes the copy if it stopped.
+func (h Handler) CopyPrefix(request *pb.PrefixRequest, stream pb.Upload_CopyPrefixServer) error {
+	return h.service.CopyPrefix(
+		stream.Context(),
+		aws.String(request.GetBucketSrc()),
+		aws.String(request.GetBucketDest()),
+		aws.String(request.GetPrefixSrc()),
+		aws.String(request.GetPrefixDest()),
+		aws.String(request.GetContinuationToken()),
+		h.concurrency(request.GetConcurrency()),
+		sendPrefixProgress(stream),
+	)
+}
+
+// MovePrefix is the request handler for moving every object under a prefix.
+// The objects are moved page by page, up to the request's concurrency and the handler's limit
+// concurrently, each the same as MoveObject moves it. Responds with a stream of the move's progress
+// after each page, whose last continuation token resumes the move if it stopped.
+func (h Handler) MovePrefix(request *pb.PrefixRequest, stream pb.Upload_MovePrefixServer) error {
+	return h.service.MovePrefix(
+		stream.Context(),
 		aws.String(request.GetBucketSrc()),
-		aws.StringSlice([]string{request.GetKeySrc()}),
+		aws.String(request.GetBucketDest()),
+		aws.String(request.GetPrefixSrc()),
+		aws.String(request.GetPrefixDest()),
+		aws.String(request.GetContinuationToken()),
+		h.concurrency(request.GetConcurrency()),
+		sendPrefixProgress(stream),
+	)
+}
+
+// DeletePrefix is the request handler for deleting every object under a prefix.
+// Responds with a stream of the delete's progress after each page, whose last
+// continuation token resumes the delete if it stopped.
+func (h Handler) DeletePrefix(request *pb.DeletePrefixRequest, stream pb.Upload_DeletePrefixServer) error {
+	return h.service.DeletePrefix(
+		stream.Context(),
+		aws.String(request.GetBucket()),
+		aws.String(request.GetPrefix()),
+		aws.String(request.GetContinuationToken()),
+		sendPrefixProgress(stream),
+	)
+}
+
+// sendPrefixProgress returns a PrefixProgressFunc that sends the progress to stream.
+func sendPrefixProgress(stream interface {
+	Send(*pb.PrefixProgress) error
+}) PrefixProgressFunc {
+	return func(progress *PrefixProgress) error {
+		failures := make([]*pb.PrefixFailure, 0, len(progress.Failures))
+		for _, failure := range progress.Failures {
+			failures = append(failures, &pb.PrefixFailure{Key: failure.Key, Error: errorStatus(failure.Err)})
+		}
+
+		return stream.Send(&pb.PrefixProgress{
+			Processed:         progress.Processed,
+			Failed:            progress.Failed,
+			Failures:          failures,
+			ContinuationToken: progress.ContinuationToken,
+			Done:              progress.Done,
+		})
+	}
+}
+
+// concurrency returns the concurrency of a batch request, limited by the handler's limit.
+func (h Handler) concurrency(requested int32) int {
+	if requested < 1 || int(requested) > h.batchConcurrency {
+		return h.batchConcurrency
+	}
+
+	return int(requested)
+}
+
+// DownloadObject is the request handler for downloading an object.
+// It streams the object's content in chunks, the first message of the stream
+// also contains the object's content type, length, ETag and metadata.
+func (h Handler) DownloadObject(
+	request *pb.DownloadObjectRequest,
+	stream pb.Upload_DownloadObjectServer,
+) error {
+	obj, err := h.service.GetObject(
+		stream.Context(),
+		aws.String(request.GetKey()),
+		aws.String(request.GetBucket()),
+		aws.String(request.GetRange()),
+	)
+	if err != nil {
+		return err
+	}
+	defer obj.Body.Close()
+
+	response := &pb.DownloadObjectResponse{
+		ContentType:   aws.StringValue(obj.ContentType),
+		ContentLength: aws.Int64Value(obj.ContentLength),
+		ETag:          aws.StringValue(obj.ETag),
+		Metadata:      aws.StringValueMap(obj.Metadata),
+		ContentRange:  aws.StringValue(obj.ContentRange),
+		Checksum:      checksumToProto(ChecksumFromMetadata(obj.Metadata)),
+	}
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := io.ReadFull(obj.Body, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return wrapError(err, "failed reading object %s/%s", request.GetBucket(), request.GetKey())
+		}
+
+		// Always send the first message, even if the object is empty,
+		// so the client receives the object's details.
+		if n > 0 || response != nil {
+			if response == nil {
+				response = &pb.DownloadObjectResponse{}
+			}
+
+			response.Chunk = buf[:n]
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+			response = nil
+		}
+
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// UploadStream is the request handler for streamed file upload.
+// The first message of the stream contains the file's details and the rest contain
+// the file's data chunks. The chunks are piped into the uploader as they arrive,
+// so the file is never buffered whole in memory. If the details have a checksum,
+// the file is verified against it and stored with it.
+// Responds with the location of the uploaded file.
+func (h Handler) UploadStream(stream pb.Upload_UploadStreamServer) error {
+	request, err := stream.Recv()
+	if err == io.EOF {
+		return invalidArgument("file details are required")
+	}
+
+	if err != nil {
+		return wrapError(err, "failed fetching file details")
+	}
+
+	details := request.GetDetails()
+	if details == nil {
+		return invalidArgument("file details must be sent in the first message")
+	}
+
+	var metadata map[string]*string
+	if len(details.GetMetadata()) > 0 {
+		metadata = aws.StringMap(details.GetMetadata())
+	}
+
+	pr, pw := io.Pipe()
+
+	// Receive the file's chunks and write them to the pipe until the stream ends.
+	go func() {
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+
+			if err != nil {
+				pw.CloseWithError(wrapError(err, "failed fetching chunk"))
+				return
+			}
+
+			if chunk.GetDetails() != nil {
+				pw.CloseWithError(invalidArgument("file details must be sent only in the first message"))
+				return
+			}
+
+			if _, err := pw.Write(chunk.GetChunk()); err != nil {
+				return
+			}
+		}
+	}()
+
+	location, err := h.service.UploadFileWithChecksum(stream.Context(),
+		pr,
+		aws.String(details.GetKey()),
+		aws.String(details.GetBucket()),
+		aws.String(details.GetContentType()),
+		metadata,
+		checksumFromProto(details.GetChecksum()))
+
+	// Unblock the receiving goroutine if the upload stopped reading before the stream ended.
+	pr.CloseWithError(io.ErrClosedPipe)
+
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&pb.UploadStreamResponse{Location: *location})
+}
+
+// ListObjects is the request handler for listing the objects in a bucket.
+// Responds with a page of the objects and common prefixes, and the token of the next page.
+func (h Handler) ListObjects(
+	ctx context.Context,
+	request *pb.ListObjectsRequest,
+) (*pb.ListObjectsResponse, error) {
+	list, err := h.service.ListObjects(
+		ctx,
+		aws.String(request.GetBucket()),
+		aws.String(request.GetPrefix()),
+		aws.String(request.GetDelimiter()),
+		aws.String(request.GetPageToken()),
+		aws.Int64(request.GetPageSize()),
 	)
-	if err != nil || len(deleteResponse.Errors) > 0 {
+	if err != nil {
 		return nil, err
 	}
 
-	return &pb.MoveObjectResponse{Moved: request.GetKeySrc()}, nil
-}
\ No newline at end of file
+	objects := make([]*pb.ObjectInfo, 0, len(list.Contents))
+	for _, object := range list.Contents {
+		contentType, ok := list.ContentTypes[*object.Key]
+
+		// Skip objects that were deleted while they were listed.
+		if !ok {
+			continue
+		}
+
+		objects = append(objects, &pb.ObjectInfo{
+			Key:          *object.Key,
+			Size:         aws.Int64Value(object.Size),
+			ETag:         aws.StringValue(object.ETag),
+			LastModified: aws.TimeValue(object.LastModified).Unix(),
+			ContentType:  contentType,
+		})
+	}
+
+	commonPrefixes := make([]string, 0, len(list.CommonPrefixes))
+	for _, commonPrefix := range list.CommonPrefixes {
+		commonPrefixes = append(commonPrefixes, *commonPrefix.Prefix)
+	}
+
+	return &pb.ListObjectsResponse{
+		Objects:        objects,
+		CommonPrefixes: commonPrefixes,
+		NextPageToken:  aws.StringValue(list.NextContinuationToken),
+	}, nil
+}
+
+// StatObject is the request handler for getting an object's details without its content.
+// Responds with a NotFound status if the object doesn't exist.
+func (h Handler) StatObject(
+	ctx context.Context,
+	request *pb.StatObjectRequest,
+) (*pb.StatObjectResponse, error) {
+	obj, err := h.service.HeadObject(ctx, aws.String(request.GetKey()), aws.String(request.GetBucket()))
+	if err != nil {
+		return nil, err
+	}
+
+	// S3 omits the storage class of objects in the standard storage class.
+	storageClass := aws.StringValue(obj.StorageClass)
+	if storageClass == "" {
+		storageClass = s3.StorageClassStandard
+	}
+
+	return &pb.StatObjectResponse{
+		ContentLength: aws.Int64Value(obj.ContentLength),
+		ContentType:   aws.StringValue(obj.ContentType),
+		ETag:          aws.StringValue(obj.ETag),
+		LastModified:  aws.TimeValue(obj.LastModified).Unix(),
+		StorageClass:  storageClass,
+		Metadata:      aws.StringValueMap(obj.Metadata),
+		Checksum:      checksumToProto(ChecksumFromMetadata(obj.Metadata)),
+	}, nil
+}
+
+// GeneratePresignedURL is the request handler for generating a presigned URL of an object.
+// Responds with an Unimplemented status if the storage backend can't presign URLs.
+func (h Handler) GeneratePresignedURL(
+	ctx context.Context,
+	request *pb.GeneratePresignedURLRequest,
+) (*pb.GeneratePresignedURLResponse, error) {
+	expiry := time.Duration(request.GetExpiresIn()) * time.Second
+	if expiry == 0 {
+		expiry = defaultPresignExpiry
+	}
+
+	url, headers, err := h.service.GeneratePresignedURL(
+		ctx,
+		aws.String(strings.ToUpper(request.GetMethod())),
+		aws.String(request.GetKey()),
+		aws.String(request.GetBucket()),
+		expiry,
+		aws.String(request.GetContentType()),
+		aws.StringMap(request.GetMetadata()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	signedHeaders := make(map[string]string, len(headers))
+	for name := range headers {
+		signedHeaders[name] = headers.Get(name)
+	}
+
+	return &pb.GeneratePresignedURLResponse{
+		Url:        url,
+		Headers:    signedHeaders,
+		Expiration: time.Now().Add(expiry).Unix(),
+	}, nil
+}
+
+// errorStatus returns the status of a failed operation from its error.
+func errorStatus(err error) *pb.ErrorStatus {
+	st := status.Convert(err)
+
+	return &pb.ErrorStatus{Code: int32(st.Code()), Message: st.Message()}
+}
+
+// checksumFromProto returns the checksum of a request, or nil if the request has none.
+func checksumFromProto(checksum *pb.Checksum) *Checksum {
+	if checksum == nil || (checksum.GetAlgorithm() == pb.ChecksumAlgorithm_CHECKSUM_ALGORITHM_UNSPECIFIED && checksum.GetValue() == "") {
+		return nil
+	}
+
+	algorithm := ""
+	if checksum.GetAlgorithm() != pb.ChecksumAlgorithm_CHECKSUM_ALGORITHM_UNSPECIFIED {
+		algorithm = checksum.GetAlgorithm().String()
+	}
+
+	return &Checksum{Algorithm: algorithm, Value: checksum.GetValue()}
+}
+
+// checksumToProto returns the response message of a checksum, or nil if checksum is nil.
+func checksumToProto(checksum *Checksum) *pb.Checksum {
+	if checksum == nil {
+		return nil
+	}
+
+	return &pb.Checksum{
+		Algorithm: pb.ChecksumAlgorithm(pb.ChecksumAlgorithm_value[checksum.Algorithm]),
+		Value:     checksum.Value,
+	}
+}
@@ -0,0 +1,122 @@
+package object
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+const (
+	// DefaultPartStreamConcurrency is the default limit of concurrent part uploads in a single
+	// UploadPart stream.
+	DefaultPartStreamConcurrency = 4
+
+	// DefaultPartGlobalConcurrency is the default limit of concurrent part uploads in all of the
+	// UploadPart streams together.
+	DefaultPartGlobalConcurrency = 16
+)
+
+// Verify that PartLimiter implements apm.MetricsGatherer.
+var _ apm.MetricsGatherer = (*PartLimiter)(nil)
+
+// PartLimiter limits the number of concurrent part uploads of UploadPart streams,
+// both in each stream and in all of the streams together, and keeps statistics
+// of the parts waiting for an upload slot.
+type PartLimiter struct {
+	streamLimit int
+	global      chan struct{}
+
+	// The following statistics are updated atomically.
+	queued    int64
+	inFlight  int64
+	waitCount int64
+	waitSum   int64
+}
+
+// PartLimiterStats is a snapshot of the statistics of a PartLimiter.
+type PartLimiterStats struct {
+	// Queued is the number of received parts waiting for an upload slot.
+	Queued int64
+
+	// InFlight is the number of parts being uploaded.
+	InFlight int64
+
+	// WaitCount is the total number of parts that got an upload slot.
+	WaitCount int64
+
+	// WaitTime is the total time parts waited for an upload slot.
+	WaitTime time.Duration
+}
+
+// NewPartLimiter creates a PartLimiter that allows up to streamLimit concurrent part uploads
+// in each stream, and up to globalLimit concurrent part uploads in all of the streams together.
+// Limits lower than 1 are set to 1.
+func NewPartLimiter(streamLimit int, globalLimit int) *PartLimiter {
+	if streamLimit < 1 {
+		streamLimit = 1
+	}
+
+	if globalLimit < 1 {
+		globalLimit = 1
+	}
+
+	return &PartLimiter{
+		streamLimit: streamLimit,
+		global:      make(chan struct{}, globalLimit),
+	}
+}
+
+// newStreamSlots returns the upload slots of a single stream.
+func (l *PartLimiter) newStreamSlots() chan struct{} {
+	return make(chan struct{}, l.streamLimit)
+}
+
+// acquire waits for a global upload slot for a received part, or until ctx is done.
+// A part that got a slot must release it once it's uploaded.
+func (l *PartLimiter) acquire(ctx context.Context) error {
+	atomic.AddInt64(&l.queued, 1)
+	defer atomic.AddInt64(&l.queued, -1)
+
+	start := time.Now()
+	select {
+	case l.global <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	atomic.AddInt64(&l.inFlight, 1)
+	atomic.AddInt64(&l.waitCount, 1)
+	atomic.AddInt64(&l.waitSum, int64(time.Since(start)))
+
+	return nil
+}
+
+// release frees a global upload slot acquired by acquire.
+func (l *PartLimiter) release() {
+	atomic.AddInt64(&l.inFlight, -1)
+	<-l.global
+}
+
+// Stats returns a snapshot of the statistics of the limiter.
+func (l *PartLimiter) Stats() PartLimiterStats {
+	return PartLimiterStats{
+		Queued:    atomic.LoadInt64(&l.queued),
+		InFlight:  atomic.LoadInt64(&l.inFlight),
+		WaitCount: atomic.LoadInt64(&l.waitCount),
+		WaitTime:  time.Duration(atomic.LoadInt64(&l.waitSum)),
+	}
+}
+
+// GatherMetrics adds the limiter's statistics to the APM metrics m.
+// The wait time is reported as a running count and sum, the same as APM's duration metrics.
+func (l *PartLimiter) GatherMetrics(ctx context.Context, m *apm.Metrics) error {
+	stats := l.Stats()
+	m.Add("upload.part.queue_depth", nil, float64(stats.Queued))
+	m.Add("upload.part.in_flight", nil, float64(stats.InFlight))
+	m.Add("upload.part.wait.count", nil, float64(stats.WaitCount))
+	m.Add("upload.part.wait.sum.us", nil, float64(stats.WaitTime/time.Microsecond))
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/meateam/upload-service/bucket"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// moveDeleteAttempts is the number of attempts to delete the source of a move,
+	// and to delete the destination when a move is rolled back.
+	moveDeleteAttempts = 3
+
+	// moveDeleteBackoff is the time to wait before the second attempt to delete an object
+	// in a move, it's doubled before each of the following attempts.
+	moveDeleteBackoff = 50 * time.Millisecond
+
+	// errorInfoMoveState is the key of a failed move's final state in the metadata
+	// of its error's google.rpc.ErrorInfo details.
+	errorInfoMoveState = "moveState"
+)
+
+// MoveState is the final state of a move.
+type MoveState string
+
+// The final states of a move.
+const (
+	// MoveStateMoved is the state of a move whose destination was copied and verified,
+	// and whose source was deleted.
+	MoveStateMoved MoveState = "MOVED"
+
+	// MoveStateNotMoved is the state of a move that failed before its source was copied,
+	// or whose copy failed, the source and the destination are left as they were.
+	MoveStateNotMoved MoveState = "NOT_MOVED"
+
+	// MoveStateRolledBack is the state of a move whose source couldn't be deleted,
+	// so its destination was deleted and the source is left as it was.
+	MoveStateRolledBack MoveState = "ROLLED_BACK"
+
+	// MoveStateDuplicated is the state of a move whose source couldn't be deleted,
+	// and whose destination couldn't be deleted either, so the object exists in both.
+	MoveStateDuplicated MoveState = "DUPLICATED"
+)
+
+// MoveResult is the result of a move.
+type MoveResult struct {
+	// State is the final state of the move.
+	State MoveState
+
+	// Journal is the steps the move has taken, in order.
+	Journal []string
+
+	// Verification is how the copy of the source was verified, empty if it wasn't copied.
+	Verification CopyVerification
+}
+
+// record adds a step to the move's journal.
+func (r *MoveResult) record(format string, args ...interface{}) {
+	r.Journal = append(r.Journal, fmt.Sprintf(format, args...))
+}
+
+// MoveObject moves an object from the source bucket and key to the destination bucket and key.
+// The object is copied and verified, and then the source is deleted, retrying failed deletes.
+// If the source can't be deleted, the move is rolled back by deleting the destination, even if
+// ctx is done, unless the destination existed before the move and was overwritten by it.
+// Returns the move's final state and its journal, which are set even if the move failed.
+// A move that failed after its source was copied returns an Aborted error with the move's
+// final state in its metadata.
+func (s *Service) MoveObject(
+	ctx aws.Context,
+	bucketSrc *string,
+	bucketDest *string,
+	keySrc *string,
+	keyDest *string,
+) (*MoveResult, error) {
+	result := &MoveResult{State: MoveStateNotMoved}
+
+	if bucketSrc != nil && bucketDest != nil && keySrc != nil && keyDest != nil &&
+		s.sameBucket(*bucketSrc, *bucketDest) && *keySrc == *keyDest {
+		return result, invalidArgument("source and destination are the same object")
+	}
+
+	// An object that was overwritten can't be restored, so it isn't deleted by a rollback.
+	destExisted, err := s.objectExists(ctx, keyDest, bucketDest)
+	if err != nil {
+		result.record("failed to check destination: %v", err)
+		return result, withMoveState(err, result.State)
+	}
+
+	verification, err := s.CopyObjectWithVerification(ctx, bucketSrc, bucketDest, keySrc, keyDest)
+	if err != nil {
+		result.record("copy failed: %v", err)
+		return result, withMoveState(err, result.State)
+	}
+	result.Verification = verification
+	result.record("copied and verified (%s) %s/%s as %s/%s", verification, *bucketSrc, *keySrc, *bucketDest, *keyDest)
+
+	deleteErr := s.deleteObjectWithRetries(ctx, keySrc, bucketSrc, result)
+	if deleteErr == nil {
+		result.State = MoveStateMoved
+		return result, nil
+	}
+
+	if destExisted {
+		result.State = MoveStateDuplicated
+		result.record("kept %s/%s, which existed before the move", *bucketDest, *keyDest)
+
+		return result, moveError(
+			deleteErr,
+			ReasonMoveIncomplete,
+			result.State,
+			"failed to delete source %s/%s, and the move wasn't rolled back since it overwrote %s/%s",
+			*bucketSrc,
+			*keySrc,
+			*bucketDest,
+			*keyDest,
+		)
+	}
+
+	// The source may have failed to delete because ctx is done, which mustn't stop the rollback.
+	rollbackCtx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	rollbackErr := s.deleteObjectWithRetries(rollbackCtx, keyDest, bucketDest, result)
+	if rollbackErr == nil {
+		result.State = MoveStateRolledBack
+		return result, moveError(
+			deleteErr,
+			ReasonMoveRolledBack,
+			result.State,
+			"failed to delete source %s/%s, the move was rolled back",
+			*bucketSrc,
+			*keySrc,
+		)
+	}
+
+	result.State = MoveStateDuplicated
+	return result, moveError(
+		deleteErr,
+		ReasonMoveIncomplete,
+		result.State,
+		"failed to delete source %s/%s, and failed to roll back the move by deleting %s/%s: %v",
+		*bucketSrc,
+		*keySrc,
+		*bucketDest,
+		*keyDest,
+		rollbackErr,
+	)
+}
+
+// sameBucket returns true if both bucket names are of the same bucket once normalized.
+func (s *Service) sameBucket(bucketA string, bucketB string) bool {
+	bucketService := bucket.NewService(s.backend)
+
+	return bucketService.NormalizeCephBucketName(bucketA) == bucketService.NormalizeCephBucketName(bucketB)
+}
+
+// objectExists returns true if the object of key exists in bucket. A missing bucket has no objects.
+func (s *Service) objectExists(ctx aws.Context, key *string, bucketName *string) (bool, error) {
+	if key == nil || *key == "" || bucketName == nil || *bucketName == "" {
+		return false, nil
+	}
+
+	normalized := bucket.NewService(s.backend).NormalizeCephBucketName(*bucketName)
+	_, err := s.backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(normalized), Key: key})
+	if err == nil {
+		return true, nil
+	}
+
+	wrapped := wrapError(err, "failed to check %s/%s", *bucketName, *key)
+	if svcErr, ok := wrapped.(*Error); ok && svcErr.Code == codes.NotFound {
+		return false, nil
+	}
+
+	return false, wrapped
+}
+
+// deleteObjectWithRetries deletes an object, retrying up to moveDeleteAttempts attempts
+// with exponential backoff, and records the attempts in the move's journal.
+func (s *Service) deleteObjectWithRetries(ctx aws.Context, key *string, bucket *string, result *MoveResult) error {
+	backoff := moveDeleteBackoff
+	var err error
+	for attempt := 1; attempt <= moveDeleteAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				result.record("stopped deleting %s/%s: %v", *bucket, *key, ctx.Err())
+				return ctx.Err()
+			}
+
+			backoff *= 2
+		}
+
+		if err = s.deleteObject(ctx, key, bucket); err == nil {
+			result.record("deleted %s/%s", *bucket, *key)
+			return nil
+		}
+
+		result.record("attempt %d to delete %s/%s failed: %v", attempt, *bucket, *key, err)
+	}
+
+	return err
+}
+
+// moveError returns an Aborted error of a move that failed after its source was copied,
+// caused by err, with the move's final state in its metadata.
+func moveError(err error, reason string, state MoveState, format string, args ...interface{}) error {
+	_, _, metadata := classifyError(err)
+	if metadata == nil {
+		metadata = make(map[string]string, 1)
+	}
+	metadata[errorInfoMoveState] = string(state)
+
+	return &Error{
+		Code:     codes.Aborted,
+		Reason:   reason,
+		Message:  fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err),
+		Metadata: metadata,
+		Err:      err,
+	}
+}
+
+// withMoveState returns err with the move's final state in its metadata.
+func withMoveState(err error, state MoveState) error {
+	var svcErr *Error
+	if !errors.As(err, &svcErr) {
+		return err
+	}
+
+	metadata := make(map[string]string, len(svcErr.Metadata)+1)
+	for k, v := range svcErr.Metadata {
+		metadata[k] = v
+	}
+	metadata[errorInfoMoveState] = string(state)
+
+	moveErr := *svcErr
+	moveErr.Metadata = metadata
+
+	return &moveErr
+}
+
+// deleteKeyError returns the error of a key that a DeleteObjects request failed to delete.
+func deleteKeyError(code *string, message *string) error {
+	return awserr.New(aws.StringValue(code), aws.StringValue(message), nil)
+}
@@ -3,67 +3,71 @@ package object_test
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
-	"log"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/meateam/upload-service/bucket"
 	"github.com/meateam/upload-service/internal/test"
 	"github.com/meateam/upload-service/object"
 	pb "github.com/meateam/upload-service/proto"
-	"github.com/meateam/upload-service/server"
+	"github.com/meateam/upload-service/storage"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
-const bufSize = 1024 * 1024
-
 // Declaring global variable.
 var (
-	logger     = logrus.New()
-	lis        *bufconn.Listener
-	s3Client   *s3.S3
-	s3Endpoint string
+	testServer *test.Server
+	backend    storage.Backend
+	endpoint   string
 )
 
 func init() {
-	lis = bufconn.Listen(bufSize)
-
-	// Disable log output.
-	logger.SetOutput(ioutil.Discard)
-	uploadServer := server.NewServer(logger)
-
-	s3Client = uploadServer.GetHandler().GetService().GetS3Client()
-	s3Endpoint = s3Client.Endpoint
-
-	go uploadServer.Serve(lis)
+	// Start an upload server on top of an in-memory storage backend, with log output disabled.
+	testServer = test.NewServer(nil)
 
-	if err := test.EmptyAndDeleteBucket(s3Client, "testbucket"); err != nil {
-		log.Printf("test.EmptyAndDeleteBucket failed with error: %v", err)
-	}
-	if err := test.EmptyAndDeleteBucket(s3Client, "testbucket1"); err != nil {
-		log.Printf("test.EmptyAndDeleteBucket failed with error: %v", err)
-	}
+	memoryBackend := testServer.GetBackend()
+	backend = memoryBackend
+	endpoint = memoryBackend.GetEndpoint()
 }
 
-func bufDialer(context.Context, string) (net.Conn, error) {
-	return lis.Dial()
+func bufDialer(ctx context.Context, addr string) (net.Conn, error) {
+	return testServer.Dialer(ctx, addr)
 }
 
 func TestService_UploadFile(t *testing.T) {
 	metadata := make(map[string]*string)
 	metadata["test"] = aws.String("testt")
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 	type args struct {
 		file        io.Reader
@@ -83,7 +87,7 @@ func TestService_UploadFile(t *testing.T) {
 	}{
 		{
 			name:   "upload text file",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      aws.String("testbucket"),
@@ -93,11 +97,11 @@ func TestService_UploadFile(t *testing.T) {
 				ctx:         context.Background(),
 			},
 			wantErr: false,
-			want:    aws.String(fmt.Sprintf("%s/testbucket/testfile.txt", s3Endpoint)),
+			want:    aws.String(fmt.Sprintf("%s/testbucket/testfile.txt", endpoint)),
 		},
 		{
 			name:   "upload text file in a folder",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfolder/testfile.txt"),
 				bucket:      aws.String("testbucket"),
@@ -107,11 +111,11 @@ func TestService_UploadFile(t *testing.T) {
 				ctx:         context.Background(),
 			},
 			wantErr: false,
-			want:    aws.String(fmt.Sprintf("%s/testbucket/testfolder/testfile.txt", s3Endpoint)),
+			want:    aws.String(fmt.Sprintf("%s/testbucket/testfolder/testfile.txt", endpoint)),
 		},
 		{
 			name:   "upload text file with empty key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String(""),
 				bucket:      aws.String("testbucket"),
@@ -124,7 +128,7 @@ func TestService_UploadFile(t *testing.T) {
 		},
 		{
 			name:   "upload text file with empty bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      aws.String(""),
@@ -137,7 +141,7 @@ func TestService_UploadFile(t *testing.T) {
 		},
 		{
 			name:   "upload text file with nil key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         nil,
 				bucket:      aws.String("testbucket"),
@@ -150,7 +154,7 @@ func TestService_UploadFile(t *testing.T) {
 		},
 		{
 			name:   "upload text file with nil bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      nil,
@@ -163,7 +167,7 @@ func TestService_UploadFile(t *testing.T) {
 		},
 		{
 			name:   "upload nil file",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      aws.String("testbucket"),
@@ -178,7 +182,7 @@ func TestService_UploadFile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := object.NewService(tt.fields.s3Client)
+			s := object.NewService(tt.fields.backend)
 
 			got, err := s.UploadFile(
 				tt.args.ctx,
@@ -206,7 +210,7 @@ func TestHandler_UploadMedia(t *testing.T) {
 		t.Errorf("Could not generate file with error: %v", err)
 	}
 
-	uploadservice := object.NewService(s3Client)
+	uploadservice := object.NewService(backend)
 
 	type fields struct {
 		UploadService *object.Service
@@ -236,7 +240,7 @@ func TestHandler_UploadMedia(t *testing.T) {
 			},
 			wantErr: false,
 			want: &pb.UploadMediaResponse{
-				Location: fmt.Sprintf("%s/testbucket/testfile.txt", s3Endpoint),
+				Location: fmt.Sprintf("%s/testbucket/testfile.txt", endpoint),
 			},
 		},
 		{
@@ -281,7 +285,7 @@ func TestHandler_UploadMedia(t *testing.T) {
 			},
 			wantErr: false,
 			want: &pb.UploadMediaResponse{
-				Location: fmt.Sprintf("%s/testbucket/testfile.txt", s3Endpoint),
+				Location: fmt.Sprintf("%s/testbucket/testfile.txt", endpoint),
 			},
 		},
 		{
@@ -298,7 +302,7 @@ func TestHandler_UploadMedia(t *testing.T) {
 			},
 			wantErr: false,
 			want: &pb.UploadMediaResponse{
-				Location: fmt.Sprintf("%s/testbucket/testfile.txt", s3Endpoint),
+				Location: fmt.Sprintf("%s/testbucket/testfile.txt", endpoint),
 			},
 		},
 	}
@@ -322,7 +326,7 @@ func TestHandler_UploadMedia(t *testing.T) {
 				t.Errorf("UploadHandler.UploadMedia() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != nil && !reflect.DeepEqual(got, tt.want) {
+			if got != nil && !cmp.Equal(tt.want, got, cmpopts.IgnoreUnexported(pb.UploadMediaResponse{})) {
 				t.Errorf("UploadHandler.UploadMedia() = %v, want %v", got, tt.want)
 			}
 		})
@@ -333,7 +337,7 @@ func TestService_UploadInit(t *testing.T) {
 	metadata := make(map[string]*string)
 	metadata["test"] = aws.String("testt")
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 	type args struct {
 		key         *string
@@ -351,7 +355,7 @@ func TestService_UploadInit(t *testing.T) {
 	}{
 		{
 			name:   "init upload",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      aws.String("testbucket"),
@@ -367,7 +371,7 @@ func TestService_UploadInit(t *testing.T) {
 		},
 		{
 			name:   "init upload in folder",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfolder/testfile.txt"),
 				contentType: aws.String("text/plain"),
@@ -383,7 +387,7 @@ func TestService_UploadInit(t *testing.T) {
 		},
 		{
 			name:   "init upload with missing key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String(""),
 				bucket:      aws.String("testbucket"),
@@ -395,7 +399,7 @@ func TestService_UploadInit(t *testing.T) {
 		},
 		{
 			name:   "init upload with nil key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         nil,
 				bucket:      aws.String("testbucket"),
@@ -407,7 +411,7 @@ func TestService_UploadInit(t *testing.T) {
 		},
 		{
 			name:   "init upload with missing bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      aws.String(""),
@@ -419,7 +423,7 @@ func TestService_UploadInit(t *testing.T) {
 		},
 		{
 			name:   "init upload with nil bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      nil,
@@ -431,7 +435,7 @@ func TestService_UploadInit(t *testing.T) {
 		},
 		{
 			name:   "init upload with empty metadata",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      aws.String("testbucket"),
@@ -447,7 +451,7 @@ func TestService_UploadInit(t *testing.T) {
 		},
 		{
 			name:   "init upload with nil metadata",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:         aws.String("testfile.txt"),
 				bucket:      aws.String("testbucket"),
@@ -464,7 +468,7 @@ func TestService_UploadInit(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := object.NewService(tt.fields.s3Client)
+			s := object.NewService(tt.fields.backend)
 
 			got, err := s.UploadInit(tt.args.ctx, tt.args.key, tt.args.bucket, tt.args.contentType, tt.args.metadata)
 			if (err != nil) != tt.wantErr {
@@ -481,7 +485,7 @@ func TestService_UploadInit(t *testing.T) {
 func TestHandler_UploadInit(t *testing.T) {
 	metadata := make(map[string]string)
 	metadata["test"] = "testt"
-	uploadservice := object.NewService(s3Client)
+	uploadservice := object.NewService(backend)
 
 	type fields struct {
 		UploadService *object.Service
@@ -635,7 +639,7 @@ func TestService_UploadPart(t *testing.T) {
 	fileReader := bytes.NewReader(file)
 
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 	type args struct {
 		initKey    *string
@@ -654,7 +658,7 @@ func TestService_UploadPart(t *testing.T) {
 	}{
 		{
 			name:   "upload part",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile.txt"),
 				initBucket: aws.String("testbucket"),
@@ -668,7 +672,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part in folder",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("testfolder/partfile.txt"),
 				initBucket: aws.String("testbucket"),
@@ -682,7 +686,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with empty key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile1.txt"),
 				initBucket: aws.String("testbucket"),
@@ -696,7 +700,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with nil key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile2.txt"),
 				initBucket: aws.String("testbucket"),
@@ -710,7 +714,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with key mismatch",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile3.txt"),
 				initBucket: aws.String("testbucket"),
@@ -724,7 +728,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with empty bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile4.txt"),
 				initBucket: aws.String("testbucket"),
@@ -738,7 +742,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with nil bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile5.txt"),
 				initBucket: aws.String("testbucket"),
@@ -752,7 +756,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with bucket mismatch",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile6.txt"),
 				initBucket: aws.String("testbucket"),
@@ -766,7 +770,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with nil body",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile6.txt"),
 				initBucket: aws.String("testbucket"),
@@ -780,7 +784,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part without part number",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile8.txt"),
 				initBucket: aws.String("testbucket"),
@@ -794,7 +798,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with part number lower than 1",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile7.txt"),
 				initBucket: aws.String("testbucket"),
@@ -808,7 +812,7 @@ func TestService_UploadPart(t *testing.T) {
 		},
 		{
 			name:   "upload part with part number greater than 10000",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("partfile7.txt"),
 				initBucket: aws.String("testbucket"),
@@ -823,7 +827,7 @@ func TestService_UploadPart(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := object.NewService(tt.fields.s3Client)
+			s := object.NewService(tt.fields.backend)
 
 			initOutput, err := s.UploadInit(
 				tt.args.ctx,
@@ -855,7 +859,7 @@ func TestService_UploadPart(t *testing.T) {
 	}
 
 	t.Run("UploadPart - nil UploadID", func(t *testing.T) {
-		s := object.NewService(s3Client)
+		s := object.NewService(backend)
 
 		ctx := context.Background()
 		got, err := s.UploadPart(
@@ -875,7 +879,7 @@ func TestService_UploadPart(t *testing.T) {
 		}
 	})
 	t.Run("UploadPart - empty UploadID", func(t *testing.T) {
-		s := object.NewService(s3Client)
+		s := object.NewService(backend)
 
 		ctx := context.Background()
 		got, err := s.UploadPart(
@@ -906,7 +910,7 @@ func TestService_UploadComplete(t *testing.T) {
 	fileReader := bytes.NewReader(file)
 
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 	type args struct {
 		initKey    *string
@@ -923,7 +927,7 @@ func TestService_UploadComplete(t *testing.T) {
 	}{
 		{
 			name:   "Upload Complete",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -935,7 +939,7 @@ func TestService_UploadComplete(t *testing.T) {
 		},
 		{
 			name:   "Upload Complete to folder",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("testfolder/file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -947,7 +951,7 @@ func TestService_UploadComplete(t *testing.T) {
 		},
 		{
 			name:   "Upload Complete with empty key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -959,7 +963,7 @@ func TestService_UploadComplete(t *testing.T) {
 		},
 		{
 			name:   "Upload Complete with nil key",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -971,7 +975,7 @@ func TestService_UploadComplete(t *testing.T) {
 		},
 		{
 			name:   "Upload Complete with key mismatch",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -983,7 +987,7 @@ func TestService_UploadComplete(t *testing.T) {
 		},
 		{
 			name:   "Upload Complete with empty bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -995,7 +999,7 @@ func TestService_UploadComplete(t *testing.T) {
 		},
 		{
 			name:   "Upload Complete with nil bucket",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -1007,7 +1011,7 @@ func TestService_UploadComplete(t *testing.T) {
 		},
 		{
 			name:   "Upload Complete with bucket mismatch",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				initKey:    aws.String("file.txt"),
 				initBucket: aws.String("testbucket"),
@@ -1020,7 +1024,7 @@ func TestService_UploadComplete(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := object.NewService(tt.fields.s3Client)
+			s := object.NewService(tt.fields.backend)
 
 			initOutput, err := s.UploadInit(
 				tt.args.ctx,
@@ -1047,7 +1051,7 @@ func TestService_UploadComplete(t *testing.T) {
 				return
 			}
 
-			got, err := s.UploadComplete(tt.args.ctx, initOutput.UploadId, tt.args.key, tt.args.bucket)
+			got, err := s.UploadComplete(tt.args.ctx, initOutput.UploadId, tt.args.key, tt.args.bucket, nil, nil, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UploadService.UploadComplete() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1058,10 +1062,10 @@ func TestService_UploadComplete(t *testing.T) {
 		})
 	}
 	t.Run("UploadComplete - empty uploadID ", func(t *testing.T) {
-		s := object.NewService(s3Client)
+		s := object.NewService(backend)
 
 		ctx := context.Background()
-		got, err := s.UploadComplete(ctx, aws.String(""), aws.String("tests.txt"), aws.String("testbucket"))
+		got, err := s.UploadComplete(ctx, aws.String(""), aws.String("tests.txt"), aws.String("testbucket"), nil, nil, nil)
 		if err == nil {
 			t.Errorf("UploadService.UploadComplete() error = %v, wantErr %v", err, true)
 			return
@@ -1073,10 +1077,10 @@ func TestService_UploadComplete(t *testing.T) {
 	})
 
 	t.Run("UploadComplete - nil uploadID ", func(t *testing.T) {
-		s := object.NewService(s3Client)
+		s := object.NewService(backend)
 
 		ctx := context.Background()
-		got, err := s.UploadComplete(ctx, nil, aws.String("tests.txt"), aws.String("testbucket"))
+		got, err := s.UploadComplete(ctx, nil, aws.String("tests.txt"), aws.String("testbucket"), nil, nil, nil)
 		if err == nil {
 			t.Errorf("UploadService.UploadComplete() error = %v, wantErr %v", err, true)
 			return
@@ -1088,6 +1092,343 @@ func TestService_UploadComplete(t *testing.T) {
 	})
 }
 
+func TestService_UploadCompleteValidation(t *testing.T) {
+	firstPart := make([]byte, 5<<20)
+	if _, err := rand.Read(firstPart); err != nil {
+		t.Fatalf("Could not generate part with error: %v", err)
+	}
+	lastPart := []byte("last part")
+	fileChecksum := sha256.Sum256(append(append([]byte{}, firstPart...), lastPart...))
+	fileSize := int64(len(firstPart) + len(lastPart))
+
+	s := object.NewService(backend)
+	ctx := context.Background()
+
+	// uploadParts initiates an upload of key and uploads its two parts,
+	// and returns the upload's ID and the parts' ETags.
+	uploadParts := func(key string) (*string, []*s3.CompletedPart) {
+		initOutput, err := s.UploadInit(ctx, aws.String(key), aws.String("testbucket"), nil, nil)
+		if err != nil {
+			t.Fatalf("UploadService.UploadInit() error = %v", err)
+		}
+
+		parts := make([]*s3.CompletedPart, 0, 2)
+		for i, part := range [][]byte{firstPart, lastPart} {
+			partNumber := aws.Int64(int64(i + 1))
+			output, err := s.UploadPart(ctx, initOutput.UploadId, aws.String(key), aws.String("testbucket"), partNumber, bytes.NewReader(part))
+			if err != nil {
+				t.Fatalf("UploadService.UploadPart() error = %v", err)
+			}
+
+			parts = append(parts, &s3.CompletedPart{PartNumber: partNumber, ETag: output.ETag})
+		}
+
+		return initOutput.UploadId, parts
+	}
+
+	tests := []struct {
+		name           string
+		parts          func([]*s3.CompletedPart) []*s3.CompletedPart
+		size           *int64
+		checksum       *string
+		wantCode       codes.Code
+		wantViolations []string
+	}{
+		{
+			name:     "matching parts, size and checksum",
+			parts:    func(parts []*s3.CompletedPart) []*s3.CompletedPart { return parts },
+			size:     aws.Int64(fileSize),
+			checksum: aws.String(hex.EncodeToString(fileChecksum[:])),
+			wantCode: codes.OK,
+		},
+		{
+			name: "missing part",
+			parts: func(parts []*s3.CompletedPart) []*s3.CompletedPart {
+				return append(parts, &s3.CompletedPart{PartNumber: aws.Int64(3), ETag: aws.String("etag")})
+			},
+			wantCode:       codes.FailedPrecondition,
+			wantViolations: []string{"PART_MISSING"},
+		},
+		{
+			name: "stale part ETag",
+			parts: func(parts []*s3.CompletedPart) []*s3.CompletedPart {
+				parts[0].ETag = aws.String(`"stale"`)
+				return parts
+			},
+			wantCode:       codes.FailedPrecondition,
+			wantViolations: []string{"PART_ETAG_MISMATCH"},
+		},
+		{
+			name:           "size mismatch",
+			parts:          func(parts []*s3.CompletedPart) []*s3.CompletedPart { return parts },
+			size:           aws.Int64(fileSize + 1),
+			wantCode:       codes.FailedPrecondition,
+			wantViolations: []string{"SIZE_MISMATCH"},
+		},
+		{
+			name:           "checksum mismatch",
+			parts:          func(parts []*s3.CompletedPart) []*s3.CompletedPart { return nil },
+			checksum:       aws.String(strings.Repeat("0", 64)),
+			wantCode:       codes.DataLoss,
+			wantViolations: []string{"CHECKSUM_MISMATCH"},
+		},
+		{
+			name: "parts out of order",
+			parts: func(parts []*s3.CompletedPart) []*s3.CompletedPart {
+				return []*s3.CompletedPart{parts[1], parts[0]}
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid checksum",
+			parts:    func(parts []*s3.CompletedPart) []*s3.CompletedPart { return parts },
+			checksum: aws.String("notachecksum"),
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := fmt.Sprintf("validatedfile%d", i)
+			uploadID, parts := uploadParts(key)
+
+			_, err := s.UploadComplete(ctx, uploadID, aws.String(key), aws.String("testbucket"), tt.parts(parts), tt.size, tt.checksum)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("UploadService.UploadComplete() error = %v, wantCode %v", err, tt.wantCode)
+			}
+
+			var svcErr *object.Error
+			var violations []string
+			if errors.As(err, &svcErr) {
+				for _, violation := range svcErr.Violations {
+					violations = append(violations, violation.Type)
+				}
+			}
+
+			if !reflect.DeepEqual(violations, tt.wantViolations) {
+				t.Errorf("UploadService.UploadComplete() violations = %v, want %v", violations, tt.wantViolations)
+			}
+
+			// An object whose checksum doesn't match must not be left behind.
+			_, err = s.HeadObject(ctx, aws.String(key), aws.String("testbucket"))
+			if exists := err == nil; exists != (tt.wantCode == codes.OK) {
+				t.Errorf("UploadService.UploadComplete() object exists = %v, want %v", exists, tt.wantCode == codes.OK)
+			}
+		})
+	}
+}
+
+// s3LikeBackend is a storage backend that can't read the parts of an upload before
+// it's completed, like S3.
+type s3LikeBackend struct {
+	storage.Backend
+}
+
+func TestService_UploadCompleteChecksumMismatch(t *testing.T) {
+	content := []byte("new content")
+	contentSum := sha256.Sum256(content)
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		backend        storage.Backend
+		existing       []byte
+		wantUploadKept bool
+	}{
+		{
+			name:           "verified before completing",
+			backend:        storage.NewMemoryBackend(),
+			existing:       []byte("existing content"),
+			wantUploadKept: true,
+		},
+		{
+			name:     "verified after completing restores the replaced object",
+			backend:  s3LikeBackend{Backend: storage.NewMemoryBackend()},
+			existing: []byte("existing content"),
+		},
+		{
+			name:    "verified after completing deletes the object",
+			backend: s3LikeBackend{Backend: storage.NewMemoryBackend()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := object.NewService(tt.backend)
+			key, bucket := aws.String("checksummedfile"), aws.String("checksumbucket")
+			if tt.existing != nil {
+				if _, err := s.UploadFile(ctx, bytes.NewReader(tt.existing), key, bucket, aws.String("text/plain"), nil); err != nil {
+					t.Fatalf("Service.UploadFile() error = %v", err)
+				}
+			}
+
+			upload, err := s.UploadInit(ctx, key, bucket, aws.String("text/plain"), nil)
+			if err != nil {
+				t.Fatalf("Service.UploadInit() error = %v", err)
+			}
+
+			if _, err := s.UploadPart(ctx, upload.UploadId, key, bucket, aws.Int64(1), bytes.NewReader(content)); err != nil {
+				t.Fatalf("Service.UploadPart() error = %v", err)
+			}
+
+			_, err = s.UploadComplete(ctx, upload.UploadId, key, bucket, nil, nil, aws.String(strings.Repeat("0", 64)))
+			if status.Code(err) != codes.DataLoss {
+				t.Fatalf("Service.UploadComplete() error = %v, want code %v", err, codes.DataLoss)
+			}
+
+			var svcErr *object.Error
+			if !errors.As(err, &svcErr) || len(svcErr.Violations) != 1 || svcErr.Violations[0].Type != "CHECKSUM_MISMATCH" {
+				t.Errorf("Service.UploadComplete() error = %#v, want a CHECKSUM_MISMATCH violation", err)
+			}
+
+			obj, err := s.GetObject(ctx, key, bucket, nil)
+			if tt.existing == nil {
+				if status.Code(err) != codes.NotFound {
+					t.Errorf("Service.GetObject() error = %v, want code %v", err, codes.NotFound)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Service.GetObject() error = %v", err)
+				}
+				defer obj.Body.Close()
+
+				if got, _ := ioutil.ReadAll(obj.Body); !bytes.Equal(got, tt.existing) {
+					t.Errorf("Service.UploadComplete() left object %q, want the existing %q", got, tt.existing)
+				}
+			}
+
+			list, err := s.ListObjects(ctx, bucket, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("Service.ListObjects() error = %v", err)
+			}
+
+			// No backup of the replaced object is left behind.
+			wantObjects := 0
+			if tt.existing != nil {
+				wantObjects = 1
+			}
+
+			if len(list.Contents) != wantObjects {
+				t.Errorf("Service.UploadComplete() left %d objects, want %d", len(list.Contents), wantObjects)
+			}
+
+			_, err = s.UploadComplete(ctx, upload.UploadId, key, bucket, nil, nil, aws.String(hex.EncodeToString(contentSum[:])))
+			if (err == nil) != tt.wantUploadKept {
+				t.Errorf("Service.UploadComplete() again error = %v, want the upload kept = %v", err, tt.wantUploadKept)
+			}
+		})
+	}
+}
+
+func TestService_UploadFileChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+	badSum := &object.Checksum{Algorithm: object.ChecksumMD5, Value: strings.Repeat("0", 32)}
+
+	tests := []struct {
+		name     string
+		existing []byte
+	}{
+		{
+			name:     "restores the replaced object",
+			existing: []byte("existing content"),
+		},
+		{
+			name: "deletes the object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := object.NewService(storage.NewMemoryBackend())
+			key, bucket := aws.String("checksummedfile"), aws.String("checksumbucket")
+			if tt.existing != nil {
+				if _, err := s.UploadFile(ctx, bytes.NewReader(tt.existing), key, bucket, aws.String("text/plain"), nil); err != nil {
+					t.Fatalf("Service.UploadFile() error = %v", err)
+				}
+			}
+
+			_, err := s.UploadFileWithChecksum(ctx, strings.NewReader("new content"), key, bucket, aws.String("text/plain"), nil, badSum)
+			if status.Code(err) != codes.DataLoss {
+				t.Fatalf("Service.UploadFileWithChecksum() error = %v, want code %v", err, codes.DataLoss)
+			}
+
+			obj, err := s.GetObject(ctx, key, bucket, nil)
+			if tt.existing == nil {
+				if status.Code(err) != codes.NotFound {
+					t.Errorf("Service.GetObject() error = %v, want code %v", err, codes.NotFound)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Service.GetObject() error = %v", err)
+				}
+				defer obj.Body.Close()
+
+				if got, _ := ioutil.ReadAll(obj.Body); !bytes.Equal(got, tt.existing) {
+					t.Errorf("Service.UploadFileWithChecksum() left object %q, want the existing %q", got, tt.existing)
+				}
+			}
+
+			list, err := s.ListObjects(ctx, bucket, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("Service.ListObjects() error = %v", err)
+			}
+
+			// No backup of the replaced object is left behind.
+			wantObjects := 0
+			if tt.existing != nil {
+				wantObjects = 1
+			}
+
+			if len(list.Contents) != wantObjects {
+				t.Errorf("Service.UploadFileWithChecksum() left %d objects, want %d", len(list.Contents), wantObjects)
+			}
+		})
+	}
+}
+
+func TestService_UploadReservedMetadata(t *testing.T) {
+	ctx := context.Background()
+	s := object.NewService(storage.NewMemoryBackend())
+	key, bucket := aws.String("reservedfile"), aws.String("testbucket")
+	metadata := map[string]*string{"test": aws.String("testt"), "checksum-Sha256": aws.String("ab")}
+	checksum := &object.Checksum{Algorithm: object.ChecksumMD5, Value: "9e107d9d372bb6826bd81d3542a419d6"}
+
+	tests := []struct {
+		name   string
+		upload func() error
+	}{
+		{
+			name: "UploadFile",
+			upload: func() error {
+				_, err := s.UploadFile(ctx, strings.NewReader("data"), key, bucket, aws.String("text/plain"), metadata)
+				return err
+			},
+		},
+		{
+			name: "UploadFileWithChecksum",
+			upload: func() error {
+				_, err := s.UploadFileWithChecksum(ctx, strings.NewReader("data"), key, bucket, aws.String("text/plain"), metadata, checksum)
+				return err
+			},
+		},
+		{
+			name: "UploadInit",
+			upload: func() error {
+				_, err := s.UploadInit(ctx, key, bucket, aws.String("text/plain"), metadata)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.upload(); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Service.%s() error = %v, want code %v", tt.name, err, codes.InvalidArgument)
+			}
+		})
+	}
+
+	if _, err := s.HeadObject(ctx, key, bucket); status.Code(err) != codes.NotFound {
+		t.Errorf("Service.HeadObject() error = %v, want code %v", err, codes.NotFound)
+	}
+}
+
 func TestHandler_UploadMultipart(t *testing.T) {
 	// Init global values to use in tests.
 	file := make([]byte, 5<<20)
@@ -1121,7 +1462,7 @@ func TestHandler_UploadMultipart(t *testing.T) {
 			},
 			wantErr: false,
 			want: &pb.UploadMultipartResponse{
-				Location: fmt.Sprintf("%s/testbucket/testfile.txt", s3Endpoint),
+				Location: fmt.Sprintf("%s/testbucket/testfile.txt", endpoint),
 			},
 		},
 		{
@@ -1138,7 +1479,7 @@ func TestHandler_UploadMultipart(t *testing.T) {
 			},
 			wantErr: false,
 			want: &pb.UploadMultipartResponse{
-				Location: fmt.Sprintf("%s/testbucket/testfolder/testfile.txt", s3Endpoint),
+				Location: fmt.Sprintf("%s/testbucket/testfolder/testfile.txt", endpoint),
 			},
 		},
 		{
@@ -1183,7 +1524,7 @@ func TestHandler_UploadMultipart(t *testing.T) {
 			},
 			wantErr: false,
 			want: &pb.UploadMultipartResponse{
-				Location: fmt.Sprintf("%s/testbucket/testfile.txt", s3Endpoint),
+				Location: fmt.Sprintf("%s/testbucket/testfile.txt", endpoint),
 			},
 		},
 		{
@@ -1200,7 +1541,7 @@ func TestHandler_UploadMultipart(t *testing.T) {
 			},
 			wantErr: false,
 			want: &pb.UploadMultipartResponse{
-				Location: fmt.Sprintf("%s/testbucket/testfile.txt", s3Endpoint),
+				Location: fmt.Sprintf("%s/testbucket/testfile.txt", endpoint),
 			},
 		},
 		{
@@ -1251,7 +1592,7 @@ func TestHandler_UploadMultipart(t *testing.T) {
 				t.Errorf("UploadHandler.UploadMultipart() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if got != nil && !cmp.Equal(tt.want, got, cmpopts.IgnoreUnexported(pb.UploadMultipartResponse{})) {
 				t.Errorf("UploadHandler.UploadMultipart() = %v, want %v", got, tt.want)
 			}
 		})
@@ -1267,7 +1608,7 @@ func TestService_UploadAbort(t *testing.T) {
 	}
 	fileReader := bytes.NewReader(file)
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 	type args struct {
 		ctx    aws.Context
@@ -1283,7 +1624,7 @@ func TestService_UploadAbort(t *testing.T) {
 	}{
 		{
 			name:   "init upload",
-			fields: fields{s3Client: s3Client},
+			fields: fields{backend: backend},
 			args: args{
 				key:    aws.String("testfile.txt"),
 				bucket: aws.String("testbucket"),
@@ -1295,7 +1636,7 @@ func TestService_UploadAbort(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := object.NewService(tt.fields.s3Client)
+			s := object.NewService(tt.fields.backend)
 
 			initOutput, err := s.UploadInit(tt.args.ctx, tt.args.key, tt.args.bucket, aws.String("text/plain"), metadata)
 			if err != nil {
@@ -1328,211 +1669,508 @@ func TestService_UploadAbort(t *testing.T) {
 	}
 }
 
-func TestService_DeleteObjects(t *testing.T) {
-	uploadservice := object.NewService(s3Client)
-	key1, err := uploadservice.UploadFile(
-		context.Background(),
-		bytes.NewReader([]byte("Hello, World!")),
-		aws.String("file1"),
-		aws.String("testbucket"),
-		aws.String("text/plain"),
-		nil,
-	)
+func TestHandler_GetUploadStatus(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
-		t.Errorf("Could not create file with error: %v", err)
+		t.Fatalf("Failed to dial bufnet: %v", err)
 	}
-	key2, err := uploadservice.UploadFile(
-		context.Background(),
-		bytes.NewReader([]byte("Hello, World!")),
-		aws.String("file2"),
-		aws.String("testbucket"),
-		aws.String("text/plain"),
-		nil,
-	)
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	upload, err := client.UploadInit(ctx, &pb.UploadInitRequest{
+		Key:         "statusfile",
+		Bucket:      "testbucket",
+		ContentType: "text/plain",
+		Metadata:    map[string]string{"owner": "test"},
+	})
 	if err != nil {
-		t.Errorf("Could not create file with error: %v", err)
+		t.Fatalf("Handler.UploadInit() error = %v", err)
 	}
-	key3, err := uploadservice.UploadFile(
-		context.Background(),
-		bytes.NewReader([]byte("Hello, World!")),
-		aws.String("file3"),
-		aws.String("testbucket"),
-		aws.String("text/plain"),
-		nil,
-	)
-	if err != nil {
-		t.Errorf("Could not create file with error: %v", err)
+
+	uploadservice := object.NewService(backend)
+	for _, partNumber := range []int64{3, 1} {
+		_, err := uploadservice.UploadPart(ctx, aws.String(upload.GetUploadId()), aws.String("statusfile"), aws.String("testbucket"), aws.Int64(partNumber), bytes.NewReader(bytes.Repeat([]byte("a"), int(partNumber))))
+		if err != nil {
+			t.Fatalf("Service.UploadPart() error = %v", err)
+		}
 	}
-	key4, err := uploadservice.UploadFile(
-		context.Background(),
-		bytes.NewReader([]byte("Hello, World!")),
-		aws.String("file4"),
-		aws.String("testbucket"),
-		aws.String("text/plain"),
-		nil,
-	)
+
+	got, err := client.GetUploadStatus(ctx, &pb.GetUploadStatusRequest{UploadId: upload.GetUploadId(), Key: "statusfile", Bucket: "testbucket"})
 	if err != nil {
-		t.Errorf("Could not create file with error: %v", err)
+		t.Fatalf("Handler.GetUploadStatus() error = %v", err)
 	}
-	type fields struct {
-		s3Client *s3.S3
+
+	want := &pb.GetUploadStatusResponse{
+		UploadId:    upload.GetUploadId(),
+		Key:         "statusfile",
+		Bucket:      "testbucket",
+		ContentType: "text/plain",
+		Metadata:    map[string]string{"owner": "test"},
+		Size:        4,
 	}
-	type args struct {
-		ctx    aws.Context
-		bucket *string
-		keys   []*string
+	if !cmp.Equal(got, want, cmpopts.IgnoreUnexported(pb.GetUploadStatusResponse{}), cmpopts.IgnoreFields(pb.GetUploadStatusResponse{}, "Initiated", "Parts", "Expires")) {
+		t.Errorf("Handler.GetUploadStatus() = %v, want %v", got, want)
 	}
+
+	if time.Since(time.Unix(got.GetInitiated(), 0)) > time.Minute {
+		t.Errorf("Handler.GetUploadStatus() initiated = %v", time.Unix(got.GetInitiated(), 0))
+	}
+
+	if got.GetExpires() < got.GetInitiated()+int64(object.DefaultSessionTTL/time.Second)-60 {
+		t.Errorf("Handler.GetUploadStatus() expires = %v, want the default session TTL after it was initiated", time.Unix(got.GetExpires(), 0))
+	}
+
+	if len(got.GetParts()) != 2 || got.GetParts()[0].GetPartNumber() != 1 || got.GetParts()[1].GetPartNumber() != 3 ||
+		got.GetParts()[1].GetSize() != 3 || got.GetParts()[1].GetETag() == "" {
+		t.Errorf("Handler.GetUploadStatus() parts = %v, want parts 1 and 3", got.GetParts())
+	}
+
+	if _, err := client.UploadAbort(ctx, &pb.UploadAbortRequest{UploadId: upload.GetUploadId(), Key: "statusfile", Bucket: "testbucket"}); err != nil {
+		t.Fatalf("Handler.UploadAbort() error = %v", err)
+	}
+
 	tests := []struct {
-		name        string
-		fields      fields
-		args        args
-		wantSuccess []string
-		wantFailed  []string
-		wantErr     bool
+		name     string
+		request  *pb.GetUploadStatusRequest
+		wantCode codes.Code
 	}{
 		{
-			name: "delete only one object",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   []*string{key1},
-				bucket: aws.String("testbucket"),
-				ctx:    context.Background(),
-			},
-			wantSuccess: []string{*key1},
-			wantFailed:  []string{},
-			wantErr:     false,
+			name:     "aborted upload",
+			request:  &pb.GetUploadStatusRequest{UploadId: upload.GetUploadId(), Key: "statusfile", Bucket: "testbucket"},
+			wantCode: codes.NotFound,
 		},
 		{
-			name: "delete two objects",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   []*string{key2, key3},
-				bucket: aws.String("testbucket"),
-				ctx:    context.Background(),
-			},
-			wantSuccess: []string{*key2, *key3},
-			wantFailed:  []string{},
-			wantErr:     false,
+			name:     "upload that doesn't exist",
+			request:  &pb.GetUploadStatusRequest{UploadId: "notexistupload", Key: "statusfile", Bucket: "testbucket"},
+			wantCode: codes.NotFound,
 		},
 		{
-			name: "delete valid and invalid objects",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   []*string{key4, aws.String("oneoneone")},
-				bucket: aws.String("testbucket"),
-				ctx:    context.Background(),
-			},
-			wantSuccess: []string{*key4, "oneoneone"},
-			wantFailed:  []string{},
-			wantErr:     false,
+			name:     "upload without id",
+			request:  &pb.GetUploadStatusRequest{Key: "statusfile", Bucket: "testbucket"},
+			wantCode: codes.InvalidArgument,
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetUploadStatus(ctx, tt.request)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Handler.GetUploadStatus() error = %v, wantCode %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestService_ListMultipartUploadsBucketName(t *testing.T) {
+	ctx := context.Background()
+	s := object.NewService(storage.NewMemoryBackend())
+	upload, err := s.UploadInit(ctx, aws.String("listedfile"), aws.String("Test_Bucket"), aws.String("text/plain"), nil)
+	if err != nil {
+		t.Fatalf("Service.UploadInit() error = %v", err)
+	}
+
+	bucket := aws.String("Test_Bucket")
+	list, err := s.ListMultipartUploads(ctx, bucket, nil, time.Time{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Service.ListMultipartUploads() error = %v", err)
+	}
+
+	if len(list.Uploads) != 1 || list.Uploads[0].UploadID != *upload.UploadId {
+		t.Errorf("Service.ListMultipartUploads() = %+v, want upload %s", list.Uploads, *upload.UploadId)
+	}
+
+	if *bucket != "Test_Bucket" {
+		t.Errorf("Service.ListMultipartUploads() changed the bucket name to %s", *bucket)
+	}
+}
+
+func TestHandler_MultipartUploads(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	uploadIDs := make(map[string]string)
+	for _, key := range []string{"a/1", "a/2", "b/1"} {
+		upload, err := client.UploadInit(ctx, &pb.UploadInitRequest{Key: key, Bucket: "uploadsbucket", ContentType: "text/plain"})
+		if err != nil {
+			t.Fatalf("Handler.UploadInit() error = %v", err)
+		}
+
+		uploadIDs[key] = upload.GetUploadId()
+	}
+
+	listKeys := func(request *pb.ListMultipartUploadsRequest) ([]string, string, error) {
+		list, err := client.ListMultipartUploads(ctx, request)
+		if err != nil {
+			return nil, "", err
+		}
+
+		keys := []string{}
+		for _, upload := range list.GetUploads() {
+			if upload.GetUploadId() != uploadIDs[upload.GetKey()] || upload.GetContentType() != "text/plain" || upload.GetInitiated() == 0 {
+				t.Errorf("Handler.ListMultipartUploads() upload = %v", upload)
+			}
+
+			keys = append(keys, upload.GetKey())
+		}
+
+		return keys, list.GetNextPageToken(), nil
+	}
+
+	listTests := []struct {
+		name     string
+		request  *pb.ListMultipartUploadsRequest
+		wantKeys []string
+		wantNext bool
+		wantCode codes.Code
+	}{
 		{
-			name: "delete invalid object",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   []*string{aws.String("oneoneone")},
-				bucket: aws.String("testbucket"),
-				ctx:    context.Background(),
-			},
-			// as S3 behave, if the key doesnt exist it'll be returned as removed.
-			wantSuccess: []string{"oneoneone"},
-			wantFailed:  []string{},
-			wantErr:     false,
+			name:     "list uploads",
+			request:  &pb.ListMultipartUploadsRequest{Bucket: "uploadsbucket"},
+			wantKeys: []string{"a/1", "a/2", "b/1"},
 		},
 		{
-			name: "delete nil keys",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   nil,
-				bucket: aws.String("testbucket"),
-				ctx:    context.Background(),
-			},
-			wantSuccess: []string{},
-			wantFailed:  []string{},
-			wantErr:     true,
+			name:     "list uploads with prefix",
+			request:  &pb.ListMultipartUploadsRequest{Bucket: "uploadsbucket", Prefix: "a/"},
+			wantKeys: []string{"a/1", "a/2"},
 		},
 		{
-			name: "delete nil bucket",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   []*string{aws.String("valid")},
-				bucket: nil,
-				ctx:    context.Background(),
-			},
-			wantSuccess: []string{},
-			wantFailed:  []string{},
-			wantErr:     true,
+			name:     "list first page of uploads",
+			request:  &pb.ListMultipartUploadsRequest{Bucket: "uploadsbucket", PageSize: 2},
+			wantKeys: []string{"a/1", "a/2"},
+			wantNext: true,
 		},
 		{
-			name: "delete empty bucket",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   []*string{aws.String("valid")},
-				bucket: aws.String(""),
-				ctx:    context.Background(),
-			},
-			wantSuccess: []string{},
-			wantFailed:  []string{},
-			wantErr:     true,
+			name:     "list old uploads",
+			request:  &pb.ListMultipartUploadsRequest{Bucket: "uploadsbucket", OlderThan: 3600},
+			wantKeys: []string{},
 		},
 		{
-			name: "delete empty key",
-			fields: fields{
-				s3Client: s3Client,
-			},
-			args: args{
-				keys:   []*string{},
-				bucket: aws.String("testbucket"),
-				ctx:    context.Background(),
-			},
-			wantSuccess: []string{},
-			wantFailed:  []string{},
-			wantErr:     true,
+			name:     "list uploads with invalid page token",
+			request:  &pb.ListMultipartUploadsRequest{Bucket: "uploadsbucket", PageToken: "!invalid"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "list uploads in bucket that doesn't exist",
+			request:  &pb.ListMultipartUploadsRequest{Bucket: "notexistuploadsbucket"},
+			wantCode: codes.NotFound,
 		},
 	}
-	for _, tt := range tests {
+	for _, tt := range listTests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := object.NewService(tt.fields.s3Client)
-			got, err := s.DeleteObjects(tt.args.ctx, tt.args.bucket, tt.args.keys)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Service.DeleteObjects() error = %v, wantErr %v", err, tt.wantErr)
+			keys, next, err := listKeys(tt.request)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Handler.ListMultipartUploads() error = %v, wantCode %v", err, tt.wantCode)
+			}
+
+			if err != nil {
 				return
 			}
-			deletedKeys := make([]string, 0)
-			if got != nil && got.Deleted != nil {
-				for _, deletedObject := range got.Deleted {
-					deletedKeys = append(deletedKeys, *(deletedObject.Key))
+
+			if !reflect.DeepEqual(keys, tt.wantKeys) || (next != "") != tt.wantNext {
+				t.Fatalf("Handler.ListMultipartUploads() = %v, next page %q, want %v", keys, next, tt.wantKeys)
+			}
+
+			if next != "" {
+				keys, next, err = listKeys(&pb.ListMultipartUploadsRequest{Bucket: "uploadsbucket", PageSize: 2, PageToken: next})
+				if err != nil || !reflect.DeepEqual(keys, []string{"b/1"}) || next != "" {
+					t.Errorf("Handler.ListMultipartUploads() next page = %v, next page %q, error = %v", keys, next, err)
 				}
 			}
-			failedKeys := make([]string, 0)
-			if got != nil && got.Errors != nil {
-				for _, erroredObject := range got.Errors {
-					failedKeys = append(failedKeys, *(erroredObject.Key))
+		})
+	}
+
+	aborted, err := client.AbortMultipartUploads(ctx, &pb.AbortMultipartUploadsRequest{
+		Bucket: "uploadsbucket",
+		Uploads: []*pb.MultipartUploadInfo{
+			{UploadId: uploadIDs["a/1"], Key: "a/1"},
+			{UploadId: "notexistupload", Key: "a/3"},
+			{UploadId: uploadIDs["a/2"], Key: "a/2"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler.AbortMultipartUploads() error = %v", err)
+	}
+
+	if len(aborted.GetAborted()) != 2 || len(aborted.GetFailed()) != 1 ||
+		aborted.GetFailed()[0].GetKey() != "a/3" || codes.Code(aborted.GetFailed()[0].GetError().GetCode()) != codes.NotFound {
+		t.Errorf("Handler.AbortMultipartUploads() = %v, want 2 aborted and a/3 not found", aborted)
+	}
+
+	keys, _, err := listKeys(&pb.ListMultipartUploadsRequest{Bucket: "uploadsbucket"})
+	if err != nil || !reflect.DeepEqual(keys, []string{"b/1"}) {
+		t.Errorf("Handler.ListMultipartUploads() after abort = %v, error = %v, want [b/1]", keys, err)
+	}
+
+	_, err = client.AbortMultipartUploads(ctx, &pb.AbortMultipartUploadsRequest{Bucket: "uploadsbucket"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Handler.AbortMultipartUploads() without uploads error = %v, wantCode %v", err, codes.InvalidArgument)
+	}
+
+	list, err := object.NewService(backend).ListMultipartUploads(ctx, aws.String("uploadsbucket"), nil, time.Now().Add(time.Minute), nil, nil)
+	if err != nil || len(list.Uploads) != 1 || list.Uploads[0].Key != "b/1" {
+		t.Errorf("Service.ListMultipartUploads() initiated before a minute from now = %v, error = %v, want [b/1]", list, err)
+	}
+}
+
+func TestService_UploadSession(t *testing.T) {
+	ctx := context.Background()
+	content := []byte("hello session")
+	contentSum := sha256.Sum256(content)
+	otherSum := sha256.Sum256([]byte("other"))
+
+	tests := []struct {
+		name       string
+		options    *object.UploadOptions
+		parts      []int64
+		size       *int64
+		checksum   *string
+		wantPart   codes.Code
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name:    "matching upload",
+			options: &object.UploadOptions{Owner: "user", Size: int64(len(content)), PartCount: 1, Checksum: &object.Checksum{Algorithm: object.ChecksumSHA256, Value: hex.EncodeToString(contentSum[:])}},
+			parts:   []int64{1},
+		},
+		{
+			name:       "part number exceeds part count",
+			options:    &object.UploadOptions{PartCount: 1},
+			parts:      []int64{2},
+			wantPart:   codes.FailedPrecondition,
+			wantCode:   codes.FailedPrecondition,
+			wantReason: object.ReasonUploadMismatch,
+		},
+		{
+			name:       "part exceeds size",
+			options:    &object.UploadOptions{Size: 5},
+			parts:      []int64{1},
+			wantPart:   codes.FailedPrecondition,
+			wantCode:   codes.FailedPrecondition,
+			wantReason: object.ReasonUploadMismatch,
+		},
+		{
+			name:       "completed with too few parts",
+			options:    &object.UploadOptions{PartCount: 2},
+			parts:      []int64{1},
+			wantCode:   codes.FailedPrecondition,
+			wantReason: object.ReasonUploadMismatch,
+		},
+		{
+			name:       "completed with another size",
+			options:    &object.UploadOptions{Size: int64(len(content))},
+			parts:      []int64{1},
+			size:       aws.Int64(int64(len(content)) - 1),
+			wantCode:   codes.FailedPrecondition,
+			wantReason: object.ReasonUploadMismatch,
+		},
+		{
+			name:       "completed with another checksum",
+			options:    &object.UploadOptions{Checksum: &object.Checksum{Algorithm: object.ChecksumSHA256, Value: hex.EncodeToString(contentSum[:])}},
+			parts:      []int64{1},
+			checksum:   aws.String(hex.EncodeToString(otherSum[:])),
+			wantCode:   codes.FailedPrecondition,
+			wantReason: object.ReasonUploadMismatch,
+		},
+		{
+			name:       "session checksum doesn't match",
+			options:    &object.UploadOptions{Checksum: &object.Checksum{Algorithm: object.ChecksumSHA256, Value: hex.EncodeToString(otherSum[:])}},
+			parts:      []int64{1},
+			wantCode:   codes.DataLoss,
+			wantReason: object.ReasonChecksumMismatch,
+		},
+		{
+			name:       "expired upload",
+			options:    &object.UploadOptions{TTL: time.Nanosecond},
+			parts:      []int64{1},
+			wantPart:   codes.FailedPrecondition,
+			wantCode:   codes.FailedPrecondition,
+			wantReason: object.ReasonUploadExpired,
+		},
+		{
+			name:     "invalid part count",
+			options:  &object.UploadOptions{PartCount: 10001},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := object.NewService(backend)
+			key := aws.String("sessionfile")
+			bucket := aws.String("sessionbucket")
+
+			upload, uploadSession, err := s.UploadInitWithOptions(ctx, key, bucket, aws.String("text/plain"), nil, tt.options)
+			if tt.parts == nil {
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("Service.UploadInitWithOptions() error = %v, want code %v", err, tt.wantCode)
 				}
+
+				return
 			}
-			if !(reflect.DeepEqual(deletedKeys, tt.wantSuccess) && reflect.DeepEqual(failedKeys, tt.wantFailed)) {
-				t.Errorf("Service.DeleteObjects() got unexpected output")
+
+			if err != nil {
+				t.Fatalf("Service.UploadInitWithOptions() error = %v", err)
+			}
+			defer s.UploadAbort(ctx, upload.UploadId, key, bucket)
+
+			if uploadSession.Owner != tt.options.Owner || uploadSession.Expires.Before(uploadSession.Created) {
+				t.Errorf("Service.UploadInitWithOptions() session = %+v, want a session with the options", uploadSession)
+			}
+
+			for _, partNumber := range tt.parts {
+				_, err := s.UploadPart(ctx, upload.UploadId, key, bucket, aws.Int64(partNumber), bytes.NewReader(content))
+				if status.Code(err) != tt.wantPart {
+					t.Fatalf("Service.UploadPart() error = %v, want code %v", err, tt.wantPart)
+				}
+			}
+
+			if tt.wantPart != codes.OK {
+				return
+			}
+
+			_, err = s.UploadComplete(ctx, upload.UploadId, key, bucket, nil, tt.size, tt.checksum)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Service.UploadComplete() error = %v, want code %v", err, tt.wantCode)
+			}
+
+			if info := errorInfo(err); tt.wantCode != codes.OK && info.GetReason() != tt.wantReason {
+				t.Errorf("Service.UploadComplete() reason = %q, want %q", info.GetReason(), tt.wantReason)
+			}
+
+			// A mismatching upload isn't completed and can be continued, so its session is kept.
+			if _, err := s.GetSessionStore().Get(*upload.UploadId); (err == nil) != (tt.wantCode != codes.OK) {
+				t.Errorf("Session store Get() error = %v, want the session deleted only when the upload is completed", err)
 			}
 		})
 	}
 }
 
-func TestHandler_DeleteObjects(t *testing.T) {
-	uploadservice := object.NewService(s3Client)
+func TestHandler_UploadSession(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+	content := []byte("owned content")
+	contentSum := sha256.Sum256(content)
+
+	upload, err := client.UploadInit(ctx, &pb.UploadInitRequest{
+		Key:       "ownedfile",
+		Bucket:    "sessionhandlerbucket",
+		Owner:     "alice",
+		Size:      int64(len(content)),
+		PartCount: 1,
+		Ttl:       60,
+	})
+	if err != nil {
+		t.Fatalf("Handler.UploadInit() error = %v", err)
+	}
+
+	if expires := time.Unix(upload.GetExpires(), 0); expires.Before(time.Now()) || expires.After(time.Now().Add(2*time.Minute)) {
+		t.Errorf("Handler.UploadInit() expires = %v, want a minute from now", expires)
+	}
+
+	stream, err := client.UploadPart(ctx)
+	if err != nil {
+		t.Fatalf("Handler.UploadPart() error = %v", err)
+	}
+
+	for _, owner := range []string{"bob", "alice"} {
+		err := stream.Send(&pb.UploadPartRequest{
+			Part:       content,
+			PartNumber: 1,
+			UploadId:   upload.GetUploadId(),
+			Key:        "ownedfile",
+			Bucket:     "sessionhandlerbucket",
+			Owner:      owner,
+			Checksum:   &pb.Checksum{Algorithm: pb.ChecksumAlgorithm_SHA256, Value: hex.EncodeToString(contentSum[:])},
+		})
+		if err != nil {
+			t.Fatalf("UploadPart stream Send() error = %v", err)
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("UploadPart stream Recv() error = %v", err)
+		}
+
+		wantCode := codes.OK
+		if owner == "bob" {
+			wantCode = codes.PermissionDenied
+		}
+
+		if codes.Code(resp.GetError().GetCode()) != wantCode {
+			t.Errorf("Handler.UploadPart() by %s error = %v, want code %v", owner, resp.GetError(), wantCode)
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("UploadPart stream CloseSend() error = %v", err)
+	}
+
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("UploadPart stream Recv() error = %v, want EOF", err)
+	}
+
+	for _, owner := range []string{"", "bob"} {
+		_, err := client.GetUploadStatus(ctx, &pb.GetUploadStatusRequest{UploadId: upload.GetUploadId(), Key: "ownedfile", Bucket: "sessionhandlerbucket", Owner: owner})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Handler.GetUploadStatus() by %q error = %v, want code %v", owner, err, codes.PermissionDenied)
+		}
+
+		_, err = client.UploadAbort(ctx, &pb.UploadAbortRequest{UploadId: upload.GetUploadId(), Key: "ownedfile", Bucket: "sessionhandlerbucket", Owner: owner})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Handler.UploadAbort() by %q error = %v, want code %v", owner, err, codes.PermissionDenied)
+		}
+
+		_, err = client.UploadComplete(ctx, &pb.UploadCompleteRequest{UploadId: upload.GetUploadId(), Key: "ownedfile", Bucket: "sessionhandlerbucket", Owner: owner})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("Handler.UploadComplete() by %q error = %v, want code %v", owner, err, codes.PermissionDenied)
+		}
+	}
+
+	uploadStatus, err := client.GetUploadStatus(ctx, &pb.GetUploadStatusRequest{UploadId: upload.GetUploadId(), Key: "ownedfile", Bucket: "sessionhandlerbucket", Owner: "alice"})
+	if err != nil {
+		t.Fatalf("Handler.GetUploadStatus() error = %v", err)
+	}
+
+	if uploadStatus.GetOwner() != "alice" || uploadStatus.GetExpectedSize() != int64(len(content)) || uploadStatus.GetPartCount() != 1 ||
+		uploadStatus.GetExpires() != upload.GetExpires() || len(uploadStatus.GetParts()) != 1 ||
+		uploadStatus.GetParts()[0].GetChecksum().GetValue() != hex.EncodeToString(contentSum[:]) {
+		t.Errorf("Handler.GetUploadStatus() = %v, want the upload's session", uploadStatus)
+	}
+
+	list, err := client.ListMultipartUploads(ctx, &pb.ListMultipartUploadsRequest{Bucket: "sessionhandlerbucket"})
+	if err != nil {
+		t.Fatalf("Handler.ListMultipartUploads() error = %v", err)
+	}
+
+	if len(list.GetUploads()) != 1 || list.GetUploads()[0].GetOwner() != "alice" || list.GetUploads()[0].GetExpires() != upload.GetExpires() {
+		t.Errorf("Handler.ListMultipartUploads() = %v, want the upload with its owner and expiry", list.GetUploads())
+	}
+
+	completed, err := client.UploadComplete(ctx, &pb.UploadCompleteRequest{UploadId: upload.GetUploadId(), Key: "ownedfile", Bucket: "sessionhandlerbucket", Owner: "alice"})
+	if err != nil {
+		t.Fatalf("Handler.UploadComplete() error = %v", err)
+	}
+
+	if completed.GetContentLength() != int64(len(content)) {
+		t.Errorf("Handler.UploadComplete() content length = %d, want %d", completed.GetContentLength(), len(content))
+	}
+}
+
+func TestService_DeleteObjects(t *testing.T) {
+	uploadservice := object.NewService(backend)
 	key1, err := uploadservice.UploadFile(
 		context.Background(),
 		bytes.NewReader([]byte("Hello, World!")),
@@ -1577,19 +2215,303 @@ func TestHandler_DeleteObjects(t *testing.T) {
 	if err != nil {
 		t.Errorf("Could not create file with error: %v", err)
 	}
+	type fields struct {
+		backend storage.Backend
+	}
 	type args struct {
-		ctx     context.Context
-		request *pb.DeleteObjectsRequest
+		ctx    aws.Context
+		bucket *string
+		keys   []*string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    *pb.DeleteObjectsResponse
-		wantErr bool
+		name        string
+		fields      fields
+		args        args
+		wantSuccess []string
+		wantFailed  []string
+		wantErr     bool
 	}{
 		{
 			name: "delete only one object",
-			args: args{
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   []*string{key1},
+				bucket: aws.String("testbucket"),
+				ctx:    context.Background(),
+			},
+			wantSuccess: []string{*key1},
+			wantFailed:  []string{},
+			wantErr:     false,
+		},
+		{
+			name: "delete two objects",
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   []*string{key2, key3},
+				bucket: aws.String("testbucket"),
+				ctx:    context.Background(),
+			},
+			wantSuccess: []string{*key2, *key3},
+			wantFailed:  []string{},
+			wantErr:     false,
+		},
+		{
+			name: "delete valid and invalid objects",
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   []*string{key4, aws.String("oneoneone")},
+				bucket: aws.String("testbucket"),
+				ctx:    context.Background(),
+			},
+			wantSuccess: []string{*key4, "oneoneone"},
+			wantFailed:  []string{},
+			wantErr:     false,
+		},
+		{
+			name: "delete invalid object",
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   []*string{aws.String("oneoneone")},
+				bucket: aws.String("testbucket"),
+				ctx:    context.Background(),
+			},
+			// as S3 behave, if the key doesnt exist it'll be returned as removed.
+			wantSuccess: []string{"oneoneone"},
+			wantFailed:  []string{},
+			wantErr:     false,
+		},
+		{
+			name: "delete nil keys",
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   nil,
+				bucket: aws.String("testbucket"),
+				ctx:    context.Background(),
+			},
+			wantSuccess: []string{},
+			wantFailed:  []string{},
+			wantErr:     true,
+		},
+		{
+			name: "delete nil bucket",
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   []*string{aws.String("valid")},
+				bucket: nil,
+				ctx:    context.Background(),
+			},
+			wantSuccess: []string{},
+			wantFailed:  []string{},
+			wantErr:     true,
+		},
+		{
+			name: "delete empty bucket",
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   []*string{aws.String("valid")},
+				bucket: aws.String(""),
+				ctx:    context.Background(),
+			},
+			wantSuccess: []string{},
+			wantFailed:  []string{},
+			wantErr:     true,
+		},
+		{
+			name: "delete empty key",
+			fields: fields{
+				backend: backend,
+			},
+			args: args{
+				keys:   []*string{},
+				bucket: aws.String("testbucket"),
+				ctx:    context.Background(),
+			},
+			wantSuccess: []string{},
+			wantFailed:  []string{},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := object.NewService(tt.fields.backend)
+			got, err := s.DeleteObjects(tt.args.ctx, tt.args.bucket, tt.args.keys)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.DeleteObjects() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			deletedKeys := make([]string, 0)
+			if got != nil && got.Deleted != nil {
+				for _, deletedObject := range got.Deleted {
+					deletedKeys = append(deletedKeys, *(deletedObject.Key))
+				}
+			}
+			failedKeys := make([]string, 0)
+			if got != nil && got.Errors != nil {
+				for _, erroredObject := range got.Errors {
+					failedKeys = append(failedKeys, *(erroredObject.Key))
+				}
+			}
+			if !(reflect.DeepEqual(deletedKeys, tt.wantSuccess) && reflect.DeepEqual(failedKeys, tt.wantFailed)) {
+				t.Errorf("Service.DeleteObjects() got unexpected output")
+			}
+		})
+	}
+}
+
+// unavailableDeleteBackend is a storage backend whose DeleteObjects fails as if the storage is down
+// for requests that delete one of the unavailable keys.
+type unavailableDeleteBackend struct {
+	storage.Backend
+	unavailable map[string]bool
+}
+
+func (b unavailableDeleteBackend) DeleteObjects(ctx aws.Context, input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	for _, object := range input.Delete.Objects {
+		if b.unavailable[aws.StringValue(object.Key)] {
+			return nil, awserr.New("ServiceUnavailable", "Please reduce your request rate.", nil)
+		}
+	}
+
+	return b.Backend.DeleteObjects(ctx, input)
+}
+
+func TestService_DeleteObjectsChunks(t *testing.T) {
+	keys := make([]*string, 2500)
+	for i := range keys {
+		keys[i] = aws.String(fmt.Sprintf("file%04d", i))
+	}
+
+	tests := []struct {
+		name        string
+		unavailable map[string]bool
+		wantDeleted int
+		wantFailed  int
+		wantCode    codes.Code
+	}{
+		{
+			name:        "delete all chunks",
+			wantDeleted: 2500,
+			wantCode:    codes.OK,
+		},
+		{
+			name:        "delete with a failed chunk",
+			unavailable: map[string]bool{"file1500": true},
+			wantDeleted: 1500,
+			wantFailed:  1000,
+			wantCode:    codes.OK,
+		},
+		{
+			name:        "delete with all chunks failed",
+			unavailable: map[string]bool{"file0000": true, "file1000": true, "file2000": true},
+			wantCode:    codes.Unavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := object.NewService(unavailableDeleteBackend{Backend: storage.NewMemoryBackend(), unavailable: tt.unavailable})
+			got, err := s.DeleteObjects(context.Background(), aws.String("testbucket"), keys)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Service.DeleteObjects() error = %v, wantCode %v", err, tt.wantCode)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if len(got.Deleted) != tt.wantDeleted || len(got.Errors) != tt.wantFailed {
+				t.Fatalf("Service.DeleteObjects() deleted %d and failed %d, want %d and %d", len(got.Deleted), len(got.Errors), tt.wantDeleted, tt.wantFailed)
+			}
+
+			for _, keyErr := range got.Errors {
+				if aws.StringValue(keyErr.Code) != "ServiceUnavailable" || aws.StringValue(keyErr.Message) == "" {
+					t.Errorf("Service.DeleteObjects() error of %s = %s: %s", aws.StringValue(keyErr.Key), aws.StringValue(keyErr.Code), aws.StringValue(keyErr.Message))
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_DeleteObjects(t *testing.T) {
+	uploadservice := object.NewService(backend)
+	key1, err := uploadservice.UploadFile(
+		context.Background(),
+		bytes.NewReader([]byte("Hello, World!")),
+		aws.String("file1"),
+		aws.String("testbucket"),
+		aws.String("text/plain"),
+		nil,
+	)
+	if err != nil {
+		t.Errorf("Could not create file with error: %v", err)
+	}
+	key2, err := uploadservice.UploadFile(
+		context.Background(),
+		bytes.NewReader([]byte("Hello, World!")),
+		aws.String("file2"),
+		aws.String("testbucket"),
+		aws.String("text/plain"),
+		nil,
+	)
+	if err != nil {
+		t.Errorf("Could not create file with error: %v", err)
+	}
+	key3, err := uploadservice.UploadFile(
+		context.Background(),
+		bytes.NewReader([]byte("Hello, World!")),
+		aws.String("file3"),
+		aws.String("testbucket"),
+		aws.String("text/plain"),
+		nil,
+	)
+	if err != nil {
+		t.Errorf("Could not create file with error: %v", err)
+	}
+	key4, err := uploadservice.UploadFile(
+		context.Background(),
+		bytes.NewReader([]byte("Hello, World!")),
+		aws.String("file4"),
+		aws.String("testbucket"),
+		aws.String("text/plain"),
+		nil,
+	)
+	if err != nil {
+		t.Errorf("Could not create file with error: %v", err)
+	}
+	manyKeys := make([]string, 2500)
+	for i := range manyKeys {
+		manyKeys[i] = fmt.Sprintf("manyfile%04d", i)
+	}
+
+	longKey := strings.Repeat("a", 1025)
+
+	type args struct {
+		ctx     context.Context
+		request *pb.DeleteObjectsRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *pb.DeleteObjectsResponse
+		wantErr bool
+	}{
+		{
+			name: "delete only one object",
+			args: args{
 				ctx: context.Background(),
 				request: &pb.DeleteObjectsRequest{
 					Bucket: "testbucket",
@@ -1647,6 +2569,39 @@ func TestHandler_DeleteObjects(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "delete more than 1000 objects",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.DeleteObjectsRequest{
+					Bucket: "testbucket",
+					Keys:   manyKeys,
+				},
+			},
+			want: &pb.DeleteObjectsResponse{
+				Deleted: manyKeys,
+				Failed:  []string{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete too long key",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.DeleteObjectsRequest{
+					Bucket: "testbucket",
+					Keys:   []string{"oneoneone", longKey},
+				},
+			},
+			want: &pb.DeleteObjectsResponse{
+				Deleted: []string{"oneoneone"},
+				Failed:  []string{longKey},
+				Errors: []*pb.DeleteError{
+					{Key: longKey, Code: storage.ErrCodeKeyTooLong, Message: "Your key is too long"},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "delete empty bucket",
 			args: args{
@@ -1707,13 +2662,17 @@ func TestHandler_DeleteObjects(t *testing.T) {
 			if !(reflect.DeepEqual(gotDeleted, wantDeleted) && reflect.DeepEqual(gotFailed, wantFailed)) {
 				t.Errorf("Handler.DeleteObjects() = %v, want %v", got, tt.want)
 			}
+
+			if !cmp.Equal(got.GetErrors(), tt.want.GetErrors(), cmpopts.IgnoreUnexported(pb.DeleteError{}), cmpopts.EquateEmpty()) {
+				t.Errorf("Handler.DeleteObjects() errors = %v, want %v", got.GetErrors(), tt.want.GetErrors())
+			}
 		})
 	}
 }
 
 func TestHandler_CopyObject(t *testing.T) {
 	// Upload files for testing
-	uploadservice := object.NewService(s3Client)
+	uploadservice := object.NewService(backend)
 	_, err := uploadservice.UploadFile(
 		context.Background(),
 		bytes.NewReader([]byte("Hello, World!")),
@@ -1728,7 +2687,7 @@ func TestHandler_CopyObject(t *testing.T) {
 
 	// Init structs
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 
 	type args struct {
@@ -1746,7 +2705,7 @@ func TestHandler_CopyObject(t *testing.T) {
 		{
 			name: "copy one object",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1757,18 +2716,18 @@ func TestHandler_CopyObject(t *testing.T) {
 					KeyDest:    "newfile1",
 				},
 			},
-			want:    &pb.CopyObjectResponse{Copied: "file1"},
+			want:    &pb.CopyObjectResponse{Copied: "file1", Verification: pb.CopyVerification_SIZE_AND_MD5},
 			wantErr: false,
 		},
 		{
 			name: "source bucket doesnt exist",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
 				request: &pb.CopyObjectRequest{
-					BucketSrc:  "notexistbucket",
+					BucketSrc:  "notexistsourcebucket",
 					BucketDest: "testbucket1",
 					KeySrc:     "file1",
 					KeyDest:    "newfile1",
@@ -1779,7 +2738,7 @@ func TestHandler_CopyObject(t *testing.T) {
 		{
 			name: "source object doesnt exist",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1795,7 +2754,7 @@ func TestHandler_CopyObject(t *testing.T) {
 		{
 			name: "dest bucket is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1811,7 +2770,7 @@ func TestHandler_CopyObject(t *testing.T) {
 		{
 			name: "source bucket is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1827,7 +2786,7 @@ func TestHandler_CopyObject(t *testing.T) {
 		{
 			name: "source key is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1843,7 +2802,7 @@ func TestHandler_CopyObject(t *testing.T) {
 		{
 			name: "dest key is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -1892,7 +2851,7 @@ func TestHandler_CopyObject(t *testing.T) {
 func TestService_CopyObject(t *testing.T) {
 	// Upload files for testing
 	// file size less than 5GB
-	uploadservice := object.NewService(s3Client)
+	uploadservice := object.NewService(backend)
 	_, err := uploadservice.UploadFile(
 		context.Background(),
 		bytes.NewReader([]byte("Hello, World!")),
@@ -1926,7 +2885,7 @@ func TestService_CopyObject(t *testing.T) {
 
 	// Init structs
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 
 	type args struct {
@@ -1947,7 +2906,7 @@ func TestService_CopyObject(t *testing.T) {
 		{
 			name: "copy small object",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -1962,7 +2921,7 @@ func TestService_CopyObject(t *testing.T) {
 		{
 			name: "copy large object",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -1971,12 +2930,13 @@ func TestService_CopyObject(t *testing.T) {
 				keySrc:     aws.String("file2"),
 				keyDest:    aws.String("newfile2"),
 			},
-			wantErr: true,
+			want:    aws.String("file2"),
+			wantErr: false,
 		},
 		{
 			name: "source bucket doesnt exist",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -1990,7 +2950,7 @@ func TestService_CopyObject(t *testing.T) {
 		{
 			name: "source object doesnt exist",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -2004,7 +2964,7 @@ func TestService_CopyObject(t *testing.T) {
 		{
 			name: "dest bucket is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -2018,7 +2978,7 @@ func TestService_CopyObject(t *testing.T) {
 		{
 			name: "source bucket is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -2032,7 +2992,7 @@ func TestService_CopyObject(t *testing.T) {
 		{
 			name: "source key is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -2046,7 +3006,7 @@ func TestService_CopyObject(t *testing.T) {
 		{
 			name: "dest key is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx:        context.Background(),
@@ -2062,7 +3022,7 @@ func TestService_CopyObject(t *testing.T) {
 	// Iterate over test cases
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := object.NewService(s3Client)
+			s := object.NewService(backend)
 
 			got, err := s.CopyObject(
 				tt.args.ctx,
@@ -2087,9 +3047,184 @@ func TestService_CopyObject(t *testing.T) {
 	}
 }
 
-func TestHandler_MoveObject(t *testing.T) {
+// truncatingBackend is a storage backend whose UploadPartCopy leaves out the last byte
+// of parts smaller than 5MB, which is the last part of a copy.
+type truncatingBackend struct {
+	storage.Backend
+}
+
+func (b truncatingBackend) UploadPartCopy(ctx aws.Context, input *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+	var start, end int64
+	fmt.Sscanf(aws.StringValue(input.CopySourceRange), "bytes=%d-%d", &start, &end)
+	if end-start+1 < 5<<20 {
+		input.CopySourceRange = aws.String(fmt.Sprintf("bytes=%d-%d", start, end-1))
+	}
+
+	return b.Backend.UploadPartCopy(ctx, input)
+}
+
+// cancelingBackend is a storage backend that cancels a context once a part is copied.
+type cancelingBackend struct {
+	storage.Backend
+	cancel context.CancelFunc
+}
+
+func (b cancelingBackend) UploadPartCopy(ctx aws.Context, input *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+	defer b.cancel()
+
+	return b.Backend.UploadPartCopy(context.Background(), input)
+}
+
+func TestService_CopyObjectMultipartCanceled(t *testing.T) {
+	// More parts than are copied concurrently, so the last parts are never started.
+	file := make([]byte, 45<<20)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backend := cancelingBackend{Backend: storage.NewMemoryBackend(), cancel: cancel}
+	s := object.NewServiceWithMultipartCopy(backend, 1, 5<<20)
+	if _, err := s.UploadFile(context.Background(), bytes.NewReader(file), aws.String("source"), aws.String("testbucket"), nil, nil); err != nil {
+		t.Fatalf("Service.UploadFile() error = %v", err)
+	}
+
+	_, err := s.CopyObject(ctx, aws.String("testbucket"), aws.String("testbucket1"), aws.String("source"), aws.String("copy"))
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("Service.CopyObject() error = %v, wantCode %v", err, codes.Canceled)
+	}
+
+	uploads, err := backend.ListMultipartUploads(context.Background(), &s3.ListMultipartUploadsInput{Bucket: aws.String("testbucket1")})
+	if err != nil || len(uploads.Uploads) != 0 {
+		t.Errorf("Service.CopyObject() left uploads %v, error = %v", uploads, err)
+	}
+}
+
+func TestService_CopyObjectMultipart(t *testing.T) {
+	file := make([]byte, 12<<20)
+	if _, err := rand.Read(file); err != nil {
+		t.Fatalf("Could not generate file with error: %v", err)
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		backend  storage.Backend
+		wantCode codes.Code
+	}{
+		{
+			name:     "copy in parts",
+			backend:  storage.NewMemoryBackend(),
+			wantCode: codes.OK,
+		},
+		{
+			name:     "copy in parts with missing data",
+			backend:  truncatingBackend{Backend: storage.NewMemoryBackend()},
+			wantCode: codes.DataLoss,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := object.NewServiceWithMultipartCopy(tt.backend, 1, 5<<20)
+			_, err := s.UploadFile(ctx, bytes.NewReader(file), aws.String("source"), aws.String("testbucket"), aws.String("text/plain"), map[string]*string{"test": aws.String("meta")})
+			if err != nil {
+				t.Fatalf("Service.UploadFile() error = %v", err)
+			}
+
+			verification, err := s.CopyObjectWithVerification(ctx, aws.String("testbucket"), aws.String("testbucket1"), aws.String("source"), aws.String("copy"))
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Service.CopyObjectWithVerification() error = %v, wantCode %v", err, tt.wantCode)
+			}
+
+			// The source has no stored checksum and the copy is assembled from parts.
+			if tt.wantCode == codes.OK && verification != object.CopyVerifiedSize {
+				t.Errorf("Service.CopyObjectWithVerification() verification = %v, want %v", verification, object.CopyVerifiedSize)
+			}
+
+			uploads, err := tt.backend.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{Bucket: aws.String("testbucket1")})
+			if err != nil || len(uploads.Uploads) != 0 {
+				t.Errorf("Service.CopyObject() left uploads %v, error = %v", uploads, err)
+			}
+
+			obj, err := s.GetObject(ctx, aws.String("copy"), aws.String("testbucket1"), nil)
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != codes.NotFound {
+					t.Errorf("Service.CopyObject() left a copy that doesn't match, GetObject error = %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Service.GetObject() error = %v", err)
+			}
+			defer obj.Body.Close()
+
+			data, err := ioutil.ReadAll(obj.Body)
+			if err != nil {
+				t.Fatalf("Could not read copy with error: %v", err)
+			}
+
+			if !bytes.Equal(data, file) || !strings.HasSuffix(aws.StringValue(obj.ETag), `-3"`) {
+				t.Errorf("Service.CopyObject() copy has %d bytes and ETag %s, want %d bytes in 3 parts", len(data), aws.StringValue(obj.ETag), len(file))
+			}
+
+			if aws.StringValue(obj.ContentType) != "text/plain" || aws.StringValue(obj.Metadata["Test"]) != "meta" {
+				t.Errorf("Service.CopyObject() copy content type = %s, metadata = %v", aws.StringValue(obj.ContentType), obj.Metadata)
+			}
+		})
+	}
+}
+
+func TestService_CopyObjectMismatchOverwrite(t *testing.T) {
+	file := make([]byte, 12<<20)
+	if _, err := rand.Read(file); err != nil {
+		t.Fatalf("Could not generate file with error: %v", err)
+	}
+
+	// Only the source is copied in parts, which the backend truncates.
+	ctx := context.Background()
+	s := object.NewServiceWithMultipartCopy(truncatingBackend{Backend: storage.NewMemoryBackend()}, 5<<20, 5<<20)
+	if _, err := s.UploadFile(ctx, bytes.NewReader(file), aws.String("source"), aws.String("testbucket"), nil, nil); err != nil {
+		t.Fatalf("Service.UploadFile() error = %v", err)
+	}
+
+	previous := []byte("previous")
+	if _, err := s.UploadFile(ctx, bytes.NewReader(previous), aws.String("copy"), aws.String("testbucket1"), nil, nil); err != nil {
+		t.Fatalf("Service.UploadFile() error = %v", err)
+	}
+
+	_, err := s.CopyObject(ctx, aws.String("testbucket"), aws.String("testbucket1"), aws.String("source"), aws.String("copy"))
+	if status.Code(err) != codes.DataLoss {
+		t.Fatalf("Service.CopyObject() error = %v, wantCode %v", err, codes.DataLoss)
+	}
+
+	obj, err := s.GetObject(ctx, aws.String("copy"), aws.String("testbucket1"), nil)
+	if err != nil {
+		t.Fatalf("Service.GetObject() error = %v", err)
+	}
+	defer obj.Body.Close()
+
+	data, err := ioutil.ReadAll(obj.Body)
+	if err != nil {
+		t.Fatalf("Could not read object with error: %v", err)
+	}
+
+	if !bytes.Equal(data, previous) {
+		t.Errorf("Service.CopyObject() left %d bytes, want the overwritten object %q", len(data), previous)
+	}
+
+	list, err := s.ListObjects(ctx, aws.String("testbucket1"), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Service.ListObjects() error = %v", err)
+	}
+
+	if len(list.Contents) != 1 {
+		t.Errorf("Service.CopyObject() left objects %v, want only the restored object", list.Contents)
+	}
+}
+
+func TestHandler_MoveObject(t *testing.T) {
 	// Upload files for testing
-	uploadservice := object.NewService(s3Client)
+	uploadservice := object.NewService(backend)
 
 	_, err := uploadservice.UploadFile(
 		context.Background(),
@@ -2105,7 +3240,7 @@ func TestHandler_MoveObject(t *testing.T) {
 
 	// Init structs
 	type fields struct {
-		s3Client *s3.S3
+		backend storage.Backend
 	}
 
 	type args struct {
@@ -2123,7 +3258,7 @@ func TestHandler_MoveObject(t *testing.T) {
 		{
 			name: "move one object",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -2134,18 +3269,18 @@ func TestHandler_MoveObject(t *testing.T) {
 					KeyDest:    "newfile1",
 				},
 			},
-			want:    &pb.MoveObjectResponse{Moved: "file1"},
+			want:    &pb.MoveObjectResponse{Moved: "file1", State: pb.MoveState_MOVED, Verification: pb.CopyVerification_SIZE_AND_MD5},
 			wantErr: false,
 		},
 		{
 			name: "source bucket doesnt exist",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
 				request: &pb.MoveObjectRequest{
-					BucketSrc:  "notexistbucket",
+					BucketSrc:  "notexistsourcebucket",
 					BucketDest: "testbucket1",
 					KeySrc:     "file1",
 					KeyDest:    "newfile1",
@@ -2156,7 +3291,7 @@ func TestHandler_MoveObject(t *testing.T) {
 		{
 			name: "source object doesnt exist",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -2172,7 +3307,7 @@ func TestHandler_MoveObject(t *testing.T) {
 		{
 			name: "dest bucket is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -2188,7 +3323,7 @@ func TestHandler_MoveObject(t *testing.T) {
 		{
 			name: "source bucket is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -2204,7 +3339,7 @@ func TestHandler_MoveObject(t *testing.T) {
 		{
 			name: "source key is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -2220,7 +3355,7 @@ func TestHandler_MoveObject(t *testing.T) {
 		{
 			name: "dest key is null",
 			fields: fields{
-				s3Client: s3Client,
+				backend: backend,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -2266,6 +3401,1954 @@ func TestHandler_MoveObject(t *testing.T) {
 	}
 }
 
-// TODO: TestHandler_UploadAbort
-// TODO: TestHandler_UploadComplete
-// TODO: TestHandler_UploadPart
+// failingDeleteBackend is a storage backend whose DeleteObjects fails to delete
+// the objects in the failing buckets, and calls cancel if it's set. Like S3, it fails
+// to delete objects once ctx is done.
+type failingDeleteBackend struct {
+	storage.Backend
+	failing map[string]bool
+	cancel  context.CancelFunc
+}
+
+func (b failingDeleteBackend) DeleteObjects(ctx aws.Context, input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !b.failing[aws.StringValue(input.Bucket)] {
+		return b.Backend.DeleteObjects(ctx, input)
+	}
+
+	if b.cancel != nil {
+		b.cancel()
+	}
+
+	output := &s3.DeleteObjectsOutput{}
+	for _, object := range input.Delete.Objects {
+		output.Errors = append(output.Errors, &s3.Error{
+			Key:     object.Key,
+			Code:    aws.String("AccessDenied"),
+			Message: aws.String("Access Denied"),
+		})
+	}
+
+	return output, nil
+}
+
+func TestService_MoveObject(t *testing.T) {
+	tests := []struct {
+		name       string
+		failing    map[string]bool
+		cancel     bool
+		existing   bool
+		bucketDest string
+		keyDest    string
+		wantState  object.MoveState
+		wantCode   codes.Code
+		wantSource bool
+		wantDest   bool
+	}{
+		{
+			name:       "move",
+			bucketDest: "testbucket1",
+			keyDest:    "moved",
+			wantState:  object.MoveStateMoved,
+			wantCode:   codes.OK,
+			wantSource: false,
+			wantDest:   true,
+		},
+		{
+			name:       "same object",
+			bucketDest: "testbucket",
+			keyDest:    "source",
+			wantState:  object.MoveStateNotMoved,
+			wantCode:   codes.InvalidArgument,
+			wantSource: true,
+			wantDest:   true,
+		},
+		{
+			name:       "source not deleted",
+			failing:    map[string]bool{"testbucket": true},
+			bucketDest: "testbucket1",
+			keyDest:    "moved",
+			wantState:  object.MoveStateRolledBack,
+			wantCode:   codes.Aborted,
+			wantSource: true,
+			wantDest:   false,
+		},
+		{
+			name:       "source not deleted and request canceled",
+			failing:    map[string]bool{"testbucket": true},
+			cancel:     true,
+			bucketDest: "testbucket1",
+			keyDest:    "moved",
+			wantState:  object.MoveStateRolledBack,
+			wantCode:   codes.Aborted,
+			wantSource: true,
+			wantDest:   false,
+		},
+		{
+			name:       "source not deleted and destination existed",
+			failing:    map[string]bool{"testbucket": true},
+			existing:   true,
+			bucketDest: "testbucket1",
+			keyDest:    "moved",
+			wantState:  object.MoveStateDuplicated,
+			wantCode:   codes.Aborted,
+			wantSource: true,
+			wantDest:   true,
+		},
+		{
+			name:       "source and destination not deleted",
+			failing:    map[string]bool{"testbucket": true, "testbucket1": true},
+			bucketDest: "testbucket1",
+			keyDest:    "moved",
+			wantState:  object.MoveStateDuplicated,
+			wantCode:   codes.Aborted,
+			wantSource: true,
+			wantDest:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			backend := failingDeleteBackend{Backend: storage.NewMemoryBackend(), failing: tt.failing}
+			if tt.cancel {
+				backend.cancel = cancel
+			}
+
+			s := object.NewService(backend)
+			_, err := s.UploadFile(ctx, bytes.NewReader([]byte("Hello, World!")), aws.String("source"), aws.String("testbucket"), aws.String("text/plain"), nil)
+			if err != nil {
+				t.Fatalf("Service.UploadFile() error = %v", err)
+			}
+
+			if tt.existing {
+				_, err = s.UploadFile(ctx, bytes.NewReader([]byte("Existing")), aws.String(tt.keyDest), aws.String(tt.bucketDest), aws.String("text/plain"), nil)
+				if err != nil {
+					t.Fatalf("Service.UploadFile() error = %v", err)
+				}
+			}
+
+			got, err := s.MoveObject(ctx, aws.String("testbucket"), aws.String(tt.bucketDest), aws.String("source"), aws.String(tt.keyDest))
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Service.MoveObject() error = %v, wantCode %v", err, tt.wantCode)
+			}
+
+			if got.State != tt.wantState {
+				t.Errorf("Service.MoveObject() state = %v, want %v, journal %v", got.State, tt.wantState, got.Journal)
+			}
+
+			if tt.wantCode == codes.Aborted {
+				if info := errorInfo(err); info == nil || info.GetMetadata()["moveState"] != string(tt.wantState) {
+					t.Errorf("Service.MoveObject() error info = %v, want moveState %v", info, tt.wantState)
+				}
+			}
+
+			ctx = context.Background()
+			_, err = s.HeadObject(ctx, aws.String("source"), aws.String("testbucket"))
+			if (err == nil) != tt.wantSource {
+				t.Errorf("Service.MoveObject() source exists = %v, want %v", err == nil, tt.wantSource)
+			}
+
+			_, err = s.HeadObject(ctx, aws.String(tt.keyDest), aws.String(tt.bucketDest))
+			if (err == nil) != tt.wantDest {
+				t.Errorf("Service.MoveObject() destination exists = %v, want %v", err == nil, tt.wantDest)
+			}
+		})
+	}
+}
+
+func TestHandler_BatchObjects(t *testing.T) {
+	ctx := context.Background()
+	uploadservice := object.NewService(backend)
+	for _, key := range []string{"copy1", "copy2", "copy3", "move1", "move2", "move3"} {
+		_, err := uploadservice.UploadFile(ctx, bytes.NewReader([]byte(key)), aws.String(key), aws.String("batchbucket"), aws.String("text/plain"), nil)
+		if err != nil {
+			t.Fatalf("Could not create file with error: %v", err)
+		}
+	}
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	items := func(keys ...string) []*pb.BatchObjectItem {
+		batch := make([]*pb.BatchObjectItem, 0, len(keys))
+		for _, key := range keys {
+			batch = append(batch, &pb.BatchObjectItem{
+				BucketSrc:  "batchbucket",
+				BucketDest: "batchbucket1",
+				KeySrc:     key,
+				KeyDest:    "new" + key,
+			})
+		}
+
+		return batch
+	}
+
+	tests := []struct {
+		name        string
+		move        bool
+		request     *pb.BatchObjectsRequest
+		wantCode    codes.Code
+		wantFailed  map[int32]codes.Code
+		wantState   pb.MoveState
+		wantSources bool
+	}{
+		{
+			name:        "copy objects",
+			request:     &pb.BatchObjectsRequest{Items: items("copy1", "copy2", "notexist", "copy3"), Concurrency: 2},
+			wantCode:    codes.OK,
+			wantFailed:  map[int32]codes.Code{2: codes.NotFound},
+			wantState:   pb.MoveState_MOVE_STATE_UNSPECIFIED,
+			wantSources: true,
+		},
+		{
+			name:        "move objects",
+			move:        true,
+			request:     &pb.BatchObjectsRequest{Items: items("move1", "move2", "move3", "notexist")},
+			wantCode:    codes.OK,
+			wantFailed:  map[int32]codes.Code{3: codes.NotFound},
+			wantState:   pb.MoveState_MOVED,
+			wantSources: false,
+		},
+		{
+			name:     "copy without objects",
+			request:  &pb.BatchObjectsRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "move without objects",
+			move:     true,
+			request:  &pb.BatchObjectsRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stream interface {
+				Recv() (*pb.BatchObjectResult, error)
+			}
+			if tt.move {
+				stream, err = client.BatchMoveObjects(ctx, tt.request)
+			} else {
+				stream, err = client.BatchCopyObjects(ctx, tt.request)
+			}
+			if err != nil {
+				t.Fatalf("UploadHandler.BatchObjects() error = %v", err)
+			}
+
+			results := make(map[int32]*pb.BatchObjectResult)
+			for {
+				result, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("UploadHandler.BatchObjects() error = %v, wantCode %v", err, tt.wantCode)
+				}
+
+				if err != nil {
+					return
+				}
+
+				results[result.GetIndex()] = result
+			}
+
+			if len(results) != len(tt.request.GetItems()) {
+				t.Fatalf("UploadHandler.BatchObjects() got %d results, want %d", len(results), len(tt.request.GetItems()))
+			}
+
+			for index, result := range results {
+				item := tt.request.GetItems()[index]
+				if !cmp.Equal(result.GetItem(), item, cmpopts.IgnoreUnexported(pb.BatchObjectItem{})) {
+					t.Errorf("UploadHandler.BatchObjects() result %d item = %v, want %v", index, result.GetItem(), item)
+				}
+
+				if wantCode, ok := tt.wantFailed[index]; ok {
+					if codes.Code(result.GetError().GetCode()) != wantCode {
+						t.Errorf("UploadHandler.BatchObjects() result %d error = %v, wantCode %v", index, result.GetError(), wantCode)
+					}
+
+					continue
+				}
+
+				if result.GetError() != nil || result.GetState() != tt.wantState {
+					t.Errorf("UploadHandler.BatchObjects() result %d error = %v, state = %v, want state %v", index, result.GetError(), result.GetState(), tt.wantState)
+				}
+
+				if _, err := uploadservice.HeadObject(ctx, aws.String(item.GetKeyDest()), aws.String(item.GetBucketDest())); err != nil {
+					t.Errorf("UploadHandler.BatchObjects() destination %s error = %v", item.GetKeyDest(), err)
+				}
+
+				_, err := uploadservice.HeadObject(ctx, aws.String(item.GetKeySrc()), aws.String(item.GetBucketSrc()))
+				if (err == nil) != tt.wantSources {
+					t.Errorf("UploadHandler.BatchObjects() source %s exists = %v, want %v", item.GetKeySrc(), err == nil, tt.wantSources)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_Prefix(t *testing.T) {
+	ctx := context.Background()
+	uploadservice := object.NewService(backend)
+	for _, key := range []string{"folder/", "folder/a", "folder/b", "folder/sub/c", "other/d"} {
+		_, err := uploadservice.UploadFile(ctx, bytes.NewReader([]byte(key)), aws.String(key), aws.String("prefixbucket"), aws.String("text/plain"), nil)
+		if err != nil {
+			t.Fatalf("Could not create file with error: %v", err)
+		}
+	}
+
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	tests := []struct {
+		name string
+		call func() (interface {
+			Recv() (*pb.PrefixProgress, error)
+		}, error)
+		wantCode    codes.Code
+		wantCount   int64
+		wantExist   []string
+		wantMissing []string
+	}{
+		{
+			name: "copy prefix",
+			call: func() (interface {
+				Recv() (*pb.PrefixProgress, error)
+			}, error) {
+				return client.CopyPrefix(ctx, &pb.PrefixRequest{
+					BucketSrc:  "prefixbucket",
+					BucketDest: "prefixbucket",
+					PrefixSrc:  "folder/",
+					PrefixDest: "copied/",
+				})
+			},
+			wantCode:  codes.OK,
+			wantCount: 4,
+			wantExist: []string{"prefixbucket/folder/a", "prefixbucket/copied/", "prefixbucket/copied/a", "prefixbucket/copied/b", "prefixbucket/copied/sub/c"},
+		},
+		{
+			name: "move prefix",
+			call: func() (interface {
+				Recv() (*pb.PrefixProgress, error)
+			}, error) {
+				return client.MovePrefix(ctx, &pb.PrefixRequest{
+					BucketSrc:   "prefixbucket",
+					BucketDest:  "prefixbucket1",
+					PrefixSrc:   "folder/",
+					PrefixDest:  "moved/",
+					Concurrency: 2,
+				})
+			},
+			wantCode:    codes.OK,
+			wantCount:   4,
+			wantExist:   []string{"prefixbucket1/moved/a", "prefixbucket1/moved/b", "prefixbucket1/moved/sub/c", "prefixbucket/other/d"},
+			wantMissing: []string{"prefixbucket/folder/", "prefixbucket/folder/a", "prefixbucket/folder/b", "prefixbucket/folder/sub/c"},
+		},
+		{
+			name: "delete prefix",
+			call: func() (interface {
+				Recv() (*pb.PrefixProgress, error)
+			}, error) {
+				return client.DeletePrefix(ctx, &pb.DeletePrefixRequest{Bucket: "prefixbucket", Prefix: "copied/"})
+			},
+			wantCode:    codes.OK,
+			wantCount:   4,
+			wantExist:   []string{"prefixbucket/other/d"},
+			wantMissing: []string{"prefixbucket/copied/", "prefixbucket/copied/a", "prefixbucket/copied/sub/c"},
+		},
+		{
+			name: "copy prefix into itself",
+			call: func() (interface {
+				Recv() (*pb.PrefixProgress, error)
+			}, error) {
+				return client.CopyPrefix(ctx, &pb.PrefixRequest{
+					BucketSrc:  "prefixbucket",
+					BucketDest: "prefixbucket",
+					PrefixSrc:  "other/",
+					PrefixDest: "other/copy/",
+				})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "delete without prefix",
+			call: func() (interface {
+				Recv() (*pb.PrefixProgress, error)
+			}, error) {
+				return client.DeletePrefix(ctx, &pb.DeletePrefixRequest{Bucket: "prefixbucket"})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "delete with invalid continuation token",
+			call: func() (interface {
+				Recv() (*pb.PrefixProgress, error)
+			}, error) {
+				return client.DeletePrefix(ctx, &pb.DeletePrefixRequest{Bucket: "prefixbucket", Prefix: "other/", ContinuationToken: "!invalid"})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "move from bucket that doesn't exist",
+			call: func() (interface {
+				Recv() (*pb.PrefixProgress, error)
+			}, error) {
+				return client.MovePrefix(ctx, &pb.PrefixRequest{BucketSrc: "notexistprefixbucket", BucketDest: "prefixbucket", PrefixSrc: "folder/"})
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := tt.call()
+			if err != nil {
+				t.Fatalf("UploadHandler.Prefix() error = %v", err)
+			}
+
+			var last *pb.PrefixProgress
+			for {
+				progress, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("UploadHandler.Prefix() error = %v, wantCode %v", err, tt.wantCode)
+				}
+
+				if err != nil {
+					return
+				}
+
+				last = progress
+			}
+
+			if last == nil || !last.GetDone() || last.GetProcessed() != tt.wantCount || last.GetFailed() != 0 {
+				t.Fatalf("UploadHandler.Prefix() last progress = %v, want done with %d processed", last, tt.wantCount)
+			}
+
+			for _, object := range tt.wantExist {
+				path := strings.SplitN(object, "/", 2)
+				if _, err := uploadservice.HeadObject(ctx, aws.String(path[1]), aws.String(path[0])); err != nil {
+					t.Errorf("UploadHandler.Prefix() object %s error = %v", object, err)
+				}
+			}
+
+			for _, object := range tt.wantMissing {
+				path := strings.SplitN(object, "/", 2)
+				if _, err := uploadservice.HeadObject(ctx, aws.String(path[1]), aws.String(path[0])); status.Code(err) != codes.NotFound {
+					t.Errorf("UploadHandler.Prefix() object %s exists, error = %v", object, err)
+				}
+			}
+		})
+	}
+}
+
+func TestService_DeletePrefixResume(t *testing.T) {
+	ctx := context.Background()
+	s := object.NewService(storage.NewMemoryBackend())
+	for i := 0; i < 1001; i++ {
+		_, err := s.UploadFile(ctx, bytes.NewReader([]byte("data")), aws.String(fmt.Sprintf("folder/%04d", i)), aws.String("testbucket"), aws.String("text/plain"), nil)
+		if err != nil {
+			t.Fatalf("Could not create file with error: %v", err)
+		}
+	}
+
+	// Stop the delete after its first page.
+	stopped := errors.New("stopped")
+	var token string
+	err := s.DeletePrefix(ctx, aws.String("testbucket"), aws.String("folder/"), nil, func(progress *object.PrefixProgress) error {
+		token = progress.ContinuationToken
+		return stopped
+	})
+	if err != stopped || token == "" {
+		t.Fatalf("Service.DeletePrefix() error = %v, token = %q, want stopped with a token", err, token)
+	}
+
+	var last *object.PrefixProgress
+	err = s.DeletePrefix(ctx, aws.String("testbucket"), aws.String("folder/"), aws.String(token), func(progress *object.PrefixProgress) error {
+		last = progress
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Service.DeletePrefix() resume error = %v", err)
+	}
+
+	if last == nil || !last.Done || last.Processed != 1 || last.ContinuationToken != "" {
+		t.Errorf("Service.DeletePrefix() resume last progress = %+v, want done with 1 processed", last)
+	}
+
+	list, err := s.ListObjects(ctx, aws.String("testbucket"), aws.String("folder/"), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Service.ListObjects() error = %v", err)
+	}
+
+	if len(list.Contents) != 0 {
+		t.Errorf("Service.DeletePrefix() left %d objects", len(list.Contents))
+	}
+}
+
+func TestService_DeletePrefixBucketName(t *testing.T) {
+	ctx := context.Background()
+	s := object.NewService(storage.NewMemoryBackend())
+	_, err := s.UploadFile(ctx, bytes.NewReader([]byte("data")), aws.String("folder/file"), aws.String("Test_Bucket"), aws.String("text/plain"), nil)
+	if err != nil {
+		t.Fatalf("Could not create file with error: %v", err)
+	}
+
+	var last *object.PrefixProgress
+	err = s.DeletePrefix(ctx, aws.String("Test_Bucket"), aws.String("folder/"), nil, func(progress *object.PrefixProgress) error {
+		last = progress
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Service.DeletePrefix() error = %v", err)
+	}
+
+	if last == nil || !last.Done || last.Processed != 1 || last.Failed != 0 {
+		t.Errorf("Service.DeletePrefix() last progress = %+v, want done with 1 processed", last)
+	}
+}
+
+func TestService_GetObject(t *testing.T) {
+	uploadservice := object.NewService(backend)
+	_, err := uploadservice.UploadFile(
+		context.Background(),
+		bytes.NewReader([]byte("Hello, World!")),
+		aws.String("getfile.txt"),
+		aws.String("testbucket"),
+		aws.String("text/plain"),
+		map[string]*string{"test": aws.String("testt")},
+	)
+	if err != nil {
+		t.Fatalf("Could not create file with error: %v", err)
+	}
+
+	type args struct {
+		ctx       aws.Context
+		key       *string
+		bucket    *string
+		byteRange *string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "get whole object",
+			args: args{
+				ctx:    context.Background(),
+				key:    aws.String("getfile.txt"),
+				bucket: aws.String("testbucket"),
+			},
+			want:    []byte("Hello, World!"),
+			wantErr: false,
+		},
+		{
+			name: "get object range",
+			args: args{
+				ctx:       context.Background(),
+				key:       aws.String("getfile.txt"),
+				bucket:    aws.String("testbucket"),
+				byteRange: aws.String("bytes=7-11"),
+			},
+			want:    []byte("World"),
+			wantErr: false,
+		},
+		{
+			name: "get object invalid range",
+			args: args{
+				ctx:       context.Background(),
+				key:       aws.String("getfile.txt"),
+				bucket:    aws.String("testbucket"),
+				byteRange: aws.String("7-11"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "get object that does not exist",
+			args: args{
+				ctx:    context.Background(),
+				key:    aws.String("notexistobject"),
+				bucket: aws.String("testbucket"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "get object with empty key",
+			args: args{
+				ctx:    context.Background(),
+				key:    aws.String(""),
+				bucket: aws.String("testbucket"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "get object with nil bucket",
+			args: args{
+				ctx: context.Background(),
+				key: aws.String("getfile.txt"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uploadservice.GetObject(tt.args.ctx, tt.args.key, tt.args.bucket, tt.args.byteRange)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UploadService.GetObject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			defer got.Body.Close()
+
+			body, err := ioutil.ReadAll(got.Body)
+			if err != nil {
+				t.Errorf("UploadService.GetObject() failed reading body: %v", err)
+				return
+			}
+			if !bytes.Equal(body, tt.want) {
+				t.Errorf("UploadService.GetObject() = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetObjectMissingBucket(t *testing.T) {
+	uploadservice := object.NewService(backend)
+	bucketName := aws.String("getobjectmissingbucket")
+
+	_, err := uploadservice.GetObject(context.Background(), aws.String("getfile.txt"), bucketName, nil)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("UploadService.GetObject() error = %v, want NotFound", err)
+	}
+
+	if bucket.NewService(backend).BucketExists(context.Background(), aws.String("getobjectmissingbucket")) {
+		t.Errorf("UploadService.GetObject() created bucket %s", *bucketName)
+	}
+}
+
+func TestService_ReadMissingBucket(t *testing.T) {
+	ctx := context.Background()
+	s := object.NewService(storage.NewMemoryBackend())
+	bucketName := "readmissingbucket"
+
+	tests := []struct {
+		name string
+		read func(bucket *string) error
+	}{
+		{
+			name: "HeadObject",
+			read: func(bucket *string) error {
+				_, err := s.HeadObject(ctx, aws.String("file.txt"), bucket)
+				return err
+			},
+		},
+		{
+			name: "ListObjects",
+			read: func(bucket *string) error {
+				_, err := s.ListObjects(ctx, bucket, nil, nil, nil, nil)
+				return err
+			},
+		},
+		{
+			name: "ListUploadParts",
+			read: func(bucket *string) error {
+				_, err := s.ListUploadParts(ctx, aws.String("uploadid"), aws.String("file.txt"), bucket)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.read(aws.String(bucketName)); status.Code(err) != codes.NotFound {
+				t.Errorf("Service.%s() error = %v, want code %v", tt.name, err, codes.NotFound)
+			}
+
+			if bucket.NewService(s.GetBackend()).BucketExists(ctx, aws.String(bucketName)) {
+				t.Errorf("Service.%s() created bucket %s", tt.name, bucketName)
+			}
+		})
+	}
+}
+
+func TestHandler_DownloadObject(t *testing.T) {
+	hugefile := make([]byte, 3<<20)
+	if _, err := rand.Read(hugefile); err != nil {
+		t.Fatalf("Could not generate file with error: %v", err)
+	}
+
+	uploadservice := object.NewService(backend)
+	_, err := uploadservice.UploadFile(
+		context.Background(),
+		bytes.NewReader(hugefile),
+		aws.String("downloadfile"),
+		aws.String("testbucket"),
+		aws.String("application/octet-stream"),
+		map[string]*string{"test": aws.String("testt")},
+	)
+	if err != nil {
+		t.Fatalf("Could not create file with error: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		request         *pb.DownloadObjectRequest
+		want            []byte
+		wantContentType string
+		wantMetadata    map[string]string
+		wantErr         bool
+	}{
+		{
+			name: "download object",
+			request: &pb.DownloadObjectRequest{
+				Key:    "downloadfile",
+				Bucket: "testbucket",
+			},
+			want:            hugefile,
+			wantContentType: "application/octet-stream",
+			wantMetadata:    map[string]string{"Test": "testt"},
+			wantErr:         false,
+		},
+		{
+			name: "download object range",
+			request: &pb.DownloadObjectRequest{
+				Key:    "downloadfile",
+				Bucket: "testbucket",
+				Range:  "bytes=1048576-2097151",
+			},
+			want:            hugefile[1<<20 : 2<<20],
+			wantContentType: "application/octet-stream",
+			wantMetadata:    map[string]string{"Test": "testt"},
+			wantErr:         false,
+		},
+		{
+			name: "download object that does not exist",
+			request: &pb.DownloadObjectRequest{
+				Key:    "notexistobject",
+				Bucket: "testbucket",
+			},
+			wantErr: true,
+		},
+		{
+			name: "download object with empty key",
+			request: &pb.DownloadObjectRequest{
+				Key:    "",
+				Bucket: "testbucket",
+			},
+			wantErr: true,
+		},
+	}
+
+	// Create connection to server
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	// Create client
+	client := pb.NewUploadClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.DownloadObject(ctx, tt.request)
+			if err != nil {
+				t.Fatalf("UploadHandler.DownloadObject() failed opening stream: %v", err)
+			}
+
+			var first *pb.DownloadObjectResponse
+			var got []byte
+			for {
+				chunk, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+
+				if (err != nil) != tt.wantErr {
+					t.Errorf("UploadHandler.DownloadObject() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				if err != nil {
+					return
+				}
+
+				if first == nil {
+					first = chunk
+				}
+				got = append(got, chunk.GetChunk()...)
+			}
+
+			if tt.wantErr {
+				t.Errorf("UploadHandler.DownloadObject() error = nil, wantErr %v", tt.wantErr)
+				return
+			}
+
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("UploadHandler.DownloadObject() got %d bytes, want %d bytes", len(got), len(tt.want))
+			}
+
+			if first.GetContentLength() != int64(len(tt.want)) {
+				t.Errorf("UploadHandler.DownloadObject() ContentLength = %d, want %d", first.GetContentLength(), len(tt.want))
+			}
+
+			if first.GetContentType() != tt.wantContentType {
+				t.Errorf("UploadHandler.DownloadObject() ContentType = %s, want %s", first.GetContentType(), tt.wantContentType)
+			}
+
+			if !reflect.DeepEqual(first.GetMetadata(), tt.wantMetadata) {
+				t.Errorf("UploadHandler.DownloadObject() Metadata = %v, want %v", first.GetMetadata(), tt.wantMetadata)
+			}
+		})
+	}
+}
+
+func TestHandler_UploadStream(t *testing.T) {
+	hugefile := make([]byte, 50<<20)
+	if _, err := rand.Read(hugefile); err != nil {
+		t.Fatalf("Could not generate file with error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		details   *pb.UploadStreamDetails
+		file      []byte
+		chunkSize int
+		want      *pb.UploadStreamResponse
+		wantErr   bool
+	}{
+		{
+			name: "UploadStream - text file",
+			details: &pb.UploadStreamDetails{
+				Key:         "streamfile.txt",
+				Bucket:      "testbucket",
+				ContentType: "text/plain",
+				Metadata:    map[string]string{"test": "testt"},
+			},
+			file:      []byte("Hello, World!"),
+			chunkSize: 5,
+			want: &pb.UploadStreamResponse{
+				Location: fmt.Sprintf("%s/testbucket/streamfile.txt", endpoint),
+			},
+			wantErr: false,
+		},
+		{
+			name: "UploadStream - huge file",
+			details: &pb.UploadStreamDetails{
+				Key:         "streamfolder/streamfile",
+				Bucket:      "testbucket",
+				ContentType: "application/octet-stream",
+			},
+			file:      hugefile,
+			chunkSize: 1 << 20,
+			want: &pb.UploadStreamResponse{
+				Location: fmt.Sprintf("%s/testbucket/streamfolder/streamfile", endpoint),
+			},
+			wantErr: false,
+		},
+		{
+			name:      "UploadStream - without details",
+			details:   nil,
+			file:      []byte("Hello, World!"),
+			chunkSize: 5,
+			wantErr:   true,
+		},
+		{
+			name: "UploadStream - with empty key",
+			details: &pb.UploadStreamDetails{
+				Key:         "",
+				Bucket:      "testbucket",
+				ContentType: "text/plain",
+			},
+			file:      []byte("Hello, World!"),
+			chunkSize: 5,
+			wantErr:   true,
+		},
+	}
+
+	// Create connection to server
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	// Create client
+	client := pb.NewUploadClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.UploadStream(ctx)
+			if err != nil {
+				t.Fatalf("UploadHandler.UploadStream() failed opening stream: %v", err)
+			}
+
+			if tt.details != nil {
+				request := &pb.UploadStreamRequest{Data: &pb.UploadStreamRequest_Details{Details: tt.details}}
+				if err := stream.Send(request); err != nil && err != io.EOF {
+					t.Fatalf("UploadHandler.UploadStream() failed sending details: %v", err)
+				}
+			}
+
+			for i := 0; i < len(tt.file); i += tt.chunkSize {
+				end := i + tt.chunkSize
+				if end > len(tt.file) {
+					end = len(tt.file)
+				}
+
+				request := &pb.UploadStreamRequest{Data: &pb.UploadStreamRequest_Chunk{Chunk: tt.file[i:end]}}
+				if err := stream.Send(request); err != nil {
+					// The server closed the stream, the error is returned by CloseAndRecv.
+					break
+				}
+			}
+
+			got, err := stream.CloseAndRecv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UploadHandler.UploadStream() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != nil && !cmp.Equal(tt.want, got, cmpopts.IgnoreUnexported(pb.UploadStreamResponse{})) {
+				t.Errorf("UploadHandler.UploadStream() = %v, want %v", got, tt.want)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			obj, err := backend.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: aws.String(tt.details.GetBucket()),
+				Key:    aws.String(tt.details.GetKey()),
+			})
+			if err != nil {
+				t.Fatalf("UploadHandler.UploadStream() failed getting uploaded object: %v", err)
+			}
+			defer obj.Body.Close()
+
+			body, err := ioutil.ReadAll(obj.Body)
+			if err != nil {
+				t.Fatalf("UploadHandler.UploadStream() failed reading uploaded object: %v", err)
+			}
+
+			if !bytes.Equal(body, tt.file) {
+				t.Errorf("UploadHandler.UploadStream() uploaded %d bytes, want %d bytes", len(body), len(tt.file))
+			}
+		})
+	}
+}
+
+// TODO: TestHandler_UploadAbort
+// TODO: TestHandler_UploadComplete
+// TODO: TestHandler_UploadPart
+
+func TestHandler_ListObjects(t *testing.T) {
+	uploadservice := object.NewService(backend)
+	files := map[string]string{
+		"a.txt":         "text/plain",
+		"docs/b.pdf":    "application/pdf",
+		"docs/c.txt":    "text/plain",
+		"docs/old/d.md": "text/markdown",
+		"e.png":         "image/png",
+	}
+	for key, contentType := range files {
+		_, err := uploadservice.UploadFile(
+			context.Background(),
+			bytes.NewReader([]byte(key)),
+			aws.String(key),
+			aws.String("listbucket"),
+			aws.String(contentType),
+			nil,
+		)
+		if err != nil {
+			t.Fatalf("Could not create file with error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name               string
+		request            *pb.ListObjectsRequest
+		wantKeys           []string
+		wantCommonPrefixes []string
+		wantErr            bool
+	}{
+		{
+			name:     "list all objects",
+			request:  &pb.ListObjectsRequest{Bucket: "listbucket"},
+			wantKeys: []string{"a.txt", "docs/b.pdf", "docs/c.txt", "docs/old/d.md", "e.png"},
+		},
+		{
+			name:               "list root folder",
+			request:            &pb.ListObjectsRequest{Bucket: "listbucket", Delimiter: "/"},
+			wantKeys:           []string{"a.txt", "e.png"},
+			wantCommonPrefixes: []string{"docs/"},
+		},
+		{
+			name:               "list folder in pages",
+			request:            &pb.ListObjectsRequest{Bucket: "listbucket", Prefix: "docs/", Delimiter: "/", PageSize: 1},
+			wantKeys:           []string{"docs/b.pdf", "docs/c.txt"},
+			wantCommonPrefixes: []string{"docs/old/"},
+		},
+		{
+			name:    "list with page size too large",
+			request: &pb.ListObjectsRequest{Bucket: "listbucket", PageSize: 1001},
+			wantErr: true,
+		},
+		{
+			name:    "list with invalid page token",
+			request: &pb.ListObjectsRequest{Bucket: "listbucket", PageToken: "!invalid"},
+			wantErr: true,
+		},
+		{
+			name:    "list with empty bucket name",
+			request: &pb.ListObjectsRequest{Bucket: ""},
+			wantErr: true,
+		},
+	}
+
+	// Create connection to server
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	// Create client
+	client := pb.NewUploadClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotKeys, gotCommonPrefixes []string
+			for {
+				got, err := client.ListObjects(ctx, tt.request)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("UploadHandler.ListObjects() error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				if err != nil {
+					return
+				}
+
+				for _, obj := range got.GetObjects() {
+					gotKeys = append(gotKeys, obj.GetKey())
+					if obj.GetContentType() != files[obj.GetKey()] {
+						t.Errorf("UploadHandler.ListObjects() ContentType = %s, want %s", obj.GetContentType(), files[obj.GetKey()])
+					}
+
+					if obj.GetSize() != int64(len(obj.GetKey())) || obj.GetETag() == "" || obj.GetLastModified() == 0 {
+						t.Errorf("UploadHandler.ListObjects() object = %v, want its size, ETag and last modified", obj)
+					}
+				}
+				gotCommonPrefixes = append(gotCommonPrefixes, got.GetCommonPrefixes()...)
+
+				if got.GetNextPageToken() == "" {
+					break
+				}
+				tt.request.PageToken = got.GetNextPageToken()
+			}
+
+			if !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Errorf("UploadHandler.ListObjects() keys = %v, want %v", gotKeys, tt.wantKeys)
+			}
+
+			if !reflect.DeepEqual(gotCommonPrefixes, tt.wantCommonPrefixes) {
+				t.Errorf("UploadHandler.ListObjects() commonPrefixes = %v, want %v", gotCommonPrefixes, tt.wantCommonPrefixes)
+			}
+		})
+	}
+}
+
+func TestHandler_StatObject(t *testing.T) {
+	uploadservice := object.NewService(backend)
+	file := []byte("Hello, World!")
+	_, err := uploadservice.UploadFile(
+		context.Background(),
+		bytes.NewReader(file),
+		aws.String("statfile"),
+		aws.String("testbucket"),
+		aws.String("text/plain"),
+		map[string]*string{"test": aws.String("testt")},
+	)
+	if err != nil {
+		t.Fatalf("Could not create file with error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		request  *pb.StatObjectRequest
+		want     *pb.StatObjectResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "stat object",
+			request: &pb.StatObjectRequest{Key: "statfile", Bucket: "testbucket"},
+			want: &pb.StatObjectResponse{
+				ContentLength: int64(len(file)),
+				ContentType:   "text/plain",
+				ETag:          `"65a8e27d8879283831b664bd8b7f0ad4"`,
+				StorageClass:  s3.StorageClassStandard,
+				Metadata:      map[string]string{"Test": "testt"},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "stat object that does not exist",
+			request:  &pb.StatObjectRequest{Key: "notexistobject", Bucket: "testbucket"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "stat object in bucket that does not exist",
+			request:  &pb.StatObjectRequest{Key: "statfile", Bucket: "notexistbucket"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "stat object with empty key",
+			request:  &pb.StatObjectRequest{Key: "", Bucket: "testbucket"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	// Create connection to server
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	// Create client
+	client := pb.NewUploadClient(conn)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.StatObject(ctx, tt.request)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("UploadHandler.StatObject() error = %v, wantCode %v", err, tt.wantCode)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.GetLastModified() == 0 {
+				t.Errorf("UploadHandler.StatObject() LastModified = 0, want the object's last modified time")
+			}
+
+			got.LastModified = 0
+			if !cmp.Equal(got, tt.want, cmpopts.IgnoreUnexported(pb.StatObjectResponse{})) {
+				t.Errorf("UploadHandler.StatObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GeneratePresignedURL(t *testing.T) {
+	// Presigning doesn't send requests, but the bucket is ensured to exist first,
+	// so serve a fake S3 server that responds OK to any request.
+	s3Server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s3Server.Close()
+
+	newSession, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.NewStaticCredentials("accesskey", "secretkey", ""),
+		Endpoint:         aws.String(s3Server.URL),
+		Region:           aws.String("eu-east-1"),
+		DisableSSL:       aws.Bool(true),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("Could not create session with error: %v", err)
+	}
+	uploadservice := object.NewService(storage.NewS3Backend(s3.New(newSession)))
+
+	tests := []struct {
+		name        string
+		method      string
+		key         string
+		bucket      string
+		expiry      time.Duration
+		contentType string
+		metadata    map[string]*string
+		wantPath    string
+		wantQuery   map[string]string
+		wantHeaders map[string]string
+		wantErr     bool
+	}{
+		{
+			name:      "presign get",
+			method:    http.MethodGet,
+			key:       "file.txt",
+			bucket:    "testbucket",
+			wantPath:  "/testbucket/file.txt",
+			wantQuery: map[string]string{"X-Amz-Expires": "900"},
+		},
+		{
+			name:        "presign get with content type and normalized bucket",
+			method:      http.MethodGet,
+			key:         "file.txt",
+			bucket:      "T874777@omer",
+			expiry:      time.Hour,
+			contentType: "text/plain",
+			wantPath:    "/t874777-omer/file.txt",
+			wantQuery:   map[string]string{"X-Amz-Expires": "3600", "response-content-type": "text/plain"},
+		},
+		{
+			name:        "presign put with content type and metadata",
+			method:      http.MethodPut,
+			key:         "file.txt",
+			bucket:      "testbucket",
+			contentType: "text/plain",
+			metadata:    map[string]*string{"test": aws.String("testt")},
+			wantPath:    "/testbucket/file.txt",
+			wantQuery:   map[string]string{"X-Amz-Expires": "900"},
+			wantHeaders: map[string]string{"Content-Type": "text/plain", "X-Amz-Meta-Test": "testt"},
+		},
+		{
+			name:    "presign with invalid method",
+			method:  http.MethodDelete,
+			key:     "file.txt",
+			bucket:  "testbucket",
+			wantErr: true,
+		},
+		{
+			name:    "presign with expiry too long",
+			method:  http.MethodGet,
+			key:     "file.txt",
+			bucket:  "testbucket",
+			expiry:  8 * 24 * time.Hour,
+			wantErr: true,
+		},
+		{
+			name:    "presign with empty key",
+			method:  http.MethodGet,
+			key:     "",
+			bucket:  "testbucket",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, headers, err := uploadservice.GeneratePresignedURL(
+				context.Background(),
+				aws.String(tt.method),
+				aws.String(tt.key),
+				aws.String(tt.bucket),
+				tt.expiry,
+				aws.String(tt.contentType),
+				tt.metadata,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.GeneratePresignedURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			presignedURL, err := url.Parse(got)
+			if err != nil {
+				t.Fatalf("Service.GeneratePresignedURL() = %s, failed parsing URL: %v", got, err)
+			}
+
+			if !strings.HasPrefix(got, s3Server.URL) || presignedURL.Path != tt.wantPath {
+				t.Errorf("Service.GeneratePresignedURL() = %s, want path %s", got, tt.wantPath)
+			}
+
+			query := presignedURL.Query()
+			if query.Get("X-Amz-Signature") == "" {
+				t.Errorf("Service.GeneratePresignedURL() = %s, want a signature", got)
+			}
+
+			for name, value := range tt.wantQuery {
+				if query.Get(name) != value {
+					t.Errorf("Service.GeneratePresignedURL() query %s = %s, want %s", name, query.Get(name), value)
+				}
+			}
+
+			for name, value := range tt.wantHeaders {
+				if headers.Get(name) != value {
+					t.Errorf("Service.GeneratePresignedURL() header %s = %s, want %s", name, headers.Get(name), value)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_GeneratePresignedURL(t *testing.T) {
+	// Create connection to server
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	// Create client
+	client := pb.NewUploadClient(conn)
+
+	// The in-memory storage backend of the test server can't presign URLs.
+	_, err = client.GeneratePresignedURL(ctx, &pb.GeneratePresignedURLRequest{
+		Method: "get",
+		Bucket: "testbucket",
+		Key:    "file.txt",
+	})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("UploadHandler.GeneratePresignedURL() error = %v, wantCode %v", err, codes.Unimplemented)
+	}
+}
+
+func TestHandler_UploadPart(t *testing.T) {
+	uploadservice := object.NewService(backend)
+	upload, err := uploadservice.UploadInit(
+		context.Background(),
+		aws.String("partsfile"),
+		aws.String("testbucket"),
+		aws.String("text/plain"),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Could not init upload with error: %v", err)
+	}
+
+	parts := map[int64][]byte{
+		1: make([]byte, 5<<20),
+		2: make([]byte, 5<<20),
+		3: []byte("last part"),
+	}
+	for partNumber, part := range parts {
+		if partNumber != 3 {
+			if _, err := rand.Read(part); err != nil {
+				t.Fatalf("Could not generate part with error: %v", err)
+			}
+		}
+	}
+
+	// Create connection to server
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	// Create client
+	client := pb.NewUploadClient(conn)
+
+	stream, err := client.UploadPart(ctx)
+	if err != nil {
+		t.Fatalf("UploadHandler.UploadPart() failed opening stream: %v", err)
+	}
+
+	for partNumber, part := range parts {
+		err := stream.Send(&pb.UploadPartRequest{
+			Part:       part,
+			PartNumber: partNumber,
+			UploadId:   *upload.UploadId,
+			Key:        "partsfile",
+			Bucket:     "testbucket",
+		})
+		if err != nil {
+			t.Fatalf("UploadHandler.UploadPart() failed sending part %d: %v", partNumber, err)
+		}
+	}
+
+	// A part of an upload that doesn't exist fails without failing the other parts.
+	err = stream.Send(&pb.UploadPartRequest{
+		Part:       []byte("part"),
+		PartNumber: 4,
+		UploadId:   "notexistupload",
+		Key:        "partsfile",
+		Bucket:     "testbucket",
+	})
+	if err != nil {
+		t.Fatalf("UploadHandler.UploadPart() failed sending part 4: %v", err)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("UploadHandler.UploadPart() failed closing stream: %v", err)
+	}
+
+	responses := make(map[int64]*pb.UploadPartResponse)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("UploadHandler.UploadPart() error = %v", err)
+		}
+		responses[resp.GetPartNumber()] = resp
+	}
+
+	if len(responses) != 4 {
+		t.Fatalf("UploadHandler.UploadPart() got %d responses, want 4", len(responses))
+	}
+
+	for partNumber, part := range parts {
+		resp := responses[partNumber]
+		md5sum := md5.Sum(part)
+		sha256sum := sha256.Sum256(part)
+		want := &pb.UploadPartResponse{
+			Code:       200,
+			Message:    fmt.Sprintf("successfully uploaded part \"%x\"", md5sum),
+			PartNumber: partNumber,
+			ETag:       fmt.Sprintf("\"%x\"", md5sum),
+			Size:       int64(len(part)),
+			Checksum:   hex.EncodeToString(sha256sum[:]),
+		}
+
+		if !cmp.Equal(resp, want, cmpopts.IgnoreUnexported(pb.UploadPartResponse{})) {
+			t.Errorf("UploadHandler.UploadPart() = %v, want %v", resp, want)
+		}
+	}
+
+	if resp := responses[4]; resp.GetError() == nil || resp.GetETag() != "" || resp.GetCode() != 500 {
+		t.Errorf("UploadHandler.UploadPart() = %v, want an error", resp)
+	}
+}
+
+// concurrencyBackend is a storage backend that records the maximal number of concurrent
+// part uploads, in total and for each key.
+type concurrencyBackend struct {
+	storage.Backend
+	mu          sync.Mutex
+	inFlight    map[string]int
+	total       int
+	maxInFlight map[string]int
+	maxTotal    int
+}
+
+func (b *concurrencyBackend) UploadPart(ctx aws.Context, input *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+	b.mu.Lock()
+	b.inFlight[*input.Key]++
+	b.total++
+	if b.inFlight[*input.Key] > b.maxInFlight[*input.Key] {
+		b.maxInFlight[*input.Key] = b.inFlight[*input.Key]
+	}
+	if b.total > b.maxTotal {
+		b.maxTotal = b.total
+	}
+	b.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	b.mu.Lock()
+	b.inFlight[*input.Key]--
+	b.total--
+	b.mu.Unlock()
+
+	return b.Backend.UploadPart(ctx, input)
+}
+
+func TestHandler_UploadPartConcurrency(t *testing.T) {
+	const streamLimit, globalLimit, streams, partsPerStream = 2, 3, 3, 8
+
+	limitedBackend := &concurrencyBackend{
+		Backend:     storage.NewMemoryBackend(),
+		inFlight:    make(map[string]int),
+		maxInFlight: make(map[string]int),
+	}
+	limiter := object.NewPartLimiter(streamLimit, globalLimit)
+	handler := object.NewHandlerWithPartLimiter(object.NewService(limitedBackend), logrus.New(), limiter)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterUploadServer(grpcServer, handler)
+	listener := bufconn.Listen(1 << 20)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(
+		ctx,
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, streams)
+	for i := 0; i < streams; i++ {
+		key := fmt.Sprintf("concurrentfile%d", i)
+		upload, err := handler.GetService().UploadInit(ctx, aws.String(key), aws.String("testbucket"), nil, nil)
+		if err != nil {
+			t.Fatalf("Could not init upload with error: %v", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			stream, err := client.UploadPart(ctx)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for partNumber := int64(1); partNumber <= partsPerStream; partNumber++ {
+				err := stream.Send(&pb.UploadPartRequest{
+					Part:       []byte("part"),
+					PartNumber: partNumber,
+					UploadId:   *upload.UploadId,
+					Key:        key,
+					Bucket:     "testbucket",
+				})
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+
+			if err := stream.CloseSend(); err != nil {
+				errs <- err
+				return
+			}
+
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					return
+				}
+
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				if resp.GetError() != nil {
+					errs <- fmt.Errorf("part %d failed: %v", resp.GetPartNumber(), resp.GetError())
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("UploadHandler.UploadPart() error = %v", err)
+	}
+
+	if limitedBackend.maxTotal > globalLimit {
+		t.Errorf("UploadHandler.UploadPart() had %d concurrent part uploads, want at most %d", limitedBackend.maxTotal, globalLimit)
+	}
+
+	for key, maxInFlight := range limitedBackend.maxInFlight {
+		if maxInFlight > streamLimit {
+			t.Errorf("UploadHandler.UploadPart() had %d concurrent part uploads of %s, want at most %d", maxInFlight, key, streamLimit)
+		}
+	}
+
+	stats := limiter.Stats()
+	if stats.WaitCount != streams*partsPerStream || stats.Queued != 0 || stats.InFlight != 0 {
+		t.Errorf("PartLimiter.Stats() = %+v, want %d parts that waited and none queued or in flight", stats, streams*partsPerStream)
+	}
+}
+
+func TestHandler_UploadPartIdleStreams(t *testing.T) {
+	const globalLimit = 2
+
+	limiter := object.NewPartLimiter(1, globalLimit)
+	handler := object.NewHandlerWithPartLimiter(object.NewService(storage.NewMemoryBackend()), logrus.New(), limiter)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterUploadServer(grpcServer, handler)
+	listener := bufconn.Listen(1 << 20)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(
+		ctx,
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	// Streams that send no parts must not hold the global slots.
+	for i := 0; i < globalLimit+1; i++ {
+		if _, err := client.UploadPart(ctx); err != nil {
+			t.Fatalf("UploadHandler.UploadPart() error = %v", err)
+		}
+	}
+
+	upload, err := handler.GetService().UploadInit(ctx, aws.String("activefile"), aws.String("testbucket"), nil, nil)
+	if err != nil {
+		t.Fatalf("Could not init upload with error: %v", err)
+	}
+
+	stream, err := client.UploadPart(ctx)
+	if err != nil {
+		t.Fatalf("UploadHandler.UploadPart() error = %v", err)
+	}
+
+	err = stream.Send(&pb.UploadPartRequest{
+		Part:       []byte("part"),
+		PartNumber: 1,
+		UploadId:   *upload.UploadId,
+		Key:        "activefile",
+		Bucket:     "testbucket",
+	})
+	if err != nil {
+		t.Fatalf("UploadHandler.UploadPart() send error = %v", err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("UploadHandler.UploadPart() error = %v, want the part uploaded while other streams are idle", err)
+	}
+
+	if resp.GetError() != nil {
+		t.Errorf("UploadHandler.UploadPart() = %v, want the part uploaded", resp)
+	}
+}
+
+// failingHeadBackend is a storage backend whose HeadObject fails with err.
+type failingHeadBackend struct {
+	storage.Backend
+	err error
+}
+
+func (b failingHeadBackend) HeadObject(ctx aws.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	return nil, b.err
+}
+
+// errorInfo returns the google.rpc.ErrorInfo details of a gRPC error, or nil if it has none.
+func errorInfo(err error) *errdetails.ErrorInfo {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+
+	return nil
+}
+
+func TestHandler_Errors(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	tests := []struct {
+		name       string
+		call       func() error
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name: "invalid argument",
+			call: func() error {
+				_, err := client.UploadInit(ctx, &pb.UploadInitRequest{Key: "", Bucket: "testbucket"})
+				return err
+			},
+			wantCode:   codes.InvalidArgument,
+			wantReason: object.ReasonInvalidArgument,
+		},
+		{
+			name: "object not found",
+			call: func() error {
+				_, err := client.StatObject(ctx, &pb.StatObjectRequest{Key: "notexistobject", Bucket: "testbucket"})
+				return err
+			},
+			wantCode:   codes.NotFound,
+			wantReason: object.ReasonObjectNotFound,
+		},
+		{
+			name: "upload not found",
+			call: func() error {
+				_, err := client.UploadAbort(ctx, &pb.UploadAbortRequest{
+					UploadId: "notexistupload",
+					Key:      "testfile",
+					Bucket:   "testbucket",
+				})
+				return err
+			},
+			wantCode:   codes.NotFound,
+			wantReason: object.ReasonUploadNotFound,
+		},
+		{
+			name: "source bucket not found",
+			call: func() error {
+				_, err := client.CopyObject(ctx, &pb.CopyObjectRequest{
+					BucketSrc:  "notexistsourcebucket",
+					BucketDest: "testbucket",
+					KeySrc:     "testfile",
+					KeyDest:    "testfile",
+				})
+				return err
+			},
+			wantCode:   codes.NotFound,
+			wantReason: object.ReasonBucketNotFound,
+		},
+		{
+			name: "complete upload without parts",
+			call: func() error {
+				upload, err := client.UploadInit(ctx, &pb.UploadInitRequest{Key: "nopartsfile", Bucket: "testbucket"})
+				if err != nil {
+					return err
+				}
+
+				_, err = client.UploadComplete(ctx, &pb.UploadCompleteRequest{
+					UploadId: upload.GetUploadId(),
+					Key:      "nopartsfile",
+					Bucket:   "testbucket",
+				})
+				return err
+			},
+			wantCode:   codes.InvalidArgument,
+			wantReason: object.ReasonInvalidArgument,
+		},
+		{
+			name: "presign not supported",
+			call: func() error {
+				_, err := client.GeneratePresignedURL(ctx, &pb.GeneratePresignedURLRequest{
+					Method: "GET",
+					Key:    "testfile",
+					Bucket: "testbucket",
+				})
+				return err
+			},
+			wantCode:   codes.Unimplemented,
+			wantReason: object.ReasonNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("UploadHandler error = %v, wantCode %v", err, tt.wantCode)
+			}
+
+			info := errorInfo(err)
+			if info == nil || info.GetReason() != tt.wantReason || info.GetDomain() != object.ErrorDomain {
+				t.Errorf("UploadHandler error info = %v, want reason %s in domain %s", info, tt.wantReason, object.ErrorDomain)
+			}
+		})
+	}
+}
+
+func TestService_ErrorsStorage(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantReason  string
+		storageCode string
+	}{
+		{
+			name:        "storage is down",
+			err:         awserr.New("RequestError", "send request failed", fmt.Errorf("connection refused")),
+			wantCode:    codes.Unavailable,
+			wantReason:  object.ReasonStorageUnavailable,
+			storageCode: "RequestError",
+		},
+		{
+			name:        "storage failed unexpectedly",
+			err:         awserr.NewRequestFailure(awserr.New(storage.ErrCodeInternalError, "internal error", nil), http.StatusInternalServerError, ""),
+			wantCode:    codes.Internal,
+			wantReason:  object.ReasonInternal,
+			storageCode: storage.ErrCodeInternalError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := object.NewService(failingHeadBackend{Backend: storage.NewMemoryBackend(), err: tt.err})
+			_, err := service.HeadObject(context.Background(), aws.String("testfile"), aws.String("testbucket"))
+
+			var svcErr *object.Error
+			if !errors.As(err, &svcErr) {
+				t.Fatalf("Service.HeadObject() error = %v, want *object.Error", err)
+			}
+
+			if svcErr.Code != tt.wantCode || svcErr.Reason != tt.wantReason {
+				t.Errorf("Service.HeadObject() error = %v %s, want %v %s", svcErr.Code, svcErr.Reason, tt.wantCode, tt.wantReason)
+			}
+
+			if svcErr.Metadata["storageCode"] != tt.storageCode {
+				t.Errorf("Service.HeadObject() error metadata = %v, want storageCode %s", svcErr.Metadata, tt.storageCode)
+			}
+		})
+	}
+}
+
+func TestHandler_UploadChecksum(t *testing.T) {
+	file := []byte("Hello, World!")
+	md5Sum := md5.Sum(file)
+	sha256Sum := sha256.Sum256(file)
+	crc32cSum := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc32cSum, crc32.Checksum(file, crc32.MakeTable(crc32.Castagnoli)))
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUploadClient(conn)
+
+	tests := []struct {
+		name     string
+		key      string
+		upload   func(key string, checksum *pb.Checksum) error
+		checksum *pb.Checksum
+		wantCode codes.Code
+	}{
+		{
+			name: "upload media with SHA-256",
+			key:  "checksumfile1",
+			upload: func(key string, checksum *pb.Checksum) error {
+				_, err := client.UploadMedia(ctx, &pb.UploadMediaRequest{File: file, Key: key, Bucket: "testbucket", Checksum: checksum})
+				return err
+			},
+			checksum: &pb.Checksum{Algorithm: pb.ChecksumAlgorithm_SHA256, Value: hex.EncodeToString(sha256Sum[:])},
+			wantCode: codes.OK,
+		},
+		{
+			name: "upload multipart with CRC32C",
+			key:  "checksumfile2",
+			upload: func(key string, checksum *pb.Checksum) error {
+				_, err := client.UploadMultipart(ctx, &pb.UploadMultipartRequest{
+					File:     file,
+					Key:      key,
+					Bucket:   "testbucket",
+					Metadata: map[string]string{"test": "testt"},
+					Checksum: checksum,
+				})
+				return err
+			},
+			checksum: &pb.Checksum{Algorithm: pb.ChecksumAlgorithm_CRC32C, Value: hex.EncodeToString(crc32cSum)},
+			wantCode: codes.OK,
+		},
+		{
+			name: "upload media with mismatching MD5",
+			key:  "checksumfile3",
+			upload: func(key string, checksum *pb.Checksum) error {
+				_, err := client.UploadMedia(ctx, &pb.UploadMediaRequest{File: file, Key: key, Bucket: "testbucket", Checksum: checksum})
+				return err
+			},
+			checksum: &pb.Checksum{Algorithm: pb.ChecksumAlgorithm_MD5, Value: strings.Repeat("0", 32)},
+			wantCode: codes.DataLoss,
+		},
+		{
+			name: "upload media with invalid checksum",
+			key:  "checksumfile4",
+			upload: func(key string, checksum *pb.Checksum) error {
+				_, err := client.UploadMedia(ctx, &pb.UploadMediaRequest{File: file, Key: key, Bucket: "testbucket", Checksum: checksum})
+				return err
+			},
+			checksum: &pb.Checksum{Value: hex.EncodeToString(md5Sum[:])},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "upload part with mismatching SHA-256",
+			key:  "checksumfile5",
+			upload: func(key string, checksum *pb.Checksum) error {
+				upload, err := client.UploadInit(ctx, &pb.UploadInitRequest{Key: key, Bucket: "testbucket"})
+				if err != nil {
+					return err
+				}
+
+				stream, err := client.UploadPart(ctx)
+				if err != nil {
+					return err
+				}
+
+				err = stream.Send(&pb.UploadPartRequest{
+					Part:       file,
+					PartNumber: 1,
+					UploadId:   upload.GetUploadId(),
+					Key:        key,
+					Bucket:     "testbucket",
+					Checksum:   checksum,
+				})
+				if err != nil {
+					return err
+				}
+
+				if err := stream.CloseSend(); err != nil {
+					return err
+				}
+
+				resp, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+
+				return status.Error(codes.Code(resp.GetError().GetCode()), resp.GetError().GetMessage())
+			},
+			checksum: &pb.Checksum{Algorithm: pb.ChecksumAlgorithm_SHA256, Value: strings.Repeat("0", 64)},
+			wantCode: codes.DataLoss,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.upload(tt.key, tt.checksum)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("UploadHandler upload error = %v, wantCode %v", err, tt.wantCode)
+			}
+
+			stat, err := client.StatObject(ctx, &pb.StatObjectRequest{Key: tt.key, Bucket: "testbucket"})
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != codes.NotFound {
+					t.Errorf("UploadHandler.StatObject() error = %v, want the object not to be stored", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UploadHandler.StatObject() error = %v", err)
+			}
+
+			if !cmp.Equal(stat.GetChecksum(), tt.checksum, cmpopts.IgnoreUnexported(pb.Checksum{})) {
+				t.Errorf("UploadHandler.StatObject() checksum = %v, want %v", stat.GetChecksum(), tt.checksum)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package object
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// prefixPageSize is the number of objects listed and processed in each page of a prefix operation.
+const prefixPageSize = 1000
+
+// PrefixFailure is an object that a prefix operation failed to process.
+type PrefixFailure struct {
+	// Key is the object's key.
+	Key string
+
+	// Err is the error of the object.
+	Err error
+}
+
+// PrefixProgress is the progress of a prefix operation, reported after each page of objects.
+type PrefixProgress struct {
+	// Processed is the number of objects processed so far in this run, including the failed objects.
+	Processed int64
+
+	// Failed is the number of objects that failed so far in this run.
+	Failed int64
+
+	// Failures are the objects of the last page that failed.
+	Failures []*PrefixFailure
+
+	// ContinuationToken resumes the operation after the last page, empty once it's done.
+	ContinuationToken string
+
+	// Done is true once all of the objects under the prefix were processed.
+	Done bool
+}
+
+// PrefixProgressFunc is called with the progress of a prefix operation after each page of objects,
+// the operation stops if it returns an error.
+type PrefixProgressFunc func(progress *PrefixProgress) error
+
+// CopyPrefix copies every object whose key begins with prefixSrc in bucketSrc to bucketDest,
+// replacing prefixSrc with prefixDest in its key. The objects are listed in pages, and each page
+// is copied up to concurrency objects concurrently, the same as CopyObject copies them.
+// progress is called after each page, objects that failed are reported and the copy goes on.
+// continuationToken is the ContinuationToken of the last progress of a previous run of the copy,
+// which resumes it after its last finished page.
+func (s *Service) CopyPrefix(
+	ctx aws.Context,
+	bucketSrc *string,
+	bucketDest *string,
+	prefixSrc *string,
+	prefixDest *string,
+	continuationToken *string,
+	concurrency int,
+	progress PrefixProgressFunc,
+) error {
+	return s.runPrefixBatch(
+		ctx,
+		bucketSrc,
+		bucketDest,
+		prefixSrc,
+		prefixDest,
+		continuationToken,
+		concurrency,
+		progress,
+		func(ctx aws.Context, result *BatchResult) {
+			// The folder object of the source prefix has no place in the root of the destination.
+			if result.Item.KeyDest == "" {
+				return
+			}
+
+			s.copyBatchItem(ctx, result)
+		},
+	)
+}
+
+// MovePrefix moves every object whose key begins with prefixSrc in bucketSrc to bucketDest,
+// replacing prefixSrc with prefixDest in its key. The objects are listed in pages, and each page
+// is moved up to concurrency objects concurrently, the same as MoveObject moves them.
+// progress is called after each page, objects that failed are reported and the move goes on.
+// continuationToken is the ContinuationToken of the last progress of a previous run of the move,
+// which resumes it after its last finished page.
+func (s *Service) MovePrefix(
+	ctx aws.Context,
+	bucketSrc *string,
+	bucketDest *string,
+	prefixSrc *string,
+	prefixDest *string,
+	continuationToken *string,
+	concurrency int,
+	progress PrefixProgressFunc,
+) error {
+	return s.runPrefixBatch(
+		ctx,
+		bucketSrc,
+		bucketDest,
+		prefixSrc,
+		prefixDest,
+		continuationToken,
+		concurrency,
+		progress,
+		func(ctx aws.Context, result *BatchResult) {
+			// The folder object of the source prefix has no place in the root of the destination,
+			// so it's only deleted.
+			if result.Item.KeyDest == "" {
+				move := &MoveResult{}
+				result.Err = s.deleteObjectWithRetries(
+					ctx,
+					aws.String(result.Item.KeySrc),
+					aws.String(result.Item.BucketSrc),
+					move,
+				)
+
+				return
+			}
+
+			s.moveBatchItem(ctx, result)
+		},
+	)
+}
+
+// DeletePrefix deletes every object whose key begins with prefix in bucket.
+// The objects are listed and deleted in pages, progress is called after each page,
+// objects that failed are reported and the delete goes on.
+// continuationToken is the ContinuationToken of the last progress of a previous run of the delete,
+// which resumes it after its last finished page.
+func (s *Service) DeletePrefix(
+	ctx aws.Context,
+	bucket *string,
+	prefix *string,
+	continuationToken *string,
+	progress PrefixProgressFunc,
+) error {
+	if ctx == nil {
+		return invalidArgument("context is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return invalidArgument("bucket name is required")
+	}
+
+	// An empty prefix would delete the whole bucket, which is done by DeleteBucket.
+	if prefix == nil || *prefix == "" {
+		return invalidArgument("prefix is required")
+	}
+
+	s.normalizeBucketName(bucket)
+
+	return s.listPrefix(ctx, bucket, prefix, continuationToken, progress, func(objects []*s3.Object) ([]*PrefixFailure, error) {
+		keys := make([]*string, 0, len(objects))
+		for _, object := range objects {
+			keys = append(keys, object.Key)
+		}
+
+		output, err := s.DeleteObjects(ctx, bucket, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		failures := make([]*PrefixFailure, 0, len(output.Errors))
+		for _, keyErr := range output.Errors {
+			failures = append(failures, &PrefixFailure{
+				Key: aws.StringValue(keyErr.Key),
+				Err: wrapError(deleteKeyError(keyErr.Code, keyErr.Message), "failed to delete %s", aws.StringValue(keyErr.Key)),
+			})
+		}
+
+		return failures, nil
+	})
+}
+
+// runPrefixBatch validates a copy or move of a prefix and runs op on each of its objects,
+// page by page, up to concurrency objects concurrently.
+func (s *Service) runPrefixBatch(
+	ctx aws.Context,
+	bucketSrc *string,
+	bucketDest *string,
+	prefixSrc *string,
+	prefixDest *string,
+	continuationToken *string,
+	concurrency int,
+	progress PrefixProgressFunc,
+	op func(ctx aws.Context, result *BatchResult),
+) error {
+	if ctx == nil {
+		return invalidArgument("context is required")
+	}
+
+	if bucketSrc == nil || *bucketSrc == "" {
+		return invalidArgument("source bucket name is required")
+	}
+
+	if bucketDest == nil || *bucketDest == "" {
+		return invalidArgument("destination bucket name is required")
+	}
+
+	source, destination := aws.StringValue(prefixSrc), aws.StringValue(prefixDest)
+
+	// Objects copied into the source prefix would be listed and copied again.
+	if s.sameBucket(*bucketSrc, *bucketDest) && strings.HasPrefix(destination, source) {
+		return invalidArgument("destination prefix %q must not be inside source prefix %q in the same bucket", destination, source)
+	}
+
+	return s.listPrefix(ctx, bucketSrc, prefixSrc, continuationToken, progress, func(objects []*s3.Object) ([]*PrefixFailure, error) {
+		items := make([]BatchItem, 0, len(objects))
+		for _, object := range objects {
+			items = append(items, BatchItem{
+				BucketSrc:  *bucketSrc,
+				BucketDest: *bucketDest,
+				KeySrc:     *object.Key,
+				KeyDest:    destination + strings.TrimPrefix(*object.Key, source),
+			})
+		}
+
+		results, err := s.runBatch(ctx, items, concurrency, op)
+		if err != nil {
+			return nil, err
+		}
+
+		var failures []*PrefixFailure
+		count := 0
+		for result := range results {
+			count++
+			if result.Err != nil {
+				failures = append(failures, &PrefixFailure{Key: result.Item.KeySrc, Err: result.Err})
+			}
+		}
+
+		// A page is finished only once all of its objects were processed.
+		if count < len(items) {
+			return nil, wrapError(ctx.Err(), "stopped before all of the objects were processed")
+		}
+
+		return failures, nil
+	})
+}
+
+// listPrefix lists the objects whose key begins with prefix in bucket, page by page, starting
+// after the last page of continuationToken, and processes each page with processPage.
+// progress is called after each page with the objects that processPage failed.
+// Listing starts after the last key of the previous page rather than with S3's continuation token,
+// since processing a page may delete its objects.
+func (s *Service) listPrefix(
+	ctx aws.Context,
+	bucket *string,
+	prefix *string,
+	continuationToken *string,
+	progress PrefixProgressFunc,
+	processPage func(objects []*s3.Object) ([]*PrefixFailure, error),
+) error {
+	startAfter, err := decodePrefixToken(aws.StringValue(continuationToken))
+	if err != nil {
+		return err
+	}
+
+	// The caller's bucket name is kept, since the items of a copy or move are normalized when they're processed.
+	bucket = aws.String(*bucket)
+	s.normalizeBucketName(bucket)
+
+	state := &PrefixProgress{}
+	for {
+		input := &s3.ListObjectsV2Input{
+			Bucket:  bucket,
+			Prefix:  prefix,
+			MaxKeys: aws.Int64(prefixPageSize),
+		}
+		if startAfter != "" {
+			input.StartAfter = aws.String(startAfter)
+		}
+
+		output, err := s.backend.ListObjectsV2(ctx, input)
+		if err != nil {
+			return wrapBucketError(err, "failed to list objects in bucket %s", *bucket)
+		}
+
+		state.Failures = nil
+		if len(output.Contents) > 0 {
+			failures, err := processPage(output.Contents)
+			if err != nil {
+				return err
+			}
+
+			state.Processed += int64(len(output.Contents))
+			state.Failed += int64(len(failures))
+			state.Failures = failures
+			startAfter = aws.StringValue(output.Contents[len(output.Contents)-1].Key)
+		}
+
+		state.Done = !aws.BoolValue(output.IsTruncated) || len(output.Contents) == 0
+		state.ContinuationToken = ""
+		if !state.Done {
+			state.ContinuationToken = encodePrefixToken(startAfter)
+		}
+
+		if progress != nil {
+			if err := progress(state); err != nil {
+				return err
+			}
+		}
+
+		if state.Done {
+			return nil
+		}
+	}
+}
+
+// encodePrefixToken returns the continuation token of a prefix operation that resumes after key.
+func encodePrefixToken(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodePrefixToken returns the key a prefix operation resumes after from its continuation token.
+func decodePrefixToken(token string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", invalidArgument("continuation token %q is invalid", token)
+	}
+
+	return string(key), nil
+}
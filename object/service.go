@@ -1,43 +1,127 @@
 package object
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"net/http"
+	"regexp"
+	"strings"
 	"sync"
-	"net/url"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/meateam/upload-service/bucket"
+	"github.com/meateam/upload-service/session"
+	"github.com/meateam/upload-service/storage"
+	"google.golang.org/grpc/codes"
 )
 
+const (
+	// maxListPageSize is the maximal number of objects and common prefixes listed in a page.
+	maxListPageSize = 1000
+
+	// defaultPresignExpiry is the time until a presigned URL expires if no expiry is given.
+	defaultPresignExpiry = 15 * time.Minute
+
+	// maxPresignExpiry is the maximal time until a presigned URL expires, which is
+	// the maximal expiry of an S3 signature.
+	maxPresignExpiry = 7 * 24 * time.Hour
+
+	// listHeadConcurrency is the maximal number of concurrent HeadObject requests
+	// made by ListObjects to fetch the content types of the listed objects.
+	listHeadConcurrency = 16
+
+	// maxDeleteKeys is the maximal number of keys S3 deletes in a single DeleteObjects request.
+	maxDeleteKeys = 1000
+
+	// deleteConcurrency is the maximal number of concurrent DeleteObjects requests
+	// made by DeleteObjects to delete more than maxDeleteKeys keys.
+	deleteConcurrency = 4
+)
+
+// ErrPresignNotSupported is returned by GeneratePresignedURL if the storage backend
+// can't generate presigned URLs.
+var ErrPresignNotSupported error = &Error{
+	Code:    codes.Unimplemented,
+	Reason:  ReasonNotSupported,
+	Message: "storage backend does not support presigned URLs",
+}
+
+// byteRangeRegexp matches a single HTTP byte range, e.g. "bytes=0-1023", "bytes=1024-" or "bytes=-1024".
+var byteRangeRegexp = regexp.MustCompile(`^bytes=(\d+-\d*|-\d+)$`)
+
 // Service is a structure used for operations on S3 objects.
 type Service struct {
-	s3Client *s3.S3
-	mu       sync.Mutex
+	backend       storage.Backend
+	mu            sync.Mutex
+	copyThreshold int64
+	copyPartSize  int64
+
+	// sessions are the sessions of the uploads initiated by the service,
+	// which expire after sessionTTL by default.
+	sessions   session.Store
+	sessionTTL time.Duration
+}
+
+// ObjectList is a page of objects listed by ListObjects.
+type ObjectList struct {
+	*s3.ListObjectsV2Output
+
+	// ContentTypes maps the key of each listed object to its content type.
+	ContentTypes map[string]string
+}
+
+// NewService creates a Service with the given storage backend and returns it.
+func NewService(backend storage.Backend) *Service {
+	return NewServiceWithMultipartCopy(backend, MaxCopyObjectSize, DefaultCopyPartSize)
 }
 
-// NewService creates a Service and returns it.
-func NewService(s3Client *s3.S3) *Service {
-	return &Service{s3Client: s3Client}
+// NewServiceWithMultipartCopy creates a Service with the given storage backend that copies
+// objects larger than threshold bytes in parts of partSize bytes, and returns it.
+// threshold is capped at MaxCopyObjectSize, and partSize is kept within the storage's part size limits.
+func NewServiceWithMultipartCopy(backend storage.Backend, threshold int64, partSize int64) *Service {
+	if threshold <= 0 || threshold > MaxCopyObjectSize {
+		threshold = MaxCopyObjectSize
+	}
+
+	if partSize < minCopyPartSize {
+		partSize = minCopyPartSize
+	}
+
+	if partSize > MaxCopyObjectSize {
+		partSize = MaxCopyObjectSize
+	}
+
+	return &Service{
+		backend:       backend,
+		copyThreshold: threshold,
+		copyPartSize:  partSize,
+		sessions:      session.NewMemoryStore(),
+		sessionTTL:    DefaultSessionTTL,
+	}
 }
 
-// GetS3Client returns the internal s3 client.
-func (s *Service) GetS3Client() *s3.S3 {
-	return s.s3Client
+// GetBackend returns the internal storage backend.
+func (s *Service) GetBackend() storage.Backend {
+	return s.backend
 }
 
 // ensureBucketExists Creates a bucket if it doesn't exist.
 func (s *Service) ensureBucketExists(ctx aws.Context, bucketName *string) error {
 	if ctx == nil {
-		return fmt.Errorf("context is required")
+		return invalidArgument("context is required")
 	}
 	if bucketName == nil {
-		return fmt.Errorf("bucketName is required")
+		return invalidArgument("bucketName is required")
 	}
 
-	bucketService := bucket.NewService(s.GetS3Client())
+	bucketService := bucket.NewService(s.backend)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	bucketExists := bucketService.BucketExists(ctx, bucketName)
@@ -45,11 +129,11 @@ func (s *Service) ensureBucketExists(ctx aws.Context, bucketName *string) error
 	if !bucketExists {
 		bucketExists, err := bucketService.CreateBucket(ctx, bucketName)
 		if err != nil {
-			return fmt.Errorf("failed to create bucket %s: %v", *bucketName, err)
+			return wrapError(err, "failed to create bucket %s", *bucketName)
 		}
 
 		if !bucketExists {
-			return fmt.Errorf("failed to create bucket %s: bucket does not exist", *bucketName)
+			return newError(codes.NotFound, ReasonBucketNotFound, "failed to create bucket %s: bucket does not exist", *bucketName)
 		}
 	}
 	*bucketName = bucketService.NormalizeCephBucketName(*bucketName)
@@ -57,8 +141,15 @@ func (s *Service) ensureBucketExists(ctx aws.Context, bucketName *string) error
 	return nil
 }
 
+// normalizeBucketName normalizes bucketName in place the same as ensureBucketExists,
+// without creating the bucket, for requests that only read from it.
+func (s *Service) normalizeBucketName(bucketName *string) {
+	*bucketName = bucket.NewService(s.backend).NormalizeCephBucketName(*bucketName)
+}
+
 // UploadFile uploads a file to the given bucket and key in S3.
-// If metadata is a non-nil map then it will be uploaded with the file.
+// If metadata is a non-nil map then it will be uploaded with the file,
+// metadata keys that begin with "Checksum-" are reserved for verified checksums.
 // Returns the file's location and an error if any occurred.
 func (s *Service) UploadFile(
 	ctx aws.Context,
@@ -67,33 +158,44 @@ func (s *Service) UploadFile(
 	bucket *string,
 	contentType *string,
 	metadata map[string]*string,
+) (*string, error) {
+	if err := validateMetadata(metadata); err != nil {
+		return nil, err
+	}
+
+	return s.uploadFile(ctx, file, key, bucket, contentType, metadata)
+}
+
+// uploadFile uploads a file the same as UploadFile, without rejecting reserved metadata keys.
+func (s *Service) uploadFile(
+	ctx aws.Context,
+	file io.Reader,
+	key *string,
+	bucket *string,
+	contentType *string,
+	metadata map[string]*string,
 ) (*string, error) {
 	if file == nil {
-		return nil, fmt.Errorf("file is required")
+		return nil, invalidArgument("file is required")
 	}
 
 	if key == nil || *key == "" {
-		return nil, fmt.Errorf("key is required")
+		return nil, invalidArgument("key is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		return nil, invalidArgument("bucket name is required")
 	}
 
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return nil, invalidArgument("context is required")
 	}
 
 	err := s.ensureBucketExists(ctx, bucket)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload file to %s/%s: %v", *bucket, *key, err)
+		return nil, wrapError(err, "failed to upload file to %s/%s", *bucket, *key)
 	}
 
-	// Create an uploader with S3 client and custom options
-	uploader := s3manager.NewUploaderWithClient(s.s3Client, func(u *s3manager.Uploader) {
-		u.PartSize = 32 * 1024 * 1024 // 32MB per part
-	})
-
 	input := &s3manager.UploadInput{
 		Bucket:      bucket,
 		Key:         key,
@@ -106,17 +208,94 @@ func (s *Service) UploadFile(
 	}
 
 	// Upload a new object with the file's data to the user's bucket
-	output, err := uploader.UploadWithContext(ctx, input)
+	output, err := s.backend.PutObject(ctx, input)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload data to %s/%s: %v", *bucket, *key, err)
+		return nil, wrapError(err, "failed to upload data to %s/%s", *bucket, *key)
 	}
 
 	return &output.Location, nil
 }
 
+// UploadFileWithChecksum uploads a file the same as UploadFile, and verifies the file's data
+// against checksum as it's uploaded. If checksum is nil then the file is uploaded without
+// verification, otherwise the verified checksum is stored in the file's metadata.
+// The object the file replaces is backed up until the checksum is verified. A file whose
+// checksum doesn't match is replaced by the object it replaced, or deleted if there was none,
+// and a DataLoss error is returned.
+func (s *Service) UploadFileWithChecksum(
+	ctx aws.Context,
+	file io.Reader,
+	key *string,
+	bucket *string,
+	contentType *string,
+	metadata map[string]*string,
+	checksum *Checksum,
+) (*string, error) {
+	if checksum == nil || file == nil {
+		return s.UploadFile(ctx, file, key, bucket, contentType, metadata)
+	}
+
+	if err := checksum.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validateMetadata(metadata); err != nil {
+		return nil, err
+	}
+
+	if key == nil || *key == "" {
+		return nil, invalidArgument("key is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return nil, invalidArgument("bucket name is required")
+	}
+
+	if err := s.ensureBucketExists(ctx, bucket); err != nil {
+		return nil, wrapError(err, "failed to upload file to %s/%s", *bucket, *key)
+	}
+
+	uploadID, err := newBackupID()
+	if err != nil {
+		return nil, wrapError(err, "failed to upload file to %s/%s", *bucket, *key)
+	}
+
+	backupKey, err := s.backupObject(ctx, key, bucket, aws.String(uploadID))
+	if err != nil {
+		return nil, err
+	}
+
+	checksumMetadata := make(map[string]*string, len(metadata)+1)
+	for k, v := range metadata {
+		checksumMetadata[k] = v
+	}
+	checksumMetadata[checksum.metadataKey()] = aws.String(strings.ToLower(checksum.Value))
+
+	hash := checksum.newHash()
+	location, err := s.uploadFile(ctx, io.TeeReader(file, hash), key, bucket, contentType, checksumMetadata)
+	if err != nil {
+		s.deleteBackup(ctx, backupKey, bucket)
+		return nil, err
+	}
+
+	verifyErr := checksum.verify(hash, fmt.Sprintf("file %s/%s", *bucket, *key))
+	if verifyErr == nil {
+		s.deleteBackup(ctx, backupKey, bucket)
+		return location, nil
+	}
+
+	if err := s.restoreBackup(ctx, key, bucket, backupKey); err != nil {
+		return nil, err
+	}
+
+	return nil, verifyErr
+}
+
 // UploadInit initiates a multipart upload to the given bucket and key in S3 with metadata.
-// File metadata is required for multipart upload.
+// File metadata is required for multipart upload, metadata keys that begin with "Checksum-"
+// are reserved for verified checksums.
+// The upload's session has no expectations and expires after the service's session TTL.
 func (s *Service) UploadInit(
 	ctx aws.Context,
 	key *string,
@@ -124,21 +303,49 @@ func (s *Service) UploadInit(
 	contentType *string,
 	metadata map[string]*string,
 ) (*s3.CreateMultipartUploadOutput, error) {
+	result, _, err := s.UploadInitWithOptions(ctx, key, bucket, contentType, metadata, nil)
+
+	return result, err
+}
+
+// UploadInitWithOptions initiates a multipart upload the same as UploadInit, and starts its session
+// with options, which is returned along with the upload. If options is nil then the upload has
+// no expectations and expires after the service's session TTL.
+func (s *Service) UploadInitWithOptions(
+	ctx aws.Context,
+	key *string,
+	bucket *string,
+	contentType *string,
+	metadata map[string]*string,
+	options *UploadOptions,
+) (*s3.CreateMultipartUploadOutput, *session.Session, error) {
 	if key == nil || *key == "" {
-		return nil, fmt.Errorf("key is required")
+		return nil, nil, invalidArgument("key is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		return nil, nil, invalidArgument("bucket name is required")
 	}
 
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return nil, nil, invalidArgument("context is required")
+	}
+
+	if options == nil {
+		options = &UploadOptions{}
+	}
+
+	if err := options.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := validateMetadata(metadata); err != nil {
+		return nil, nil, err
 	}
 
 	err := s.ensureBucketExists(ctx, bucket)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init upload to %s/%s: %v", *bucket, *key, err)
+		return nil, nil, wrapError(err, "failed to init upload to %s/%s", *bucket, *key)
 	}
 
 	input := &s3.CreateMultipartUploadInput{
@@ -148,15 +355,47 @@ func (s *Service) UploadInit(
 		ContentType: contentType,
 	}
 
-	result, err := s.s3Client.CreateMultipartUploadWithContext(ctx, input)
+	result, err := s.backend.CreateMultipartUpload(ctx, input)
 	if err != nil {
-		return nil, err
+		return nil, nil, wrapError(err, "failed to init upload to %s/%s", *bucket, *key)
 	}
 
-	return result, err
+	ttl := options.TTL
+	if ttl == 0 {
+		ttl = s.sessionTTL
+	}
+
+	now := time.Now()
+	uploadSession := &session.Session{
+		UploadID:    aws.StringValue(result.UploadId),
+		Key:         *key,
+		Bucket:      *bucket,
+		Owner:       options.Owner,
+		ContentType: aws.StringValue(contentType),
+		Metadata:    aws.StringValueMap(metadata),
+		Size:        options.Size,
+		PartCount:   options.PartCount,
+		Checksum:    (*session.Checksum)(options.Checksum),
+		Created:     now,
+		Expires:     now.Add(ttl),
+	}
+
+	if err := s.sessions.Put(uploadSession); err != nil {
+		// An upload without a session would never expire, so it's aborted.
+		if _, abortErr := s.UploadAbort(ctx, result.UploadId, key, bucket); abortErr != nil {
+			return nil, nil, sessionError(err, "failed to start session of upload %s, and to abort it: %v", *result.UploadId, abortErr)
+		}
+
+		return nil, nil, sessionError(err, "failed to start session of upload %s", *result.UploadId)
+	}
+
+	return result, uploadSession, nil
 }
 
 // UploadPart uploads a part in a multipart upload of a file.
+// If the upload has a session, the part is checked against it before it's uploaded
+// and recorded in it after it's uploaded. Parts of an expired upload fail with
+// FailedPrecondition, and so do parts that exceed the upload's part count or size.
 func (s *Service) UploadPart(
 	ctx aws.Context,
 	uploadID *string,
@@ -164,38 +403,56 @@ func (s *Service) UploadPart(
 	bucket *string,
 	partNumber *int64,
 	body io.ReadSeeker,
+) (*s3.UploadPartOutput, error) {
+	return s.uploadPart(ctx, uploadID, key, bucket, partNumber, body, nil)
+}
+
+// uploadPart uploads a part and records it in the upload's session with its verified checksum,
+// which is nil if the part wasn't verified.
+func (s *Service) uploadPart(
+	ctx aws.Context,
+	uploadID *string,
+	key *string,
+	bucket *string,
+	partNumber *int64,
+	body io.ReadSeeker,
+	checksum *Checksum,
 ) (*s3.UploadPartOutput, error) {
 	if body == nil {
-		return nil, fmt.Errorf("part body is required")
+		return nil, invalidArgument("part body is required")
 	}
 
 	if key == nil || *key == "" {
-		return nil, fmt.Errorf("key is required")
+		return nil, invalidArgument("key is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		return nil, invalidArgument("bucket name is required")
 	}
 
 	if uploadID == nil || *uploadID == "" {
-		return nil, fmt.Errorf("upload id is required")
+		return nil, invalidArgument("upload id is required")
 	}
 
 	if partNumber == nil {
-		return nil, fmt.Errorf("part number is required")
+		return nil, invalidArgument("part number is required")
 	}
 
 	if *partNumber < 1 || *partNumber > 10000 {
-		return nil, fmt.Errorf("part number must be between 1 and 10,000")
+		return nil, invalidArgument("part number must be between 1 and 10,000")
 	}
 
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return nil, invalidArgument("context is required")
+	}
+
+	if err := s.checkPart(*uploadID, *partNumber, body); err != nil {
+		return nil, err
 	}
 
 	err := s.ensureBucketExists(ctx, bucket)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload part to %s/%s: %v", *bucket, *key, err)
+		return nil, wrapError(err, "failed to upload part to %s/%s", *bucket, *key)
 	}
 
 	input := &s3.UploadPartInput{
@@ -206,15 +463,59 @@ func (s *Service) UploadPart(
 		UploadId:   uploadID,
 	}
 
-	result, err := s.s3Client.UploadPartWithContext(ctx, input)
+	result, err := s.backend.UploadPart(ctx, input)
+	if err != nil {
+		return nil, wrapError(err, "failed to upload part %d to %s/%s", *partNumber, *bucket, *key)
+	}
+
+	size, err := partSize(body)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.recordPart(*uploadID, *partNumber, size, aws.StringValue(result.ETag), checksum); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
+// UploadPartWithChecksum uploads a part the same as UploadPart, after verifying the part's
+// data against checksum. If checksum is nil then the part is uploaded without verification.
+// A part whose checksum doesn't match isn't uploaded, and a DataLoss error is returned.
+func (s *Service) UploadPartWithChecksum(
+	ctx aws.Context,
+	uploadID *string,
+	key *string,
+	bucket *string,
+	partNumber *int64,
+	body io.ReadSeeker,
+	checksum *Checksum,
+) (*s3.UploadPartOutput, error) {
+	if checksum != nil && body != nil {
+		if err := checksum.validate(); err != nil {
+			return nil, err
+		}
+
+		hash := checksum.newHash()
+		if _, err := io.Copy(hash, body); err != nil {
+			return nil, wrapError(err, "failed reading part")
+		}
+
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, wrapError(err, "failed reading part")
+		}
+
+		if err := checksum.verify(hash, fmt.Sprintf("part %d", aws.Int64Value(partNumber))); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.uploadPart(ctx, uploadID, key, bucket, partNumber, body, checksum)
+}
+
 // ListUploadParts lists the uploaded file parts of a multipart upload of a file.
+// A missing bucket fails with NotFound, it isn't created.
 func (s *Service) ListUploadParts(
 	ctx aws.Context,
 	uploadID *string,
@@ -222,25 +523,22 @@ func (s *Service) ListUploadParts(
 	bucket *string,
 ) (*s3.ListPartsOutput, error) {
 	if key == nil || *key == "" {
-		return nil, fmt.Errorf("key is required")
+		return nil, invalidArgument("key is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		return nil, invalidArgument("bucket name is required")
 	}
 
 	if uploadID == nil || *uploadID == "" {
-		return nil, fmt.Errorf("upload id is required")
+		return nil, invalidArgument("upload id is required")
 	}
 
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return nil, invalidArgument("context is required")
 	}
 
-	err := s.ensureBucketExists(ctx, bucket)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list upload %s parts at %s/%s: %v", *uploadID, *bucket, *key, err)
-	}
+	s.normalizeBucketName(bucket)
 
 	listPartsInput := &s3.ListPartsInput{
 		UploadId: uploadID,
@@ -249,57 +547,124 @@ func (s *Service) ListUploadParts(
 		MaxParts: aws.Int64(10000),
 	}
 
-	parts, err := s.s3Client.ListPartsWithContext(ctx, listPartsInput)
-	if err != nil {
-		return nil, err
+	// The storage may return fewer parts than requested, list the rest of them page by page.
+	var parts *s3.ListPartsOutput
+	for {
+		page, err := s.backend.ListParts(ctx, listPartsInput)
+		if err != nil {
+			return nil, wrapError(err, "failed to list upload %s parts at %s/%s", *uploadID, *bucket, *key)
+		}
+
+		if parts == nil {
+			parts = page
+		} else {
+			parts.Parts = append(parts.Parts, page.Parts...)
+		}
+
+		if !aws.BoolValue(page.IsTruncated) {
+			break
+		}
+
+		listPartsInput.PartNumberMarker = page.NextPartNumberMarker
 	}
 
+	parts.IsTruncated = aws.Bool(false)
+	parts.NextPartNumberMarker = nil
+
 	return parts, nil
 }
 
 // UploadComplete completes a multipart upload by assembling previously uploaded parts
 // associated with uploadID.
+// If parts is empty then all of the uploaded parts are assembled, otherwise only the given parts
+// are assembled, and each of them must have been uploaded with the given ETag.
+// If size is non-nil then the total size of the assembled parts must equal it.
+// If checksum is a non-empty string then the hex encoded SHA-256 of the assembled object
+// must equal it. If the storage can read the uploaded parts then it's verified before they're
+// assembled, and a mismatching upload is kept so it can be fixed and completed again.
+// Otherwise it's verified after the object is assembled, and a mismatching object is deleted
+// and the object it replaced, if any, is restored.
+// Mismatches are returned as an *Error with a Violation for each of them.
 func (s *Service) UploadComplete(
 	ctx aws.Context,
 	uploadID *string,
 	key *string,
 	bucket *string,
+	parts []*s3.CompletedPart,
+	size *int64,
+	checksum *string,
 ) (*s3.CompleteMultipartUploadOutput, error) {
 	if key == nil || *key == "" {
-		return nil, fmt.Errorf("key is required")
+		return nil, invalidArgument("key is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		return nil, invalidArgument("bucket name is required")
 	}
 
 	if uploadID == nil || *uploadID == "" {
-		return nil, fmt.Errorf("upload id is required")
+		return nil, invalidArgument("upload id is required")
 	}
 
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return nil, invalidArgument("context is required")
 	}
 
-	err := s.ensureBucketExists(ctx, bucket)
+	if err := validateCompletedParts(parts); err != nil {
+		return nil, err
+	}
+
+	if size != nil && *size < 0 {
+		return nil, invalidArgument("size must not be negative")
+	}
+
+	var fileChecksum *Checksum
+	if checksum != nil && *checksum != "" {
+		fileChecksum = &Checksum{Algorithm: ChecksumSHA256, Value: *checksum}
+		if err := fileChecksum.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The upload is checked against its session, whose size and checksum are verified
+	// if the request has none.
+	uploadSession, err := s.getSession(*uploadID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload complete %s parts at %s/%s: %v", *uploadID, *bucket, *key, err)
+		return nil, err
 	}
 
-	parts, err := s.ListUploadParts(ctx, uploadID, key, bucket)
+	var sessionViolations []Violation
+	if uploadSession != nil {
+		if err := checkExpiry(uploadSession); err != nil {
+			return nil, err
+		}
+
+		size, fileChecksum, sessionViolations = sessionExpectations(uploadSession, size, fileChecksum)
+	}
+
+	err = s.ensureBucketExists(ctx, bucket)
 	if err != nil {
-		return nil, fmt.Errorf("failed listing upload parts")
+		return nil, wrapError(err, "failed to upload complete %s parts at %s/%s", *uploadID, *bucket, *key)
 	}
 
-	completedMultipartParts := make([]*s3.CompletedPart, 0, len(parts.Parts))
+	uploadedParts, err := s.ListUploadParts(ctx, uploadID, key, bucket)
+	if err != nil {
+		return nil, wrapError(err, "failed listing upload parts")
+	}
 
-	for _, v := range parts.Parts {
-		completedPart := &s3.CompletedPart{
-			ETag:       v.ETag,
-			PartNumber: v.PartNumber,
-		}
+	completedMultipartParts, violations := matchUploadParts(parts, uploadedParts.Parts, size)
+	if uploadSession != nil {
+		violations = append(sessionViolations, violations...)
+		violations = append(violations, partCountViolations(uploadSession, completedMultipartParts)...)
+	}
 
-		completedMultipartParts = append(completedMultipartParts, completedPart)
+	if len(violations) > 0 {
+		return nil, &Error{
+			Code:       codes.FailedPrecondition,
+			Reason:     ReasonUploadMismatch,
+			Message:    fmt.Sprintf("upload %s of %s/%s doesn't match: %s", *uploadID, *bucket, *key, describeViolations(violations)),
+			Violations: violations,
+		}
 	}
 
 	completedMultipartUpload := &s3.CompletedMultipartUpload{
@@ -313,36 +678,361 @@ func (s *Service) UploadComplete(
 		UploadId:        uploadID,
 	}
 
-	result, err := s.s3Client.CompleteMultipartUploadWithContext(ctx, input)
+	partReader, canReadParts := s.backend.(storage.PartReader)
+	if fileChecksum != nil && canReadParts {
+		if err := verifyPartsChecksum(ctx, partReader, uploadID, key, bucket, completedMultipartParts, fileChecksum); err != nil {
+			return nil, err
+		}
+	}
+
+	// S3 can't read the parts before they're assembled, so the object that's replaced
+	// is backed up until the assembled object's checksum is verified.
+	var backupKey *string
+	if fileChecksum != nil && !canReadParts {
+		backupKey, err = s.backupObject(ctx, key, bucket, uploadID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.backend.CompleteMultipartUpload(ctx, input)
 	if err != nil {
-		return nil, err
+		s.deleteBackup(ctx, backupKey, bucket)
+		return nil, wrapError(err, "failed to upload complete %s parts at %s/%s", *uploadID, *bucket, *key)
+	}
+
+	s.deleteSession(*uploadID)
+
+	if fileChecksum != nil && !canReadParts {
+		if err := s.verifyChecksum(ctx, key, bucket, fileChecksum, backupKey); err != nil {
+			return nil, err
+		}
 	}
 
 	return result, nil
 }
 
+// validateCompletedParts returns an error if parts aren't in ascending part number order,
+// or if any of them has an invalid part number or no ETag.
+func validateCompletedParts(parts []*s3.CompletedPart) error {
+	var previous int64
+	for _, part := range parts {
+		if part == nil {
+			return invalidArgument("parts must not be nil")
+		}
+
+		partNumber := aws.Int64Value(part.PartNumber)
+		if partNumber < 1 || partNumber > 10000 {
+			return invalidArgument("part number must be between 1 and 10,000")
+		}
+
+		if partNumber <= previous {
+			return invalidArgument("parts must be in ascending part number order without duplicates")
+		}
+
+		if aws.StringValue(part.ETag) == "" {
+			return invalidArgument("part %d ETag is required", partNumber)
+		}
+
+		previous = partNumber
+	}
+
+	return nil
+}
+
+// matchUploadParts returns the parts to complete an upload with, out of its uploaded parts.
+// If expected is empty then all of the uploaded parts are returned, otherwise the expected parts
+// are returned, and a violation is returned for each of them that's missing or has another ETag.
+// If size is non-nil, a violation is returned if the returned parts' total size doesn't equal it.
+func matchUploadParts(expected []*s3.CompletedPart, uploaded []*s3.Part, size *int64) ([]*s3.CompletedPart, []Violation) {
+	uploadedByNumber := make(map[int64]*s3.Part, len(uploaded))
+	for _, part := range uploaded {
+		uploadedByNumber[aws.Int64Value(part.PartNumber)] = part
+	}
+
+	if len(expected) == 0 {
+		expected = make([]*s3.CompletedPart, 0, len(uploaded))
+		for _, part := range uploaded {
+			expected = append(expected, &s3.CompletedPart{ETag: part.ETag, PartNumber: part.PartNumber})
+		}
+	}
+
+	var violations []Violation
+	var totalSize int64
+	completed := make([]*s3.CompletedPart, 0, len(expected))
+	for _, part := range expected {
+		partNumber := aws.Int64Value(part.PartNumber)
+		subject := fmt.Sprintf("part %d", partNumber)
+
+		uploadedPart, ok := uploadedByNumber[partNumber]
+		if !ok {
+			violations = append(violations, Violation{
+				Type:        "PART_MISSING",
+				Subject:     subject,
+				Description: fmt.Sprintf("part %d was not uploaded", partNumber),
+			})
+
+			continue
+		}
+
+		if trimETag(uploadedPart.ETag) != trimETag(part.ETag) {
+			violations = append(violations, Violation{
+				Type:    "PART_ETAG_MISMATCH",
+				Subject: subject,
+				Description: fmt.Sprintf(
+					"part %d was uploaded with ETag %s, expected %s",
+					partNumber,
+					aws.StringValue(uploadedPart.ETag),
+					aws.StringValue(part.ETag),
+				),
+			})
+
+			continue
+		}
+
+		totalSize += aws.Int64Value(uploadedPart.Size)
+		completed = append(completed, &s3.CompletedPart{ETag: uploadedPart.ETag, PartNumber: uploadedPart.PartNumber})
+	}
+
+	// The total size is meaningless if some of the parts are missing.
+	if size != nil && len(violations) == 0 && totalSize != *size {
+		violations = append(violations, Violation{
+			Type:        "SIZE_MISMATCH",
+			Subject:     "size",
+			Description: fmt.Sprintf("parts total %d bytes, expected %d", totalSize, *size),
+		})
+	}
+
+	return completed, violations
+}
+
+// verifyPartsChecksum verifies that the checksum of the given uploaded parts of an upload,
+// read in order from the storage, matches checksum.
+func verifyPartsChecksum(
+	ctx aws.Context,
+	partReader storage.PartReader,
+	uploadID *string,
+	key *string,
+	bucket *string,
+	parts []*s3.CompletedPart,
+	checksum *Checksum,
+) error {
+	hash := checksum.newHash()
+	for _, part := range parts {
+		body, err := partReader.GetUploadPart(ctx, bucket, key, uploadID, part.PartNumber)
+		if err != nil {
+			return wrapError(err, "failed to verify checksum of upload %s of %s/%s", *uploadID, *bucket, *key)
+		}
+
+		_, err = io.Copy(hash, body)
+		body.Close()
+		if err != nil {
+			return wrapError(err, "failed to verify checksum of upload %s of %s/%s", *uploadID, *bucket, *key)
+		}
+	}
+
+	return checksum.verify(hash, fmt.Sprintf("upload %s of %s/%s", *uploadID, *bucket, *key))
+}
+
+// verifyChecksum verifies that the checksum of an object matches checksum. If it doesn't,
+// the object is replaced by its backup at backupKey, or deleted if backupKey is nil.
+// The backup is deleted either way.
+func (s *Service) verifyChecksum(ctx aws.Context, key *string, bucket *string, checksum *Checksum, backupKey *string) error {
+	obj, err := s.backend.GetObject(ctx, &s3.GetObjectInput{Bucket: bucket, Key: key})
+	if err != nil {
+		s.deleteBackup(ctx, backupKey, bucket)
+		return wrapError(err, "failed to verify checksum of %s/%s", *bucket, *key)
+	}
+	defer obj.Body.Close()
+
+	hash := checksum.newHash()
+	if _, err := io.Copy(hash, obj.Body); err != nil {
+		s.deleteBackup(ctx, backupKey, bucket)
+		return wrapError(err, "failed to verify checksum of %s/%s", *bucket, *key)
+	}
+
+	verifyErr := checksum.verify(hash, fmt.Sprintf("object %s/%s", *bucket, *key))
+	if verifyErr == nil {
+		s.deleteBackup(ctx, backupKey, bucket)
+		return nil
+	}
+
+	if err := s.restoreBackup(ctx, key, bucket, backupKey); err != nil {
+		return err
+	}
+
+	return verifyErr
+}
+
+// restoreBackup replaces the object at key, whose checksum didn't match, by its backup at
+// backupKey and deletes the backup. The object is deleted if backupKey is nil.
+func (s *Service) restoreBackup(ctx aws.Context, key *string, bucket *string, backupKey *string) error {
+	if backupKey == nil {
+		if err := s.deleteObject(ctx, key, bucket); err != nil {
+			return wrapError(err, "failed to delete %s/%s after its checksum didn't match", *bucket, *key)
+		}
+
+		return nil
+	}
+
+	backup, err := s.backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: bucket, Key: backupKey})
+	if err == nil {
+		_, err = s.copyVerified(ctx, backup, bucket, bucket, backupKey, key)
+	}
+
+	if err != nil {
+		return wrapError(err, "failed to restore %s/%s from %s after its checksum didn't match", *bucket, *key, *backupKey)
+	}
+
+	s.deleteBackup(ctx, backupKey, bucket)
+
+	return nil
+}
+
+// newBackupID returns a new random ID for the backup of an object replaced by a file upload,
+// which isn't a multipart upload and has no upload ID of its own.
+func newBackupID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+// backupObject copies the object at key, which is about to be replaced by the upload with
+// the given ID, to a backup key next to it and returns the backup key. Returns nil if there's
+// no object at key.
+func (s *Service) backupObject(ctx aws.Context, key *string, bucket *string, uploadID *string) (*string, error) {
+	existing, err := s.backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: bucket, Key: key})
+	if err != nil {
+		wrapped := wrapError(err, "failed to back up %s/%s", *bucket, *key)
+		if svcErr, ok := wrapped.(*Error); ok && svcErr.Code == codes.NotFound {
+			return nil, nil
+		}
+
+		return nil, wrapped
+	}
+
+	backupKey := aws.String(fmt.Sprintf("%s.%s.backup", *key, *uploadID))
+	if _, err := s.copyVerified(ctx, existing, bucket, bucket, key, backupKey); err != nil {
+		s.deleteBackup(ctx, backupKey, bucket)
+		return nil, wrapError(err, "failed to back up %s/%s", *bucket, *key)
+	}
+
+	return backupKey, nil
+}
+
+// deleteBackup deletes the backup of an object at backupKey, if it isn't nil.
+// Its error is ignored, since the backup is no longer needed.
+func (s *Service) deleteBackup(ctx aws.Context, backupKey *string, bucket *string) {
+	if backupKey != nil {
+		_ = s.deleteObject(ctx, backupKey, bucket)
+	}
+}
+
+// deleteObject deletes a single object.
+func (s *Service) deleteObject(ctx aws.Context, key *string, bucket *string) error {
+	deleteInput := &s3.DeleteObjectsInput{
+		Bucket: bucket,
+		Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: key}}},
+	}
+
+	output, err := s.backend.DeleteObjects(ctx, deleteInput)
+	if err != nil {
+		return err
+	}
+
+	if len(output.Errors) > 0 {
+		return deleteKeyError(output.Errors[0].Code, output.Errors[0].Message)
+	}
+
+	return nil
+}
+
+// trimETag returns an ETag without its surrounding quotes.
+func trimETag(eTag *string) string {
+	return strings.Trim(aws.StringValue(eTag), `"`)
+}
+
+// describeViolations returns the descriptions of violations joined into a single message.
+func describeViolations(violations []Violation) string {
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		descriptions = append(descriptions, violation.Description)
+	}
+
+	return strings.Join(descriptions, "; ")
+}
+
 // HeadObject returns object's details.
+// The returned error's code is codes.NotFound if the object or its bucket doesn't exist,
+// and it wraps the storage backend's error.
 func (s *Service) HeadObject(ctx aws.Context, key *string, bucket *string) (*s3.HeadObjectOutput, error) {
 	if key == nil || *key == "" {
-		return nil, fmt.Errorf("key is required")
+		return nil, invalidArgument("key is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		return nil, invalidArgument("bucket name is required")
 	}
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return nil, invalidArgument("context is required")
 	}
 
-	err := s.ensureBucketExists(ctx, bucket)
+	// Reading an object doesn't create its bucket, a missing bucket fails with NotFound.
+	s.normalizeBucketName(bucket)
+
+	obj, err := s.backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: bucket, Key: key})
 	if err != nil {
-		return nil, fmt.Errorf("failed to HeadObject %s/%s: %v", *bucket, *key, err)
+		return nil, wrapError(err, "failed to head object %s/%s", *bucket, *key)
+	}
+	return obj, nil
+}
+
+// GetObject returns an object's details and a reader of its content.
+// If byteRange is a non-empty string then only the given range of the object is returned,
+// byteRange should be in the HTTP Range header format, for example "bytes=0-1023".
+// The caller is responsible for closing the returned object's body.
+func (s *Service) GetObject(
+	ctx aws.Context,
+	key *string,
+	bucket *string,
+	byteRange *string,
+) (*s3.GetObjectOutput, error) {
+	if key == nil || *key == "" {
+		return nil, invalidArgument("key is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return nil, invalidArgument("bucket name is required")
+	}
+
+	if ctx == nil {
+		return nil, invalidArgument("context is required")
+	}
+
+	if byteRange != nil && *byteRange != "" && !byteRangeRegexp.MatchString(*byteRange) {
+		return nil, invalidArgument("range %s is invalid, expected format bytes=<start>-<end>", *byteRange)
+	}
+
+	// Reading an object doesn't create its bucket, a missing bucket fails with NotFound.
+	s.normalizeBucketName(bucket)
+
+	input := &s3.GetObjectInput{
+		Bucket: bucket,
+		Key:    key,
 	}
 
-	obj, err := s.s3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: bucket, Key: key})
+	if byteRange != nil && *byteRange != "" {
+		input.Range = byteRange
+	}
+
+	obj, err := s.backend.GetObject(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to head object")
+		return nil, wrapError(err, "failed to get object %s/%s", *bucket, *key)
 	}
+
 	return obj, nil
 }
 
@@ -355,24 +1045,24 @@ func (s *Service) HeadObject(ctx aws.Context, key *string, bucket *string) (*s3.
 // the List Parts operation and ensure the parts list is empty.
 func (s *Service) UploadAbort(ctx aws.Context, uploadID *string, key *string, bucket *string) (bool, error) {
 	if key == nil || *key == "" {
-		return false, fmt.Errorf("key is required")
+		return false, invalidArgument("key is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return false, fmt.Errorf("bucket name is required")
+		return false, invalidArgument("bucket name is required")
 	}
 
 	if uploadID == nil || *uploadID == "" {
-		return false, fmt.Errorf("upload id is required")
+		return false, invalidArgument("upload id is required")
 	}
 
 	if ctx == nil {
-		return false, fmt.Errorf("context is required")
+		return false, invalidArgument("context is required")
 	}
 
 	err := s.ensureBucketExists(ctx, bucket)
 	if err != nil {
-		return false, fmt.Errorf("failed to list upload %s parts at %s/%s: %v", *uploadID, *bucket, *key, err)
+		return false, wrapError(err, "failed to list upload %s parts at %s/%s", *uploadID, *bucket, *key)
 	}
 
 	abortInput := &s3.AbortMultipartUploadInput{
@@ -381,35 +1071,119 @@ func (s *Service) UploadAbort(ctx aws.Context, uploadID *string, key *string, bu
 		UploadId: uploadID,
 	}
 
-	_, err = s.s3Client.AbortMultipartUploadWithContext(ctx, abortInput)
+	_, err = s.backend.AbortMultipartUpload(ctx, abortInput)
 	if err != nil {
-		return false, fmt.Errorf("failed aborting multipart upload: %v", err)
+		// The session of an upload that no longer exists is of no use.
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchUpload {
+			s.deleteSession(*uploadID)
+		}
+
+		return false, wrapError(err, "failed aborting multipart upload")
 	}
 
+	s.deleteSession(*uploadID)
+
 	return true, nil
 }
 
 // DeleteObjects repeated string  deletes an object from s3,
 // It receives a bucket and a slice of *strings to be deleted
 // and returns the deleted and errored objects or an error if exists.
+// The keys are deleted in chunks of up to 1000 keys, the most S3 deletes in a request,
+// up to deleteConcurrency chunks concurrently. The keys of a chunk whose request failed are
+// returned as errored objects with the request's error, unless every chunk failed,
+// in which case the first chunk's error is returned.
 func (s *Service) DeleteObjects(ctx aws.Context, bucket *string, keys []*string) (*s3.DeleteObjectsOutput, error) {
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return nil, invalidArgument("context is required")
 	}
 
 	if bucket == nil || *bucket == "" {
-		return nil, fmt.Errorf("bucket name is required")
+		return nil, invalidArgument("bucket name is required")
 	}
 
 	if keys == nil || len(keys) <= 0 {
-		return nil, fmt.Errorf("keys are required")
+		return nil, invalidArgument("keys are required")
+	}
+
+	for _, key := range keys {
+		if key == nil || *key == "" {
+			return nil, invalidArgument("keys must not be empty")
+		}
 	}
 
 	err := s.ensureBucketExists(ctx, bucket)
 	if err != nil {
-		return nil, fmt.Errorf("failed to DeleteObjects bucket, %s, does not exist: %v", *bucket, err)
+		return nil, wrapError(err, "failed to DeleteObjects bucket, %s, does not exist", *bucket)
+	}
+
+	var chunks [][]*string
+	for start := 0; start < len(keys); start += maxDeleteKeys {
+		end := start + maxDeleteKeys
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		chunks = append(chunks, keys[start:end])
+	}
+
+	outputs := make([]*s3.DeleteObjectsOutput, len(chunks))
+	errs := make([]error, len(chunks))
+	sem := make(chan struct{}, deleteConcurrency)
+	wg := sync.WaitGroup{}
+
+	for chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(chunk int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			outputs[chunk], errs[chunk] = s.deleteObjectsChunk(ctx, bucket, chunks[chunk])
+		}(chunk)
+	}
+
+	wg.Wait()
+
+	deleteResponse := &s3.DeleteObjectsOutput{}
+	failedChunks := 0
+	for chunk, output := range outputs {
+		if errs[chunk] == nil {
+			deleteResponse.Deleted = append(deleteResponse.Deleted, output.Deleted...)
+			deleteResponse.Errors = append(deleteResponse.Errors, output.Errors...)
+			continue
+		}
+
+		failedChunks++
+		code, _, metadata := classifyError(errs[chunk])
+		storageCode := metadata[errorInfoStorageCode]
+		if storageCode == "" {
+			storageCode = code.String()
+		}
+
+		message := errs[chunk].Error()
+		if awsErr, ok := errs[chunk].(awserr.Error); ok {
+			message = awsErr.Message()
+		}
+
+		for _, key := range chunks[chunk] {
+			deleteResponse.Errors = append(deleteResponse.Errors, &s3.Error{
+				Key:     key,
+				Code:    aws.String(storageCode),
+				Message: aws.String(message),
+			})
+		}
+	}
+
+	if failedChunks == len(chunks) {
+		return nil, wrapError(errs[0], "failed to delete objects")
 	}
 
+	return deleteResponse, nil
+}
+
+// deleteObjectsChunk deletes a chunk of up to maxDeleteKeys keys from bucket.
+func (s *Service) deleteObjectsChunk(ctx aws.Context, bucket *string, keys []*string) (*s3.DeleteObjectsOutput, error) {
 	objects := make([]*s3.ObjectIdentifier, 0, len(keys))
 
 	for _, key := range keys {
@@ -426,88 +1200,300 @@ func (s *Service) DeleteObjects(ctx aws.Context, bucket *string, keys []*string)
 		},
 	}
 
-	deleteResponse, err := s.s3Client.DeleteObjectsWithContext(ctx, deleteObjectsInput)
-	if err != nil {
-		return nil, fmt.Errorf("failed to delete objects: %v", err)
-	}
-	return deleteResponse, nil
+	return s.backend.DeleteObjects(ctx, deleteObjectsInput)
 }
 
-
 // CopyObject - copy an object between source and destination buckets
-// It receives a source bucket, object key and a destination bucket 
+// It receives a source bucket, object key and a destination bucket
+// Objects larger than the service's copy threshold are copied in parts concurrently.
+// The copy is verified by its size and checksum. A copy that doesn't match the source is
+// deleted, and the object it overwrote, if any, is restored from a backup.
 func (s *Service) CopyObject(
 	ctx aws.Context,
-	bucketSrc *string, 
-	bucketDest *string, 
-	keySrc *string,  
+	bucketSrc *string,
+	bucketDest *string,
+	keySrc *string,
+	keyDest *string,
+) (*string, error) {
+	if _, err := s.CopyObjectWithVerification(ctx, bucketSrc, bucketDest, keySrc, keyDest); err != nil {
+		return nil, err
+	}
+
+	return keySrc, nil
+}
+
+// CopyObjectWithVerification copies an object the same as CopyObject, and returns how the copy
+// was verified. The copy's size is always verified. Its content is verified by the checksum
+// stored in the source's metadata, or by its md5 sum if neither the source nor the copy was
+// assembled from parts. Otherwise only its size is verified, which is CopyVerifiedSize.
+func (s *Service) CopyObjectWithVerification(
+	ctx aws.Context,
+	bucketSrc *string,
+	bucketDest *string,
+	keySrc *string,
 	keyDest *string,
-	) (*string, error) {
+) (CopyVerification, error) {
 	if ctx == nil {
-		return nil, fmt.Errorf("context is required")
+		return "", invalidArgument("context is required")
 	}
 
 	if bucketSrc == nil || *bucketSrc == "" {
-		return nil, fmt.Errorf("source bucket name is required")
+		return "", invalidArgument("source bucket name is required")
 	}
 
 	if bucketDest == nil || *bucketDest == "" {
-		return nil, fmt.Errorf("destination bucket name is required")
+		return "", invalidArgument("destination bucket name is required")
 	}
 
 	if keySrc == nil || *keySrc == "" {
-		return nil, fmt.Errorf("object's src key is required")
+		return "", invalidArgument("object's src key is required")
 	}
 
 	if keyDest == nil || *keyDest == "" {
-		return nil, fmt.Errorf("object's dest key is required")
+		return "", invalidArgument("object's dest key is required")
 	}
 
-	
-	// Check if the source bucket exists 
+	// Check if the source bucket exists
 	// if it doesn't exist, we don't need to create a new bucket,
 	// because it would be empty with no object to copy
 	headBucketinput := &s3.HeadBucketInput{Bucket: bucketSrc}
 
-	if _, err := s.s3Client.HeadBucket(headBucketinput); err != nil {
-		return nil, fmt.Errorf("failed to CopyObject from bucket, %s, does not exist: %v", *bucketSrc, err)
+	if _, err := s.backend.HeadBucket(ctx, headBucketinput); err != nil {
+		return "", wrapBucketError(err, "failed to CopyObject from bucket, %s, does not exist", *bucketSrc)
 	}
 
 	// Check if the object exists
 	sourceObjectResponse, err := s.HeadObject(ctx, keySrc, bucketSrc)
 	if err != nil {
-		return nil, fmt.Errorf("failed to CopyObject from bucket, %s, because object %s does not exist: %v", *bucketSrc, *keySrc, err)
+		return "", wrapError(err, "failed to CopyObject from bucket, %s, because object %s does not exist", *bucketSrc, *keySrc)
 	}
 
-	// Check if the destination bucket exist 
+	// Check if the destination bucket exist
 	if err := s.ensureBucketExists(ctx, bucketDest); err != nil {
-		return nil, fmt.Errorf("failed to CopyObject from bucket, %s, does not exist: %v", *bucketSrc, err)
+		return "", wrapError(err, "failed to CopyObject from bucket, %s, does not exist", *bucketSrc)
 	}
 
-	// Parse the location of the object to URL
-	objectToCopy := url.QueryEscape(*bucketSrc + "/" + *keySrc)
+	// A copy that doesn't match its source is replaced by the object it overwrote.
+	backupID, err := newBackupID()
+	if err != nil {
+		return "", wrapError(err, "failed to back up %s/%s", *bucketDest, *keyDest)
+	}
 
-	copyObjectinput := &s3.CopyObjectInput{
-		Bucket: bucketDest,
-		CopySource: aws.String(objectToCopy), // (CopySource field expected url)
-		Key: keyDest,
+	backupKey, err := s.backupObject(ctx, keyDest, bucketDest, aws.String(backupID))
+	if err != nil {
+		return "", err
 	}
 
-	copyObjectResponse, err := s.s3Client.CopyObjectWithContext(ctx, copyObjectinput)
+	verification, err := s.copyVerified(ctx, sourceObjectResponse, bucketSrc, bucketDest, keySrc, keyDest)
+	if svcErr, ok := err.(*Error); ok && svcErr.Code == codes.DataLoss {
+		// The request may be done already, which mustn't stop the restore.
+		restoreCtx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+		defer cancel()
+
+		if restoreErr := s.restoreBackup(restoreCtx, keyDest, bucketDest, backupKey); restoreErr != nil {
+			return "", restoreErr
+		}
+
+		return "", err
+	}
+
+	s.deleteBackup(ctx, backupKey, bucketDest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to copy object: %v", err)
+		return "", err
 	}
 
-	// Compare the ETags between the source and the destination buckets (kind of a checksum)
-	if *sourceObjectResponse.ETag != *copyObjectResponse.CopyObjectResult.ETag {
-		return nil, fmt.Errorf(
-			"failed to copy object %s from source bucket, %s, because something went wrong in the process of copying the object to bucket %s and the ETag has changed : %v",
-			*keySrc,
-			*bucketSrc,
-			*bucketDest,
-			err)
+	return verification, nil
+}
+
+// ListObjects lists a page of the objects in a bucket whose key begins with prefix.
+// If delimiter is a non-empty string then keys that contain it after the prefix are grouped
+// into common prefixes, which lists a single folder. pageToken is the NextContinuationToken
+// of the previous page, and pageSize is the maximal number of objects and common prefixes
+// in the page, or 1000 if it's zero. A missing bucket fails with NotFound.
+// The content type of each listed object is fetched with HeadObject, since S3 doesn't list it.
+func (s *Service) ListObjects(
+	ctx aws.Context,
+	bucket *string,
+	prefix *string,
+	delimiter *string,
+	pageToken *string,
+	pageSize *int64,
+) (*ObjectList, error) {
+	if ctx == nil {
+		return nil, invalidArgument("context is required")
 	}
-	
 
-	return keySrc, nil
+	if bucket == nil || *bucket == "" {
+		return nil, invalidArgument("bucket name is required")
+	}
+
+	if pageSize != nil && (*pageSize < 0 || *pageSize > maxListPageSize) {
+		return nil, invalidArgument("page size must be between 0 and %d", maxListPageSize)
+	}
+
+	// Listing a bucket doesn't create it, a missing bucket fails with NotFound.
+	s.normalizeBucketName(bucket)
+
+	input := &s3.ListObjectsV2Input{
+		Bucket:    bucket,
+		Prefix:    prefix,
+		Delimiter: delimiter,
+	}
+
+	if pageToken != nil && *pageToken != "" {
+		input.ContinuationToken = pageToken
+	}
+
+	if pageSize != nil && *pageSize != 0 {
+		input.MaxKeys = pageSize
+	}
+
+	output, err := s.backend.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, wrapBucketError(err, "failed to list objects in bucket %s", *bucket)
+	}
+
+	contentTypes, err := s.headContentTypes(ctx, bucket, output.Contents)
+	if err != nil {
+		return nil, wrapError(err, "failed to list objects in bucket %s", *bucket)
+	}
+
+	return &ObjectList{ListObjectsV2Output: output, ContentTypes: contentTypes}, nil
+}
+
+// headContentTypes fetches the content types of the given objects in bucket concurrently,
+// and returns them mapped by the objects' keys.
+// Objects that were deleted since they were listed are left out.
+func (s *Service) headContentTypes(ctx aws.Context, bucket *string, objects []*s3.Object) (map[string]string, error) {
+	contentTypes := make(map[string]string, len(objects))
+	errs := make(chan error, len(objects))
+	sem := make(chan struct{}, listHeadConcurrency)
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for _, object := range objects {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key *string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			head, err := s.backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: bucket, Key: key})
+			if err != nil {
+				if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == storage.ErrCodeNotFound {
+					return
+				}
+
+				errs <- wrapError(err, "failed to head object %s", *key)
+				return
+			}
+
+			mu.Lock()
+			contentTypes[*key] = aws.StringValue(head.ContentType)
+			mu.Unlock()
+		}(object.Key)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	return contentTypes, nil
+}
+
+// GeneratePresignedURL returns a URL that grants access to an object directly in the storage
+// until expiry passes, and the headers that must be sent with the request to it.
+// method is http.MethodGet to download the object or http.MethodPut to upload it.
+// For PUT, contentType and metadata are signed so the object must be uploaded with them.
+// For GET, contentType overrides the content type the object is downloaded with.
+// If expiry is zero, the URL expires after 15 minutes.
+func (s *Service) GeneratePresignedURL(
+	ctx aws.Context,
+	method *string,
+	key *string,
+	bucket *string,
+	expiry time.Duration,
+	contentType *string,
+	metadata map[string]*string,
+) (string, http.Header, error) {
+	if ctx == nil {
+		return "", nil, invalidArgument("context is required")
+	}
+
+	if method == nil || (*method != http.MethodGet && *method != http.MethodPut) {
+		return "", nil, invalidArgument("method must be %s or %s", http.MethodGet, http.MethodPut)
+	}
+
+	if key == nil || *key == "" {
+		return "", nil, invalidArgument("key is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return "", nil, invalidArgument("bucket name is required")
+	}
+
+	if expiry < 0 || expiry > maxPresignExpiry {
+		return "", nil, invalidArgument("expiry must be between 0 and %v", maxPresignExpiry)
+	}
+
+	if expiry == 0 {
+		expiry = defaultPresignExpiry
+	}
+
+	presigner, ok := s.backend.(storage.Presigner)
+	if !ok {
+		return "", nil, ErrPresignNotSupported
+	}
+
+	err := s.ensureBucketExists(ctx, bucket)
+	if err != nil {
+		return "", nil, wrapError(err, "failed to presign %s/%s", *bucket, *key)
+	}
+
+	var url string
+	var headers http.Header
+	if *method == http.MethodGet {
+		input := &s3.GetObjectInput{
+			Bucket: bucket,
+			Key:    key,
+		}
+
+		if contentType != nil && *contentType != "" {
+			input.ResponseContentType = contentType
+		}
+
+		url, headers, err = presigner.PresignGetObject(input, expiry)
+	} else {
+		input := &s3.PutObjectInput{
+			Bucket: bucket,
+			Key:    key,
+		}
+
+		if contentType != nil && *contentType != "" {
+			input.ContentType = contentType
+		}
+
+		if len(metadata) > 0 {
+			input.Metadata = metadata
+		}
+
+		url, headers, err = presigner.PresignPutObject(input, expiry)
+	}
+
+	if err != nil {
+		return "", nil, wrapError(err, "failed to presign %s/%s", *bucket, *key)
+	}
+
+	// The signer returns the signed headers with lower case names, canonicalize them.
+	signedHeaders := make(http.Header, len(headers))
+	for name, values := range headers {
+		for _, value := range values {
+			signedHeaders.Add(name, value)
+		}
+	}
+
+	return url, signedHeaders, nil
 }
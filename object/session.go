@@ -0,0 +1,299 @@
+package object
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/meateam/upload-service/session"
+	"google.golang.org/grpc/codes"
+)
+
+// DefaultSessionTTL is the default time until a multipart upload expires after it's initiated.
+const DefaultSessionTTL = 24 * time.Hour
+
+// UploadOptions are the expectations a multipart upload is initiated with, which are kept
+// in the upload's session and checked as its parts are uploaded and when it's completed.
+// Zero values aren't checked.
+type UploadOptions struct {
+	// Owner is the user who starts the upload, parts and completions of other users are denied.
+	Owner string
+
+	// Size is the total size of the upload in bytes.
+	Size int64
+
+	// PartCount is the number of parts of the upload.
+	PartCount int64
+
+	// Checksum is the checksum of the assembled upload.
+	Checksum *Checksum
+
+	// TTL is the time until the upload expires, the service's session TTL if it's zero.
+	TTL time.Duration
+}
+
+// validate returns an error if any of the options is invalid.
+func (o *UploadOptions) validate() error {
+	if o.Size < 0 {
+		return invalidArgument("size must not be negative")
+	}
+
+	if o.PartCount < 0 || o.PartCount > 10000 {
+		return invalidArgument("part count must be between 0 and 10,000")
+	}
+
+	if o.TTL < 0 {
+		return invalidArgument("ttl must not be negative")
+	}
+
+	if o.Checksum != nil {
+		return o.Checksum.validate()
+	}
+
+	return nil
+}
+
+// SetSessionStore sets the store the service keeps its upload sessions in, and the time until
+// an upload expires if it's initiated without a TTL. A ttl that isn't positive is set to
+// DefaultSessionTTL. It must be called before the service is used.
+func (s *Service) SetSessionStore(store session.Store, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	s.sessions = store
+	s.sessionTTL = ttl
+}
+
+// GetSessionStore returns the store of the service's upload sessions.
+func (s *Service) GetSessionStore() session.Store {
+	return s.sessions
+}
+
+// CheckUploadOwner returns a PermissionDenied error if the upload's session has an owner
+// and owner isn't it. Uploads without a session or an owner are owned by everyone.
+func (s *Service) CheckUploadOwner(uploadID *string, owner string) error {
+	uploadSession, err := s.getSession(aws.StringValue(uploadID))
+	if err != nil || uploadSession == nil {
+		return err
+	}
+
+	if uploadSession.Owner != "" && uploadSession.Owner != owner {
+		return newError(codes.PermissionDenied, ReasonPermissionDenied, "upload %s is owned by another user", uploadSession.UploadID)
+	}
+
+	return nil
+}
+
+// getSession returns an upload's session, or nil if the upload has no session, such as
+// an upload that was initiated by another service.
+func (s *Service) getSession(uploadID string) (*session.Session, error) {
+	uploadSession, err := s.sessions.Get(uploadID)
+	if err == session.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, sessionError(err, "failed to get session of upload %s", uploadID)
+	}
+
+	return uploadSession, nil
+}
+
+// checkPart returns an error if a part whose body is body doesn't fit the upload's session.
+// Parts are uploaded concurrently, so a part that fits may still exceed the upload's size
+// along with parts uploaded at the same time, which is caught when the upload is completed.
+func (s *Service) checkPart(uploadID string, partNumber int64, body io.Seeker) error {
+	uploadSession, err := s.getSession(uploadID)
+	if err != nil || uploadSession == nil {
+		return err
+	}
+
+	if err := checkExpiry(uploadSession); err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("part %d", partNumber)
+	if uploadSession.PartCount > 0 && partNumber > uploadSession.PartCount {
+		return mismatchError(uploadSession, Violation{
+			Type:        "PART_NUMBER_EXCEEDED",
+			Subject:     subject,
+			Description: fmt.Sprintf("part %d exceeds the upload's %d parts", partNumber, uploadSession.PartCount),
+		})
+	}
+
+	if uploadSession.Size > 0 {
+		size, err := partSize(body)
+		if err != nil {
+			return err
+		}
+
+		// A part that was uploaded before is replaced by this part.
+		uploaded := uploadSession.UploadedSize()
+		if previous, ok := uploadSession.Parts[partNumber]; ok {
+			uploaded -= previous.Size
+		}
+
+		if uploaded+size > uploadSession.Size {
+			return mismatchError(uploadSession, Violation{
+				Type:    "SIZE_EXCEEDED",
+				Subject: subject,
+				Description: fmt.Sprintf(
+					"part %d of %d bytes exceeds the upload's %d bytes, %d were uploaded",
+					partNumber,
+					size,
+					uploadSession.Size,
+					uploaded,
+				),
+			})
+		}
+	}
+
+	return nil
+}
+
+// recordPart records an uploaded part in the upload's session, if it has one.
+func (s *Service) recordPart(uploadID string, partNumber int64, size int64, eTag string, checksum *Checksum) error {
+	err := s.sessions.Update(uploadID, func(uploadSession *session.Session) error {
+		if uploadSession.Parts == nil {
+			uploadSession.Parts = make(map[int64]*session.Part)
+		}
+
+		uploadSession.Parts[partNumber] = &session.Part{
+			Size:     size,
+			ETag:     eTag,
+			Checksum: (*session.Checksum)(checksum),
+			Uploaded: time.Now(),
+		}
+
+		return nil
+	})
+	if err != nil && err != session.ErrNotFound {
+		return sessionError(err, "failed to record part %d of upload %s", partNumber, uploadID)
+	}
+
+	return nil
+}
+
+// deleteSession deletes the session of an upload that was completed or aborted.
+// A session that fails to delete is left to expire, and is deleted when the expired
+// upload is aborted.
+func (s *Service) deleteSession(uploadID string) {
+	_ = s.sessions.Delete(uploadID)
+}
+
+// sessionExpectations returns the size and checksum an upload should be completed with,
+// which are the given size and checksum, or the session's if they're nil, along with
+// the violations of the session's expectations by the given size and checksum.
+func sessionExpectations(
+	uploadSession *session.Session,
+	size *int64,
+	checksum *Checksum,
+) (*int64, *Checksum, []Violation) {
+	var violations []Violation
+	if uploadSession.Size > 0 {
+		if size == nil {
+			size = aws.Int64(uploadSession.Size)
+		} else if *size != uploadSession.Size {
+			violations = append(violations, Violation{
+				Type:        "SIZE_MISMATCH",
+				Subject:     "size",
+				Description: fmt.Sprintf("size %d doesn't match the upload's %d bytes", *size, uploadSession.Size),
+			})
+		}
+	}
+
+	if uploadSession.Checksum != nil {
+		sessionChecksum := (*Checksum)(uploadSession.Checksum)
+		if checksum == nil {
+			checksum = sessionChecksum
+		} else if checksum.Algorithm == sessionChecksum.Algorithm && !strings.EqualFold(checksum.Value, sessionChecksum.Value) {
+			violations = append(violations, Violation{
+				Type:    "CHECKSUM_MISMATCH",
+				Subject: "checksum",
+				Description: fmt.Sprintf(
+					"checksum %s doesn't match the upload's %s checksum %s",
+					checksum.Value,
+					sessionChecksum.Algorithm,
+					sessionChecksum.Value,
+				),
+			})
+		}
+	}
+
+	return size, checksum, violations
+}
+
+// partCountViolations returns a violation if an upload is completed with another number of parts
+// than its session's part count.
+func partCountViolations(uploadSession *session.Session, parts []*s3.CompletedPart) []Violation {
+	if uploadSession.PartCount == 0 || int64(len(parts)) == uploadSession.PartCount {
+		return nil
+	}
+
+	return []Violation{{
+		Type:        "PART_COUNT_MISMATCH",
+		Subject:     "parts",
+		Description: fmt.Sprintf("%d parts were uploaded, expected %d", len(parts), uploadSession.PartCount),
+	}}
+}
+
+// checkExpiry returns a FailedPrecondition error if the upload's session expired.
+func checkExpiry(uploadSession *session.Session) error {
+	if !uploadSession.Expired(time.Now()) {
+		return nil
+	}
+
+	return newError(
+		codes.FailedPrecondition,
+		ReasonUploadExpired,
+		"upload %s of %s/%s expired at %v",
+		uploadSession.UploadID,
+		uploadSession.Bucket,
+		uploadSession.Key,
+		uploadSession.Expires,
+	)
+}
+
+// mismatchError returns a FailedPrecondition error of an upload that doesn't match its session.
+func mismatchError(uploadSession *session.Session, violations ...Violation) error {
+	return &Error{
+		Code:   codes.FailedPrecondition,
+		Reason: ReasonUploadMismatch,
+		Message: fmt.Sprintf(
+			"upload %s of %s/%s doesn't match: %s",
+			uploadSession.UploadID,
+			uploadSession.Bucket,
+			uploadSession.Key,
+			describeViolations(violations),
+		),
+		Violations: violations,
+	}
+}
+
+// sessionError returns an Internal error of a failed operation on the session store.
+func sessionError(err error, format string, args ...interface{}) error {
+	return &Error{
+		Code:    codes.Internal,
+		Reason:  ReasonInternal,
+		Message: fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err),
+		Err:     err,
+	}
+}
+
+// partSize returns the size of a part's body, which is left at its beginning.
+func partSize(body io.Seeker) (int64, error) {
+	size, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, wrapError(err, "failed reading part")
+	}
+
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return 0, wrapError(err, "failed reading part")
+	}
+
+	return size, nil
+}
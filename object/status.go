@@ -0,0 +1,117 @@
+package object
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"google.golang.org/grpc/codes"
+)
+
+// UploadStatus is the status of a multipart upload in progress.
+type UploadStatus struct {
+	UploadID string
+	Key      string
+	Bucket   string
+
+	// ContentType, Metadata, Owner, ExpectedSize, PartCount, Checksum and Expires are
+	// the details the upload was initiated with, kept in its session.
+	// They're known only for uploads initiated by this service.
+	ContentType  string
+	Metadata     map[string]string
+	Owner        string
+	ExpectedSize int64
+	PartCount    int64
+	Checksum     *Checksum
+	Expires      time.Time
+
+	// PartChecksums are the verified checksums of the parts by part number,
+	// for the parts that were uploaded with a checksum.
+	PartChecksums map[int64]*Checksum
+
+	// Initiated is the time the upload was initiated.
+	Initiated time.Time
+
+	// Parts are the parts uploaded so far, ordered by part number.
+	Parts []*s3.Part
+}
+
+// GetUploadStatus returns the status of a multipart upload in progress, with the parts uploaded so far.
+// A client that lost its UploadPart stream can use it to resend only the parts that are missing.
+func (s *Service) GetUploadStatus(
+	ctx aws.Context,
+	uploadID *string,
+	key *string,
+	bucket *string,
+) (*UploadStatus, error) {
+	parts, err := s.ListUploadParts(ctx, uploadID, key, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &UploadStatus{
+		UploadID: *uploadID,
+		Key:      *key,
+		Bucket:   *bucket,
+		Parts:    parts.Parts,
+	}
+
+	uploadSession, err := s.getSession(*uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if uploadSession != nil {
+		status.ContentType = uploadSession.ContentType
+		status.Metadata = uploadSession.Metadata
+		status.Owner = uploadSession.Owner
+		status.ExpectedSize = uploadSession.Size
+		status.PartCount = uploadSession.PartCount
+		status.Checksum = (*Checksum)(uploadSession.Checksum)
+		status.Expires = uploadSession.Expires
+		status.PartChecksums = make(map[int64]*Checksum)
+		for partNumber, part := range uploadSession.Parts {
+			if part.Checksum != nil {
+				status.PartChecksums[partNumber] = (*Checksum)(part.Checksum)
+			}
+		}
+	}
+
+	initiated, err := s.uploadInitiated(ctx, uploadID, key, bucket)
+	if err != nil {
+		return nil, err
+	}
+	status.Initiated = initiated
+
+	return status, nil
+}
+
+// uploadInitiated returns the time a multipart upload was initiated, by listing the uploads of its key.
+func (s *Service) uploadInitiated(ctx aws.Context, uploadID *string, key *string, bucket *string) (time.Time, error) {
+	input := &s3.ListMultipartUploadsInput{
+		Bucket: bucket,
+		Prefix: key,
+	}
+
+	for {
+		output, err := s.backend.ListMultipartUploads(ctx, input)
+		if err != nil {
+			return time.Time{}, wrapError(err, "failed to list uploads of %s/%s", *bucket, *key)
+		}
+
+		for _, upload := range output.Uploads {
+			if aws.StringValue(upload.UploadId) == *uploadID && aws.StringValue(upload.Key) == *key {
+				return aws.TimeValue(upload.Initiated), nil
+			}
+		}
+
+		if !aws.BoolValue(output.IsTruncated) {
+			break
+		}
+
+		input.KeyMarker = output.NextKeyMarker
+		input.UploadIdMarker = output.NextUploadIdMarker
+	}
+
+	return time.Time{}, newError(codes.NotFound, ReasonUploadNotFound, "upload %s of %s/%s does not exist", *uploadID, *bucket, *key)
+}
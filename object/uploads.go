@@ -0,0 +1,234 @@
+package object
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// maxAbortUploads is the maximal number of uploads aborted by AbortMultipartUploads.
+	maxAbortUploads = 1000
+
+	// abortConcurrency is the maximal number of uploads aborted concurrently by AbortMultipartUploads.
+	abortConcurrency = 8
+)
+
+// MultipartUpload is a multipart upload in progress.
+type MultipartUpload struct {
+	UploadID string
+	Key      string
+
+	// Initiated is the time the upload was initiated.
+	Initiated time.Time
+
+	// ContentType, Owner and Expires are the details the upload was initiated with,
+	// kept in its session. They're known only for uploads initiated by this service.
+	ContentType string
+	Owner       string
+	Expires     time.Time
+}
+
+// MultipartUploadList is a page of multipart uploads listed by ListMultipartUploads.
+type MultipartUploadList struct {
+	Uploads []*MultipartUpload
+
+	// NextPageToken is the token of the next page, empty if this is the last page.
+	NextPageToken string
+}
+
+// AbortResult is the result of aborting a multipart upload with AbortMultipartUploads.
+type AbortResult struct {
+	UploadID string
+	Key      string
+
+	// Err is the error of the abort, nil if the upload was aborted.
+	Err error
+}
+
+// uploadsPageToken is the position after the last upload of a page of uploads.
+type uploadsPageToken struct {
+	KeyMarker      string `json:"k"`
+	UploadIDMarker string `json:"u"`
+}
+
+// ListMultipartUploads lists a page of the multipart uploads in progress in bucket whose key begins
+// with prefix. If initiatedBefore isn't zero then only uploads initiated before it are listed.
+// pageToken is the NextPageToken of the previous page, and pageSize is the maximal number of
+// uploads in the page, or 1000 if it's zero.
+func (s *Service) ListMultipartUploads(
+	ctx aws.Context,
+	bucket *string,
+	prefix *string,
+	initiatedBefore time.Time,
+	pageToken *string,
+	pageSize *int64,
+) (*MultipartUploadList, error) {
+	if ctx == nil {
+		return nil, invalidArgument("context is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return nil, invalidArgument("bucket name is required")
+	}
+
+	if pageSize != nil && (*pageSize < 0 || *pageSize > maxListPageSize) {
+		return nil, invalidArgument("page size must be between 0 and %d", maxListPageSize)
+	}
+
+	// A copy of the bucket name is normalized, so the caller's isn't changed.
+	bucket = aws.String(*bucket)
+	s.normalizeBucketName(bucket)
+
+	limit := int64(maxListPageSize)
+	if pageSize != nil && *pageSize != 0 {
+		limit = *pageSize
+	}
+
+	input := &s3.ListMultipartUploadsInput{
+		Bucket:     bucket,
+		Prefix:     prefix,
+		MaxUploads: aws.Int64(limit),
+	}
+
+	if pageToken != nil && *pageToken != "" {
+		token, err := decodeUploadsPageToken(*pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		input.KeyMarker = aws.String(token.KeyMarker)
+		input.UploadIdMarker = aws.String(token.UploadIDMarker)
+	}
+
+	list := &MultipartUploadList{Uploads: []*MultipartUpload{}}
+
+	// Uploads that were initiated too recently are filtered out, so the storage is listed
+	// until the page is full or there are no more uploads.
+	for {
+		output, err := s.backend.ListMultipartUploads(ctx, input)
+		if err != nil {
+			return nil, wrapBucketError(err, "failed to list uploads in bucket %s", *bucket)
+		}
+
+		for _, upload := range output.Uploads {
+			if int64(len(list.Uploads)) == limit {
+				list.NextPageToken = encodeUploadsPageToken(input.KeyMarker, input.UploadIdMarker)
+				return list, nil
+			}
+
+			input.KeyMarker = upload.Key
+			input.UploadIdMarker = upload.UploadId
+
+			initiated := aws.TimeValue(upload.Initiated)
+			if !initiatedBefore.IsZero() && !initiated.Before(initiatedBefore) {
+				continue
+			}
+
+			multipartUpload := &MultipartUpload{
+				UploadID:  aws.StringValue(upload.UploadId),
+				Key:       aws.StringValue(upload.Key),
+				Initiated: initiated,
+			}
+			uploadSession, err := s.getSession(multipartUpload.UploadID)
+			if err != nil {
+				return nil, err
+			}
+
+			if uploadSession != nil {
+				multipartUpload.ContentType = uploadSession.ContentType
+				multipartUpload.Owner = uploadSession.Owner
+				multipartUpload.Expires = uploadSession.Expires
+			}
+
+			list.Uploads = append(list.Uploads, multipartUpload)
+		}
+
+		if !aws.BoolValue(output.IsTruncated) {
+			return list, nil
+		}
+	}
+}
+
+// AbortMultipartUploads aborts up to 1000 multipart uploads in bucket concurrently, and returns
+// the result of each upload in the same order as uploads. Only the UploadID and Key of each
+// upload are used. Uploads that fail to abort are returned with their error.
+func (s *Service) AbortMultipartUploads(
+	ctx aws.Context,
+	bucket *string,
+	uploads []*MultipartUpload,
+) ([]*AbortResult, error) {
+	if ctx == nil {
+		return nil, invalidArgument("context is required")
+	}
+
+	if bucket == nil || *bucket == "" {
+		return nil, invalidArgument("bucket name is required")
+	}
+
+	if len(uploads) == 0 {
+		return nil, invalidArgument("uploads are required")
+	}
+
+	if len(uploads) > maxAbortUploads {
+		return nil, invalidArgument("%d uploads were given, at most %d are allowed", len(uploads), maxAbortUploads)
+	}
+
+	results := make([]*AbortResult, len(uploads))
+	sem := make(chan struct{}, abortConcurrency)
+	wg := sync.WaitGroup{}
+
+	for i, upload := range uploads {
+		if upload == nil {
+			return nil, invalidArgument("upload %d is empty", i)
+		}
+
+		results[i] = &AbortResult{UploadID: upload.UploadID, Key: upload.Key}
+	}
+
+	for _, result := range results {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		// UploadAbort normalizes the bucket name in place, so each abort gets its own copy.
+		go func(result *AbortResult, bucket *string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, result.Err = s.UploadAbort(ctx, aws.String(result.UploadID), aws.String(result.Key), bucket)
+		}(result, aws.String(*bucket))
+	}
+
+	wg.Wait()
+
+	return results, nil
+}
+
+// encodeUploadsPageToken returns the page token of the uploads after the given markers.
+func encodeUploadsPageToken(keyMarker *string, uploadIDMarker *string) string {
+	token, _ := json.Marshal(uploadsPageToken{
+		KeyMarker:      aws.StringValue(keyMarker),
+		UploadIDMarker: aws.StringValue(uploadIDMarker),
+	})
+
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// decodeUploadsPageToken returns the markers of a page token returned by encodeUploadsPageToken.
+func decodeUploadsPageToken(pageToken string) (*uploadsPageToken, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, invalidArgument("page token %q is invalid", pageToken)
+	}
+
+	token := &uploadsPageToken{}
+	if err := json.Unmarshal(decoded, token); err != nil {
+		return nil, invalidArgument("page token %q is invalid", pageToken)
+	}
+
+	return token, nil
+}
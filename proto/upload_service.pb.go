@@ -29,6 +29,176 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// ChecksumAlgorithm is the algorithm of a content checksum.
+type ChecksumAlgorithm int32
+
+const (
+	ChecksumAlgorithm_CHECKSUM_ALGORITHM_UNSPECIFIED ChecksumAlgorithm = 0
+	ChecksumAlgorithm_MD5                            ChecksumAlgorithm = 1
+	ChecksumAlgorithm_SHA256                         ChecksumAlgorithm = 2
+	ChecksumAlgorithm_CRC32C                         ChecksumAlgorithm = 3
+)
+
+// Enum value maps for ChecksumAlgorithm.
+var (
+	ChecksumAlgorithm_name = map[int32]string{
+		0: "CHECKSUM_ALGORITHM_UNSPECIFIED",
+		1: "MD5",
+		2: "SHA256",
+		3: "CRC32C",
+	}
+	ChecksumAlgorithm_value = map[string]int32{
+		"CHECKSUM_ALGORITHM_UNSPECIFIED": 0,
+		"MD5":                            1,
+		"SHA256":                         2,
+		"CRC32C":                         3,
+	}
+)
+
+func (x ChecksumAlgorithm) Enum() *ChecksumAlgorithm {
+	p := new(ChecksumAlgorithm)
+	*p = x
+	return p
+}
+
+func (x ChecksumAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChecksumAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_upload_service_proto_enumTypes[0].Descriptor()
+}
+
+func (ChecksumAlgorithm) Type() protoreflect.EnumType {
+	return &file_upload_service_proto_enumTypes[0]
+}
+
+func (x ChecksumAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChecksumAlgorithm.Descriptor instead.
+func (ChecksumAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{0}
+}
+
+// CopyVerification is how a copy of an object was verified against its source.
+type CopyVerification int32
+
+const (
+	CopyVerification_COPY_VERIFICATION_UNSPECIFIED CopyVerification = 0
+	// Only the copy's size was verified, since the source has no stored checksum and the
+	// copy's md5 sum can't be compared by ETag, as the source or the copy was assembled from parts.
+	CopyVerification_SIZE_ONLY CopyVerification = 1
+	// The copy's size and md5 sum were verified.
+	CopyVerification_SIZE_AND_MD5 CopyVerification = 2
+	// The copy's size and the checksum stored with the source were verified.
+	CopyVerification_SIZE_AND_CHECKSUM CopyVerification = 3
+)
+
+// Enum value maps for CopyVerification.
+var (
+	CopyVerification_name = map[int32]string{
+		0: "COPY_VERIFICATION_UNSPECIFIED",
+		1: "SIZE_ONLY",
+		2: "SIZE_AND_MD5",
+		3: "SIZE_AND_CHECKSUM",
+	}
+	CopyVerification_value = map[string]int32{
+		"COPY_VERIFICATION_UNSPECIFIED": 0,
+		"SIZE_ONLY":                     1,
+		"SIZE_AND_MD5":                  2,
+		"SIZE_AND_CHECKSUM":             3,
+	}
+)
+
+func (x CopyVerification) Enum() *CopyVerification {
+	p := new(CopyVerification)
+	*p = x
+	return p
+}
+
+func (x CopyVerification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CopyVerification) Descriptor() protoreflect.EnumDescriptor {
+	return file_upload_service_proto_enumTypes[1].Descriptor()
+}
+
+func (CopyVerification) Type() protoreflect.EnumType {
+	return &file_upload_service_proto_enumTypes[1]
+}
+
+func (x CopyVerification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CopyVerification.Descriptor instead.
+func (CopyVerification) EnumDescriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{1}
+}
+
+// MoveState is the final state of a move.
+type MoveState int32
+
+const (
+	MoveState_MOVE_STATE_UNSPECIFIED MoveState = 0
+	// The object was copied and verified, and the source was deleted.
+	MoveState_MOVED MoveState = 1
+	// The object wasn't copied, the source is left as it was.
+	MoveState_NOT_MOVED MoveState = 2
+	// The source couldn't be deleted, so the copy was deleted and the source is left as it was.
+	MoveState_ROLLED_BACK MoveState = 3
+	// The source couldn't be deleted, and neither could the copy, so the object exists in both.
+	MoveState_DUPLICATED MoveState = 4
+)
+
+// Enum value maps for MoveState.
+var (
+	MoveState_name = map[int32]string{
+		0: "MOVE_STATE_UNSPECIFIED",
+		1: "MOVED",
+		2: "NOT_MOVED",
+		3: "ROLLED_BACK",
+		4: "DUPLICATED",
+	}
+	MoveState_value = map[string]int32{
+		"MOVE_STATE_UNSPECIFIED": 0,
+		"MOVED":                  1,
+		"NOT_MOVED":              2,
+		"ROLLED_BACK":            3,
+		"DUPLICATED":             4,
+	}
+)
+
+func (x MoveState) Enum() *MoveState {
+	p := new(MoveState)
+	*p = x
+	return p
+}
+
+func (x MoveState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MoveState) Descriptor() protoreflect.EnumDescriptor {
+	return file_upload_service_proto_enumTypes[2].Descriptor()
+}
+
+func (MoveState) Type() protoreflect.EnumType {
+	return &file_upload_service_proto_enumTypes[2]
+}
+
+func (x MoveState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MoveState.Descriptor instead.
+func (MoveState) EnumDescriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{2}
+}
+
 // UploadMediaRequest is the request for media upload
 type UploadMediaRequest struct {
 	state         protoimpl.MessageState
@@ -43,6 +213,8 @@ type UploadMediaRequest struct {
 	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	// The mime-type of the file.
 	ContentType string `protobuf:"bytes,4,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The expected checksum of the file, not verified if unset.
+	Checksum *Checksum `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
 }
 
 func (x *UploadMediaRequest) Reset() {
@@ -105,6 +277,72 @@ func (x *UploadMediaRequest) GetContentType() string {
 	return ""
 }
 
+func (x *UploadMediaRequest) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+// Checksum is a checksum of content, verified by the server as the content is uploaded.
+// A content whose checksum doesn't match fails with a DataLoss status.
+type Checksum struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The checksum's algorithm
+	Algorithm ChecksumAlgorithm `protobuf:"varint,1,opt,name=algorithm,proto3,enum=upload.ChecksumAlgorithm" json:"algorithm,omitempty"`
+	// The hex encoded checksum, CRC32C is encoded as a big-endian 4 byte value
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Checksum) Reset() {
+	*x = Checksum{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Checksum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Checksum) ProtoMessage() {}
+
+func (x *Checksum) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Checksum.ProtoReflect.Descriptor instead.
+func (*Checksum) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Checksum) GetAlgorithm() ChecksumAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ChecksumAlgorithm_CHECKSUM_ALGORITHM_UNSPECIFIED
+}
+
+func (x *Checksum) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 // UploadMediaResponse is the response for media upload
 type UploadMediaResponse struct {
 	state         protoimpl.MessageState
@@ -118,7 +356,7 @@ type UploadMediaResponse struct {
 func (x *UploadMediaResponse) Reset() {
 	*x = UploadMediaResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[1]
+		mi := &file_upload_service_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -131,7 +369,7 @@ func (x *UploadMediaResponse) String() string {
 func (*UploadMediaResponse) ProtoMessage() {}
 
 func (x *UploadMediaResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[1]
+	mi := &file_upload_service_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -144,7 +382,7 @@ func (x *UploadMediaResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMediaResponse.ProtoReflect.Descriptor instead.
 func (*UploadMediaResponse) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{1}
+	return file_upload_service_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *UploadMediaResponse) GetLocation() string {
@@ -170,12 +408,14 @@ type UploadMultipartRequest struct {
 	Bucket string `protobuf:"bytes,4,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	// The mime-type of the file.
 	ContentType string `protobuf:"bytes,5,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The expected checksum of the file, not verified if unset.
+	Checksum *Checksum `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
 }
 
 func (x *UploadMultipartRequest) Reset() {
 	*x = UploadMultipartRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[2]
+		mi := &file_upload_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -188,7 +428,7 @@ func (x *UploadMultipartRequest) String() string {
 func (*UploadMultipartRequest) ProtoMessage() {}
 
 func (x *UploadMultipartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[2]
+	mi := &file_upload_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -201,7 +441,7 @@ func (x *UploadMultipartRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMultipartRequest.ProtoReflect.Descriptor instead.
 func (*UploadMultipartRequest) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{2}
+	return file_upload_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UploadMultipartRequest) GetFile() []byte {
@@ -239,6 +479,13 @@ func (x *UploadMultipartRequest) GetContentType() string {
 	return ""
 }
 
+func (x *UploadMultipartRequest) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
 // UploadMultipartResponse is the response for multipart upload
 type UploadMultipartResponse struct {
 	state         protoimpl.MessageState
@@ -252,7 +499,7 @@ type UploadMultipartResponse struct {
 func (x *UploadMultipartResponse) Reset() {
 	*x = UploadMultipartResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[3]
+		mi := &file_upload_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -265,7 +512,7 @@ func (x *UploadMultipartResponse) String() string {
 func (*UploadMultipartResponse) ProtoMessage() {}
 
 func (x *UploadMultipartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[3]
+	mi := &file_upload_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,7 +525,7 @@ func (x *UploadMultipartResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMultipartResponse.ProtoReflect.Descriptor instead.
 func (*UploadMultipartResponse) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{3}
+	return file_upload_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UploadMultipartResponse) GetLocation() string {
@@ -302,12 +549,22 @@ type UploadInitRequest struct {
 	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// The mime-type of the file.
 	ContentType string `protobuf:"bytes,4,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The user who starts the upload, parts and completions of other users are denied if set.
+	Owner string `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The total size of the file in bytes, parts that exceed it are rejected if set.
+	Size int64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	// The number of parts of the file, greater part numbers are rejected if set.
+	PartCount int64 `protobuf:"varint,7,opt,name=partCount,proto3" json:"partCount,omitempty"`
+	// The expected checksum of the file, verified when the upload completes if set.
+	Checksum *Checksum `protobuf:"bytes,8,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// The number of seconds until the upload expires, the server's default if 0.
+	Ttl int64 `protobuf:"varint,9,opt,name=ttl,proto3" json:"ttl,omitempty"`
 }
 
 func (x *UploadInitRequest) Reset() {
 	*x = UploadInitRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[4]
+		mi := &file_upload_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -320,7 +577,7 @@ func (x *UploadInitRequest) String() string {
 func (*UploadInitRequest) ProtoMessage() {}
 
 func (x *UploadInitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[4]
+	mi := &file_upload_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -333,7 +590,7 @@ func (x *UploadInitRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadInitRequest.ProtoReflect.Descriptor instead.
 func (*UploadInitRequest) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{4}
+	return file_upload_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UploadInitRequest) GetKey() string {
@@ -364,6 +621,41 @@ func (x *UploadInitRequest) GetContentType() string {
 	return ""
 }
 
+func (x *UploadInitRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *UploadInitRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadInitRequest) GetPartCount() int64 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+func (x *UploadInitRequest) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *UploadInitRequest) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
 // UploadInitResponse is the response for initiating resumable upload
 type UploadInitResponse struct {
 	state         protoimpl.MessageState
@@ -376,12 +668,15 @@ type UploadInitResponse struct {
 	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	// The bucket to upload the file to
 	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The time the upload expires, in seconds since the epoch.
+	// An expired upload can't be continued and is aborted by the server.
+	Expires int64 `protobuf:"varint,4,opt,name=expires,proto3" json:"expires,omitempty"`
 }
 
 func (x *UploadInitResponse) Reset() {
 	*x = UploadInitResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[5]
+		mi := &file_upload_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -394,7 +689,7 @@ func (x *UploadInitResponse) String() string {
 func (*UploadInitResponse) ProtoMessage() {}
 
 func (x *UploadInitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[5]
+	mi := &file_upload_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,7 +702,7 @@ func (x *UploadInitResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadInitResponse.ProtoReflect.Descriptor instead.
 func (*UploadInitResponse) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{5}
+	return file_upload_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UploadInitResponse) GetUploadId() string {
@@ -431,6 +726,13 @@ func (x *UploadInitResponse) GetBucket() string {
 	return ""
 }
 
+func (x *UploadInitResponse) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
 // UploadPartRequest is the request for resumable part uload
 type UploadPartRequest struct {
 	state         protoimpl.MessageState
@@ -447,12 +749,16 @@ type UploadPartRequest struct {
 	Key string `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
 	// The bucket to upload the file to
 	Bucket string `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The expected checksum of the part, not verified if unset.
+	Checksum *Checksum `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// The user who uploads the part, checked against the upload's owner if set.
+	Owner string `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
 }
 
 func (x *UploadPartRequest) Reset() {
 	*x = UploadPartRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[6]
+		mi := &file_upload_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -465,7 +771,7 @@ func (x *UploadPartRequest) String() string {
 func (*UploadPartRequest) ProtoMessage() {}
 
 func (x *UploadPartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[6]
+	mi := &file_upload_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,7 +784,7 @@ func (x *UploadPartRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadPartRequest.ProtoReflect.Descriptor instead.
 func (*UploadPartRequest) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{6}
+	return file_upload_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UploadPartRequest) GetPart() []byte {
@@ -516,22 +822,50 @@ func (x *UploadPartRequest) GetBucket() string {
 	return ""
 }
 
-// UploadPartResponse is the response for resumable part upload
+func (x *UploadPartRequest) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *UploadPartRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+// UploadPartResponse is the response for resumable part upload.
+// Parts are uploaded concurrently so their responses may arrive in any order,
+// use partNumber to match a response to its part.
 type UploadPartResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Upload status code
+	// Upload status code, 200 if the part was uploaded and 500 otherwise.
+	// Deprecated: use error instead.
 	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	// Upload status message
 	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The number of the part
+	PartNumber int64 `protobuf:"varint,3,opt,name=partNumber,proto3" json:"partNumber,omitempty"`
+	// The ETag of the uploaded part, needed to complete the upload
+	ETag string `protobuf:"bytes,4,opt,name=eTag,proto3" json:"eTag,omitempty"`
+	// The size of the uploaded part in bytes
+	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// The hex encoded SHA-256 checksum of the part's data as received by the server
+	Checksum string `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// The error of a part that failed to upload, unset if the part was uploaded.
+	// A part that failed can be uploaded again with the same part number.
+	Error *ErrorStatus `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
 }
 
 func (x *UploadPartResponse) Reset() {
 	*x = UploadPartResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[7]
+		mi := &file_upload_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -544,7 +878,7 @@ func (x *UploadPartResponse) String() string {
 func (*UploadPartResponse) ProtoMessage() {}
 
 func (x *UploadPartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[7]
+	mi := &file_upload_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +891,7 @@ func (x *UploadPartResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadPartResponse.ProtoReflect.Descriptor instead.
 func (*UploadPartResponse) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{7}
+	return file_upload_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UploadPartResponse) GetCode() int32 {
@@ -574,37 +908,70 @@ func (x *UploadPartResponse) GetMessage() string {
 	return ""
 }
 
-// UploadCompleteRequest is the request for completing resumable upload
-type UploadCompleteRequest struct {
+func (x *UploadPartResponse) GetPartNumber() int64 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadPartResponse) GetETag() string {
+	if x != nil {
+		return x.ETag
+	}
+	return ""
+}
+
+func (x *UploadPartResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadPartResponse) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *UploadPartResponse) GetError() *ErrorStatus {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ErrorStatus is the status of a failed operation.
+type ErrorStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	/// Upload ID generated for resumable upload of a file
-	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
-	// File key to store in S3
-	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	// The bucket to upload the file to
-	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The gRPC status code of the error
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The error message
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *UploadCompleteRequest) Reset() {
-	*x = UploadCompleteRequest{}
+func (x *ErrorStatus) Reset() {
+	*x = ErrorStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[8]
+		mi := &file_upload_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadCompleteRequest) String() string {
+func (x *ErrorStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadCompleteRequest) ProtoMessage() {}
+func (*ErrorStatus) ProtoMessage() {}
 
-func (x *UploadCompleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[8]
+func (x *ErrorStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,61 +982,65 @@ func (x *UploadCompleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadCompleteRequest.ProtoReflect.Descriptor instead.
-func (*UploadCompleteRequest) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ErrorStatus.ProtoReflect.Descriptor instead.
+func (*ErrorStatus) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UploadCompleteRequest) GetUploadId() string {
+func (x *ErrorStatus) GetCode() int32 {
 	if x != nil {
-		return x.UploadId
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadCompleteRequest) GetKey() string {
+func (x *ErrorStatus) GetMessage() string {
 	if x != nil {
-		return x.Key
+		return x.Message
 	}
 	return ""
 }
 
-func (x *UploadCompleteRequest) GetBucket() string {
-	if x != nil {
-		return x.Bucket
-	}
-	return ""
-}
-
-// UploadCompleteResponse is the response for completing resumable upload
-type UploadCompleteResponse struct {
+// UploadCompleteRequest is the request for completing resumable upload
+type UploadCompleteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The size that the uploaded file
-	ContentLength int64 `protobuf:"varint,1,opt,name=ContentLength,proto3" json:"ContentLength,omitempty"`
-	// The type of the uploaded file
-	ContentType string `protobuf:"bytes,2,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	/// Upload ID generated for resumable upload of a file
+	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
+	// File key to store in S3
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The bucket to upload the file to
+	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The parts to assemble the file from, in ascending part number order.
+	// If empty, all of the uploaded parts are assembled.
+	Parts []*CompletedPart `protobuf:"bytes,4,rep,name=parts,proto3" json:"parts,omitempty"`
+	// The expected size of the file in bytes, not checked if 0.
+	Size int64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// The expected hex encoded SHA-256 checksum of the file, not checked if empty.
+	Checksum string `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// The user who completes the upload, checked against the upload's owner if set.
+	Owner string `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
 }
 
-func (x *UploadCompleteResponse) Reset() {
-	*x = UploadCompleteResponse{}
+func (x *UploadCompleteRequest) Reset() {
+	*x = UploadCompleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[9]
+		mi := &file_upload_service_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UploadCompleteResponse) String() string {
+func (x *UploadCompleteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadCompleteResponse) ProtoMessage() {}
+func (*UploadCompleteRequest) ProtoMessage() {}
 
-func (x *UploadCompleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[9]
+func (x *UploadCompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,103 +1051,74 @@ func (x *UploadCompleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadCompleteResponse.ProtoReflect.Descriptor instead.
-func (*UploadCompleteResponse) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use UploadCompleteRequest.ProtoReflect.Descriptor instead.
+func (*UploadCompleteRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UploadCompleteResponse) GetContentLength() int64 {
+func (x *UploadCompleteRequest) GetUploadId() string {
 	if x != nil {
-		return x.ContentLength
+		return x.UploadId
 	}
-	return 0
+	return ""
 }
 
-func (x *UploadCompleteResponse) GetContentType() string {
+func (x *UploadCompleteRequest) GetKey() string {
 	if x != nil {
-		return x.ContentType
+		return x.Key
 	}
 	return ""
 }
 
-// UploadAbortRequest is the request for aborting resumable upload
-type UploadAbortRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// Upload ID generated for resumable upload of a file
-	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
-	// File key to store in S3
-	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	// The bucket to upload the file to
-	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
-}
-
-func (x *UploadAbortRequest) Reset() {
-	*x = UploadAbortRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_upload_service_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *UploadCompleteRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
 	}
+	return ""
 }
 
-func (x *UploadAbortRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*UploadAbortRequest) ProtoMessage() {}
-
-func (x *UploadAbortRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_upload_service_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *UploadCompleteRequest) GetParts() []*CompletedPart {
+	if x != nil {
+		return x.Parts
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use UploadAbortRequest.ProtoReflect.Descriptor instead.
-func (*UploadAbortRequest) Descriptor() ([]byte, []int) {
-	return file_upload_service_proto_rawDescGZIP(), []int{10}
+	return nil
 }
 
-func (x *UploadAbortRequest) GetUploadId() string {
+func (x *UploadCompleteRequest) GetSize() int64 {
 	if x != nil {
-		return x.UploadId
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *UploadAbortRequest) GetKey() string {
+func (x *UploadCompleteRequest) GetChecksum() string {
 	if x != nil {
-		return x.Key
+		return x.Checksum
 	}
 	return ""
 }
 
-func (x *UploadAbortRequest) GetBucket() string {
+func (x *UploadCompleteRequest) GetOwner() string {
 	if x != nil {
-		return x.Bucket
+		return x.Owner
 	}
 	return ""
 }
 
-// UploadAbortResponse is the response for aborting resumable upload
-type UploadAbortResponse struct {
+// CompletedPart is a part of a resumable upload as the client uploaded it.
+type CompletedPart struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status bool `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	// The part's number
+	PartNumber int64 `protobuf:"varint,1,opt,name=partNumber,proto3" json:"partNumber,omitempty"`
+	// The part's ETag, as returned by UploadPart
+	ETag string `protobuf:"bytes,2,opt,name=eTag,proto3" json:"eTag,omitempty"`
 }
 
-func (x *UploadAbortResponse) Reset() {
-	*x = UploadAbortResponse{}
+func (x *CompletedPart) Reset() {
+	*x = CompletedPart{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_upload_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -784,13 +1126,13 @@ func (x *UploadAbortResponse) Reset() {
 	}
 }
 
-func (x *UploadAbortResponse) String() string {
+func (x *CompletedPart) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UploadAbortResponse) ProtoMessage() {}
+func (*CompletedPart) ProtoMessage() {}
 
-func (x *UploadAbortResponse) ProtoReflect() protoreflect.Message {
+func (x *CompletedPart) ProtoReflect() protoreflect.Message {
 	mi := &file_upload_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -802,32 +1144,39 @@ func (x *UploadAbortResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UploadAbortResponse.ProtoReflect.Descriptor instead.
-func (*UploadAbortResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompletedPart.ProtoReflect.Descriptor instead.
+func (*CompletedPart) Descriptor() ([]byte, []int) {
 	return file_upload_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *UploadAbortResponse) GetStatus() bool {
+func (x *CompletedPart) GetPartNumber() int64 {
 	if x != nil {
-		return x.Status
+		return x.PartNumber
 	}
-	return false
+	return 0
 }
 
-// DeleteObjectsRequest is the request for deleting objects.
-type DeleteObjectsRequest struct {
+func (x *CompletedPart) GetETag() string {
+	if x != nil {
+		return x.ETag
+	}
+	return ""
+}
+
+// UploadCompleteResponse is the response for completing resumable upload
+type UploadCompleteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The bucket to delete the objects from.
-	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	// The object keys to be deleted from s3.
-	Keys []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	// The size that the uploaded file
+	ContentLength int64 `protobuf:"varint,1,opt,name=ContentLength,proto3" json:"ContentLength,omitempty"`
+	// The type of the uploaded file
+	ContentType string `protobuf:"bytes,2,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
 }
 
-func (x *DeleteObjectsRequest) Reset() {
-	*x = DeleteObjectsRequest{}
+func (x *UploadCompleteResponse) Reset() {
+	*x = UploadCompleteResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_upload_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -835,13 +1184,13 @@ func (x *DeleteObjectsRequest) Reset() {
 	}
 }
 
-func (x *DeleteObjectsRequest) String() string {
+func (x *UploadCompleteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteObjectsRequest) ProtoMessage() {}
+func (*UploadCompleteResponse) ProtoMessage() {}
 
-func (x *DeleteObjectsRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadCompleteResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_upload_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -853,39 +1202,43 @@ func (x *DeleteObjectsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteObjectsRequest.ProtoReflect.Descriptor instead.
-func (*DeleteObjectsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadCompleteResponse.ProtoReflect.Descriptor instead.
+func (*UploadCompleteResponse) Descriptor() ([]byte, []int) {
 	return file_upload_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DeleteObjectsRequest) GetBucket() string {
+func (x *UploadCompleteResponse) GetContentLength() int64 {
 	if x != nil {
-		return x.Bucket
+		return x.ContentLength
 	}
-	return ""
+	return 0
 }
 
-func (x *DeleteObjectsRequest) GetKeys() []string {
+func (x *UploadCompleteResponse) GetContentType() string {
 	if x != nil {
-		return x.Keys
+		return x.ContentType
 	}
-	return nil
+	return ""
 }
 
-// DeleteObjectsResponse is the response for deleting objects.
-type DeleteObjectsResponse struct {
+// GetUploadStatusRequest is the request for the status of a resumable upload in progress.
+type GetUploadStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The object keys that deleted successfully.
-	Deleted []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
-	// The object keys that failed to delete.
-	Failed []string `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+	// The upload id of the upload
+	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
+	// The key of the upload
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The bucket of the upload
+	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The user who requests the status, checked against the upload's owner if set.
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
 }
 
-func (x *DeleteObjectsResponse) Reset() {
-	*x = DeleteObjectsResponse{}
+func (x *GetUploadStatusRequest) Reset() {
+	*x = GetUploadStatusRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_upload_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -893,13 +1246,13 @@ func (x *DeleteObjectsResponse) Reset() {
 	}
 }
 
-func (x *DeleteObjectsResponse) String() string {
+func (x *GetUploadStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteObjectsResponse) ProtoMessage() {}
+func (*GetUploadStatusRequest) ProtoMessage() {}
 
-func (x *DeleteObjectsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetUploadStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_upload_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -911,43 +1264,59 @@ func (x *DeleteObjectsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteObjectsResponse.ProtoReflect.Descriptor instead.
-func (*DeleteObjectsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUploadStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusRequest) Descriptor() ([]byte, []int) {
 	return file_upload_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *DeleteObjectsResponse) GetDeleted() []string {
+func (x *GetUploadStatusRequest) GetUploadId() string {
 	if x != nil {
-		return x.Deleted
+		return x.UploadId
 	}
-	return nil
+	return ""
 }
 
-func (x *DeleteObjectsResponse) GetFailed() []string {
+func (x *GetUploadStatusRequest) GetKey() string {
 	if x != nil {
-		return x.Failed
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-// CopyObjectRequest is the request for copy object between buckets.
-type CopyObjectRequest struct {
+func (x *GetUploadStatusRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetUploadStatusRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+// UploadedPart is a part of a resumable upload that was uploaded.
+type UploadedPart struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The source bucket of the object.
-	BucketSrc string `protobuf:"bytes,1,opt,name=bucketSrc,proto3" json:"bucketSrc,omitempty"`
-	// The source bucket of the object.
-	BucketDest string `protobuf:"bytes,2,opt,name=bucketDest,proto3" json:"bucketDest,omitempty"`
-	// The object key to be copied.
-	KeySrc string `protobuf:"bytes,3,opt,name=keySrc,proto3" json:"keySrc,omitempty"`
-	// New object key.
-	KeyDest string `protobuf:"bytes,4,opt,name=keyDest,proto3" json:"keyDest,omitempty"`
+	// The part's number
+	PartNumber int64 `protobuf:"varint,1,opt,name=partNumber,proto3" json:"partNumber,omitempty"`
+	// The part's size in bytes
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// The part's ETag
+	ETag string `protobuf:"bytes,3,opt,name=eTag,proto3" json:"eTag,omitempty"`
+	// The time the part was uploaded, in unix seconds
+	LastModified int64 `protobuf:"varint,4,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
+	// The part's verified checksum, unset if the part was uploaded without one
+	Checksum *Checksum `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
 }
 
-func (x *CopyObjectRequest) Reset() {
-	*x = CopyObjectRequest{}
+func (x *UploadedPart) Reset() {
+	*x = UploadedPart{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_upload_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -955,13 +1324,13 @@ func (x *CopyObjectRequest) Reset() {
 	}
 }
 
-func (x *CopyObjectRequest) String() string {
+func (x *UploadedPart) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CopyObjectRequest) ProtoMessage() {}
+func (*UploadedPart) ProtoMessage() {}
 
-func (x *CopyObjectRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadedPart) ProtoReflect() protoreflect.Message {
 	mi := &file_upload_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -973,51 +1342,83 @@ func (x *CopyObjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CopyObjectRequest.ProtoReflect.Descriptor instead.
-func (*CopyObjectRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadedPart.ProtoReflect.Descriptor instead.
+func (*UploadedPart) Descriptor() ([]byte, []int) {
 	return file_upload_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *CopyObjectRequest) GetBucketSrc() string {
+func (x *UploadedPart) GetPartNumber() int64 {
 	if x != nil {
-		return x.BucketSrc
+		return x.PartNumber
 	}
-	return ""
+	return 0
 }
 
-func (x *CopyObjectRequest) GetBucketDest() string {
+func (x *UploadedPart) GetSize() int64 {
 	if x != nil {
-		return x.BucketDest
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *CopyObjectRequest) GetKeySrc() string {
+func (x *UploadedPart) GetETag() string {
 	if x != nil {
-		return x.KeySrc
+		return x.ETag
 	}
 	return ""
 }
 
-func (x *CopyObjectRequest) GetKeyDest() string {
+func (x *UploadedPart) GetLastModified() int64 {
 	if x != nil {
-		return x.KeyDest
+		return x.LastModified
 	}
-	return ""
+	return 0
 }
 
-// CopyObjectResponse is the response for copy an object.
-type CopyObjectResponse struct {
+func (x *UploadedPart) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+// GetUploadStatusResponse is the status of a resumable upload in progress.
+// Responds with a NotFound status if the upload doesn't exist, or was completed or aborted.
+type GetUploadStatusResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The object key that copied successfully.
-	Copied string `protobuf:"bytes,1,opt,name=copied,proto3" json:"copied,omitempty"`
-}
-
-func (x *CopyObjectResponse) Reset() {
-	*x = CopyObjectResponse{}
+	// The upload id of the upload
+	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
+	// The key of the upload
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The bucket of the upload
+	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The content type the upload was initiated with, unset if it's unknown
+	ContentType string `protobuf:"bytes,4,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The metadata the upload was initiated with, unset if it's unknown
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The time the upload was initiated, in unix seconds
+	Initiated int64 `protobuf:"varint,6,opt,name=initiated,proto3" json:"initiated,omitempty"`
+	// The parts uploaded so far, ordered by part number
+	Parts []*UploadedPart `protobuf:"bytes,7,rep,name=parts,proto3" json:"parts,omitempty"`
+	// The total size in bytes of the parts uploaded so far
+	Size int64 `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	// The user who started the upload, unset if it's unknown
+	Owner string `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The total size in bytes the upload was initiated with, unset if it's unknown
+	ExpectedSize int64 `protobuf:"varint,10,opt,name=expectedSize,proto3" json:"expectedSize,omitempty"`
+	// The number of parts the upload was initiated with, unset if it's unknown
+	PartCount int64 `protobuf:"varint,11,opt,name=partCount,proto3" json:"partCount,omitempty"`
+	// The time the upload expires, in unix seconds, unset if it's unknown
+	Expires int64 `protobuf:"varint,12,opt,name=expires,proto3" json:"expires,omitempty"`
+	// The checksum the upload was initiated with, unset if it's unknown
+	Checksum *Checksum `protobuf:"bytes,13,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *GetUploadStatusResponse) Reset() {
+	*x = GetUploadStatusResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_upload_service_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1025,13 +1426,13 @@ func (x *CopyObjectResponse) Reset() {
 	}
 }
 
-func (x *CopyObjectResponse) String() string {
+func (x *GetUploadStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CopyObjectResponse) ProtoMessage() {}
+func (*GetUploadStatusResponse) ProtoMessage() {}
 
-func (x *CopyObjectResponse) ProtoReflect() protoreflect.Message {
+func (x *GetUploadStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_upload_service_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1043,36 +1444,122 @@ func (x *CopyObjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CopyObjectResponse.ProtoReflect.Descriptor instead.
-func (*CopyObjectResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUploadStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusResponse) Descriptor() ([]byte, []int) {
 	return file_upload_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CopyObjectResponse) GetCopied() string {
+func (x *GetUploadStatusResponse) GetUploadId() string {
 	if x != nil {
-		return x.Copied
+		return x.UploadId
 	}
 	return ""
 }
 
-// MoveObjectRequest is the request for move object between buckets.
-type MoveObjectRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *GetUploadStatusResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
 
-	// The source bucket of the object.
-	BucketSrc string `protobuf:"bytes,1,opt,name=bucketSrc,proto3" json:"bucketSrc,omitempty"`
-	// The source bucket of the object.
-	BucketDest string `protobuf:"bytes,2,opt,name=bucketDest,proto3" json:"bucketDest,omitempty"`
-	// The object key to be moved.
-	KeySrc string `protobuf:"bytes,3,opt,name=keySrc,proto3" json:"keySrc,omitempty"`
-	// New object key.
-	KeyDest string `protobuf:"bytes,4,opt,name=keyDest,proto3" json:"keyDest,omitempty"`
+func (x *GetUploadStatusResponse) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
 }
 
-func (x *MoveObjectRequest) Reset() {
-	*x = MoveObjectRequest{}
+func (x *GetUploadStatusResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetUploadStatusResponse) GetInitiated() int64 {
+	if x != nil {
+		return x.Initiated
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetParts() []*UploadedPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *GetUploadStatusResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetExpectedSize() int64 {
+	if x != nil {
+		return x.ExpectedSize
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetPartCount() int64 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+// ListMultipartUploadsRequest is the request for listing the resumable uploads in progress in a bucket.
+type ListMultipartUploadsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bucket of the uploads
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// List only uploads whose key begins with prefix
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// List only uploads initiated more than olderThan seconds ago, unset to list all uploads
+	OlderThan int64 `protobuf:"varint,3,opt,name=olderThan,proto3" json:"olderThan,omitempty"`
+	// The nextPageToken of the previous page, unset for the first page
+	PageToken string `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// The maximal number of uploads in the page, at most 1000, unset for 1000
+	PageSize int64 `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+}
+
+func (x *ListMultipartUploadsRequest) Reset() {
+	*x = ListMultipartUploadsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_upload_service_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1080,13 +1567,13 @@ func (x *MoveObjectRequest) Reset() {
 	}
 }
 
-func (x *MoveObjectRequest) String() string {
+func (x *ListMultipartUploadsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveObjectRequest) ProtoMessage() {}
+func (*ListMultipartUploadsRequest) ProtoMessage() {}
 
-func (x *MoveObjectRequest) ProtoReflect() protoreflect.Message {
+func (x *ListMultipartUploadsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_upload_service_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1098,51 +1585,68 @@ func (x *MoveObjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveObjectRequest.ProtoReflect.Descriptor instead.
-func (*MoveObjectRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListMultipartUploadsRequest.ProtoReflect.Descriptor instead.
+func (*ListMultipartUploadsRequest) Descriptor() ([]byte, []int) {
 	return file_upload_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *MoveObjectRequest) GetBucketSrc() string {
+func (x *ListMultipartUploadsRequest) GetBucket() string {
 	if x != nil {
-		return x.BucketSrc
+		return x.Bucket
 	}
 	return ""
 }
 
-func (x *MoveObjectRequest) GetBucketDest() string {
+func (x *ListMultipartUploadsRequest) GetPrefix() string {
 	if x != nil {
-		return x.BucketDest
+		return x.Prefix
 	}
 	return ""
 }
 
-func (x *MoveObjectRequest) GetKeySrc() string {
+func (x *ListMultipartUploadsRequest) GetOlderThan() int64 {
 	if x != nil {
-		return x.KeySrc
+		return x.OlderThan
 	}
-	return ""
+	return 0
 }
 
-func (x *MoveObjectRequest) GetKeyDest() string {
+func (x *ListMultipartUploadsRequest) GetPageToken() string {
 	if x != nil {
-		return x.KeyDest
+		return x.PageToken
 	}
 	return ""
 }
 
-// MoveObjectResponse is the response for moving an object.
-type MoveObjectResponse struct {
+func (x *ListMultipartUploadsRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// MultipartUploadInfo is a resumable upload in progress.
+type MultipartUploadInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The object keys that moved successfully.
-	Moved string `protobuf:"bytes,1,opt,name=moved,proto3" json:"moved,omitempty"`
+	// The upload id of the upload
+	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
+	// The key of the upload
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The time the upload was initiated, in unix seconds
+	Initiated int64 `protobuf:"varint,3,opt,name=initiated,proto3" json:"initiated,omitempty"`
+	// The content type the upload was initiated with, unset if it's unknown
+	ContentType string `protobuf:"bytes,4,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The user who started the upload, unset if it's unknown
+	Owner string `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"`
+	// The time the upload expires, in unix seconds, unset if it's unknown
+	Expires int64 `protobuf:"varint,6,opt,name=expires,proto3" json:"expires,omitempty"`
 }
 
-func (x *MoveObjectResponse) Reset() {
-	*x = MoveObjectResponse{}
+func (x *MultipartUploadInfo) Reset() {
+	*x = MultipartUploadInfo{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_upload_service_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1150,13 +1654,13 @@ func (x *MoveObjectResponse) Reset() {
 	}
 }
 
-func (x *MoveObjectResponse) String() string {
+func (x *MultipartUploadInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveObjectResponse) ProtoMessage() {}
+func (*MultipartUploadInfo) ProtoMessage() {}
 
-func (x *MoveObjectResponse) ProtoReflect() protoreflect.Message {
+func (x *MultipartUploadInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_upload_service_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1168,256 +1672,4226 @@ func (x *MoveObjectResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveObjectResponse.ProtoReflect.Descriptor instead.
-func (*MoveObjectResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use MultipartUploadInfo.ProtoReflect.Descriptor instead.
+func (*MultipartUploadInfo) Descriptor() ([]byte, []int) {
 	return file_upload_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *MoveObjectResponse) GetMoved() string {
+func (x *MultipartUploadInfo) GetUploadId() string {
 	if x != nil {
-		return x.Moved
+		return x.UploadId
 	}
 	return ""
 }
 
-var File_upload_service_proto protoreflect.FileDescriptor
+func (x *MultipartUploadInfo) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
 
-var file_upload_service_proto_rawDesc = []byte{
-	0x0a, 0x14, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x74,
-	0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75,
-	0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b,
-	0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x22, 0x31, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65,
-	0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xff, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x48, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f,
-	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x3b, 0x0a, 0x0d,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x17, 0x55, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0xe1, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b,
-	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x12, 0x43, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x5a, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e,
-	0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b,
-	0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x22, 0x8d, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x72, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x70, 0x61, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61,
-	0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
-	0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b,
-	0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x22, 0x42, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x5d, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
-	0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62,
-	0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x22, 0x60, 0x0a, 0x16, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d,
-	0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a,
-	0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
-	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x5a, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41,
-	0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
-	0x74, 0x22, 0x2d, 0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x62, 0x6f, 0x72, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x22, 0x42, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b,
-	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
-	0x6b, 0x65, 0x79, 0x73, 0x22, 0x49, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
-	0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22,
-	0x83, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53,
-	0x72, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x53, 0x72, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x73,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x72, 0x63, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x72, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x6b,
-	0x65, 0x79, 0x44, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65,
-	0x79, 0x44, 0x65, 0x73, 0x74, 0x22, 0x2c, 0x0a, 0x12, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a,
-	0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63,
-	0x6f, 0x70, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x70,
-	0x69, 0x65, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x4d, 0x6f, 0x76, 0x65, 0x4f, 0x62, 0x6a, 0x65,
-	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75,
-	0x63, 0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65,
-	0x74, 0x44, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x72,
-	0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x72, 0x63, 0x12,
-	0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73, 0x74, 0x22, 0x2a, 0x0a, 0x12, 0x4d, 0x6f, 0x76,
-	0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x32, 0xb5, 0x05, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x12, 0x48, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x12,
-	0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d,
-	0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x75, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0f, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x12, 0x1e, 0x2e,
-	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c,
-	0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
-	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c,
-	0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x45, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x12, 0x19,
-	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e,
-	0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x50, 0x61, 0x72, 0x74, 0x12, 0x19, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01,
-	0x30, 0x01, 0x12, 0x51, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70,
-	0x6c, 0x65, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41,
-	0x62, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x4e, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
-	0x12, 0x1c, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
-	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x45, 0x0a, 0x0a, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x19, 0x2e,
-	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x4d, 0x6f, 0x76, 0x65, 0x4f, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x12, 0x19, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x6f,
-	0x76, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x4f, 0x62, 0x6a,
-	0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *MultipartUploadInfo) GetInitiated() int64 {
+	if x != nil {
+		return x.Initiated
+	}
+	return 0
 }
 
-var (
-	file_upload_service_proto_rawDescOnce sync.Once
-	file_upload_service_proto_rawDescData = file_upload_service_proto_rawDesc
-)
+func (x *MultipartUploadInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
 
-func file_upload_service_proto_rawDescGZIP() []byte {
-	file_upload_service_proto_rawDescOnce.Do(func() {
-		file_upload_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_upload_service_proto_rawDescData)
-	})
-	return file_upload_service_proto_rawDescData
+func (x *MultipartUploadInfo) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
 }
 
-var file_upload_service_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
-var file_upload_service_proto_goTypes = []interface{}{
-	(*UploadMediaRequest)(nil),      // 0: upload.UploadMediaRequest
-	(*UploadMediaResponse)(nil),     // 1: upload.UploadMediaResponse
-	(*UploadMultipartRequest)(nil),  // 2: upload.UploadMultipartRequest
-	(*UploadMultipartResponse)(nil), // 3: upload.UploadMultipartResponse
-	(*UploadInitRequest)(nil),       // 4: upload.UploadInitRequest
-	(*UploadInitResponse)(nil),      // 5: upload.UploadInitResponse
-	(*UploadPartRequest)(nil),       // 6: upload.UploadPartRequest
-	(*UploadPartResponse)(nil),      // 7: upload.UploadPartResponse
-	(*UploadCompleteRequest)(nil),   // 8: upload.UploadCompleteRequest
-	(*UploadCompleteResponse)(nil),  // 9: upload.UploadCompleteResponse
-	(*UploadAbortRequest)(nil),      // 10: upload.UploadAbortRequest
-	(*UploadAbortResponse)(nil),     // 11: upload.UploadAbortResponse
-	(*DeleteObjectsRequest)(nil),    // 12: upload.DeleteObjectsRequest
-	(*DeleteObjectsResponse)(nil),   // 13: upload.DeleteObjectsResponse
-	(*CopyObjectRequest)(nil),       // 14: upload.CopyObjectRequest
-	(*CopyObjectResponse)(nil),      // 15: upload.CopyObjectResponse
-	(*MoveObjectRequest)(nil),       // 16: upload.MoveObjectRequest
-	(*MoveObjectResponse)(nil),      // 17: upload.MoveObjectResponse
-	nil,                             // 18: upload.UploadMultipartRequest.MetadataEntry
-	nil,                             // 19: upload.UploadInitRequest.MetadataEntry
+func (x *MultipartUploadInfo) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
 }
-var file_upload_service_proto_depIdxs = []int32{
-	18, // 0: upload.UploadMultipartRequest.metadata:type_name -> upload.UploadMultipartRequest.MetadataEntry
-	19, // 1: upload.UploadInitRequest.metadata:type_name -> upload.UploadInitRequest.MetadataEntry
-	0,  // 2: upload.Upload.UploadMedia:input_type -> upload.UploadMediaRequest
-	2,  // 3: upload.Upload.UploadMultipart:input_type -> upload.UploadMultipartRequest
-	4,  // 4: upload.Upload.UploadInit:input_type -> upload.UploadInitRequest
-	6,  // 5: upload.Upload.UploadPart:input_type -> upload.UploadPartRequest
-	8,  // 6: upload.Upload.UploadComplete:input_type -> upload.UploadCompleteRequest
-	10, // 7: upload.Upload.UploadAbort:input_type -> upload.UploadAbortRequest
-	12, // 8: upload.Upload.DeleteObjects:input_type -> upload.DeleteObjectsRequest
-	14, // 9: upload.Upload.CopyObject:input_type -> upload.CopyObjectRequest
-	16, // 10: upload.Upload.MoveObject:input_type -> upload.MoveObjectRequest
-	1,  // 11: upload.Upload.UploadMedia:output_type -> upload.UploadMediaResponse
-	3,  // 12: upload.Upload.UploadMultipart:output_type -> upload.UploadMultipartResponse
-	5,  // 13: upload.Upload.UploadInit:output_type -> upload.UploadInitResponse
-	7,  // 14: upload.Upload.UploadPart:output_type -> upload.UploadPartResponse
-	9,  // 15: upload.Upload.UploadComplete:output_type -> upload.UploadCompleteResponse
-	11, // 16: upload.Upload.UploadAbort:output_type -> upload.UploadAbortResponse
-	13, // 17: upload.Upload.DeleteObjects:output_type -> upload.DeleteObjectsResponse
-	15, // 18: upload.Upload.CopyObject:output_type -> upload.CopyObjectResponse
-	17, // 19: upload.Upload.MoveObject:output_type -> upload.MoveObjectResponse
-	11, // [11:20] is the sub-list for method output_type
-	2,  // [2:11] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+
+// ListMultipartUploadsResponse is a page of the resumable uploads in progress in a bucket.
+type ListMultipartUploadsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The uploads in the page, ordered by key and upload id
+	Uploads []*MultipartUploadInfo `protobuf:"bytes,1,rep,name=uploads,proto3" json:"uploads,omitempty"`
+	// The token of the next page, unset if this is the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
 }
 
-func init() { file_upload_service_proto_init() }
-func file_upload_service_proto_init() {
-	if File_upload_service_proto != nil {
-		return
+func (x *ListMultipartUploadsResponse) Reset() {
+	*x = ListMultipartUploadsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_upload_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadMediaRequest); i {
+}
+
+func (x *ListMultipartUploadsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMultipartUploadsResponse) ProtoMessage() {}
+
+func (x *ListMultipartUploadsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMultipartUploadsResponse.ProtoReflect.Descriptor instead.
+func (*ListMultipartUploadsResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListMultipartUploadsResponse) GetUploads() []*MultipartUploadInfo {
+	if x != nil {
+		return x.Uploads
+	}
+	return nil
+}
+
+func (x *ListMultipartUploadsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// AbortMultipartUploadsRequest is the request for aborting many resumable uploads in a bucket.
+type AbortMultipartUploadsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bucket of the uploads
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The uploads to abort by upload id and key, at most 1000
+	Uploads []*MultipartUploadInfo `protobuf:"bytes,2,rep,name=uploads,proto3" json:"uploads,omitempty"`
+}
+
+func (x *AbortMultipartUploadsRequest) Reset() {
+	*x = AbortMultipartUploadsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortMultipartUploadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMultipartUploadsRequest) ProtoMessage() {}
+
+func (x *AbortMultipartUploadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortMultipartUploadsRequest.ProtoReflect.Descriptor instead.
+func (*AbortMultipartUploadsRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AbortMultipartUploadsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *AbortMultipartUploadsRequest) GetUploads() []*MultipartUploadInfo {
+	if x != nil {
+		return x.Uploads
+	}
+	return nil
+}
+
+// AbortMultipartUploadFailure is a resumable upload that failed to abort.
+type AbortMultipartUploadFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The upload id of the upload
+	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
+	// The key of the upload
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The error of the abort
+	Error *ErrorStatus `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AbortMultipartUploadFailure) Reset() {
+	*x = AbortMultipartUploadFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortMultipartUploadFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMultipartUploadFailure) ProtoMessage() {}
+
+func (x *AbortMultipartUploadFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortMultipartUploadFailure.ProtoReflect.Descriptor instead.
+func (*AbortMultipartUploadFailure) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AbortMultipartUploadFailure) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *AbortMultipartUploadFailure) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AbortMultipartUploadFailure) GetError() *ErrorStatus {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// AbortMultipartUploadsResponse is the response for aborting many resumable uploads.
+type AbortMultipartUploadsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The uploads that were aborted
+	Aborted []*MultipartUploadInfo `protobuf:"bytes,1,rep,name=aborted,proto3" json:"aborted,omitempty"`
+	// The uploads that failed to abort
+	Failed []*AbortMultipartUploadFailure `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+}
+
+func (x *AbortMultipartUploadsResponse) Reset() {
+	*x = AbortMultipartUploadsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortMultipartUploadsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMultipartUploadsResponse) ProtoMessage() {}
+
+func (x *AbortMultipartUploadsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortMultipartUploadsResponse.ProtoReflect.Descriptor instead.
+func (*AbortMultipartUploadsResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AbortMultipartUploadsResponse) GetAborted() []*MultipartUploadInfo {
+	if x != nil {
+		return x.Aborted
+	}
+	return nil
+}
+
+func (x *AbortMultipartUploadsResponse) GetFailed() []*AbortMultipartUploadFailure {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+// UploadAbortRequest is the request for aborting resumable upload
+type UploadAbortRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Upload ID generated for resumable upload of a file
+	UploadId string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
+	// File key to store in S3
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The bucket to upload the file to
+	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The user who aborts the upload, checked against the upload's owner if set.
+	Owner string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (x *UploadAbortRequest) Reset() {
+	*x = UploadAbortRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadAbortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAbortRequest) ProtoMessage() {}
+
+func (x *UploadAbortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAbortRequest.ProtoReflect.Descriptor instead.
+func (*UploadAbortRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UploadAbortRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadAbortRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UploadAbortRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *UploadAbortRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+// UploadAbortResponse is the response for aborting resumable upload
+type UploadAbortResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status bool `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *UploadAbortResponse) Reset() {
+	*x = UploadAbortResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadAbortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAbortResponse) ProtoMessage() {}
+
+func (x *UploadAbortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAbortResponse.ProtoReflect.Descriptor instead.
+func (*UploadAbortResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UploadAbortResponse) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+// DeleteObjectsRequest is the request for deleting objects.
+type DeleteObjectsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bucket to delete the objects from.
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The object keys to be deleted from s3.
+	Keys []string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *DeleteObjectsRequest) Reset() {
+	*x = DeleteObjectsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteObjectsRequest) ProtoMessage() {}
+
+func (x *DeleteObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteObjectsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteObjectsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DeleteObjectsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// DeleteObjectsResponse is the response for deleting objects.
+type DeleteObjectsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The object keys that deleted successfully.
+	Deleted []string `protobuf:"bytes,1,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	// The object keys that failed to delete.
+	Failed []string `protobuf:"bytes,2,rep,name=failed,proto3" json:"failed,omitempty"`
+	// The errors of the object keys that failed to delete, in the same order as failed.
+	Errors []*DeleteError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *DeleteObjectsResponse) Reset() {
+	*x = DeleteObjectsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteObjectsResponse) ProtoMessage() {}
+
+func (x *DeleteObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteObjectsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteObjectsResponse) GetDeleted() []string {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *DeleteObjectsResponse) GetFailed() []string {
+	if x != nil {
+		return x.Failed
+	}
+	return nil
+}
+
+func (x *DeleteObjectsResponse) GetErrors() []*DeleteError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// DeleteError is the error of an object key that failed to delete.
+type DeleteError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The object key that failed to delete.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The storage's error code, e.g. "AccessDenied".
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// The storage's error message.
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeleteError) Reset() {
+	*x = DeleteError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteError) ProtoMessage() {}
+
+func (x *DeleteError) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteError.ProtoReflect.Descriptor instead.
+func (*DeleteError) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteError) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DeleteError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DeleteError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// CopyObjectRequest is the request for copy object between buckets.
+type CopyObjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The source bucket of the object.
+	BucketSrc string `protobuf:"bytes,1,opt,name=bucketSrc,proto3" json:"bucketSrc,omitempty"`
+	// The source bucket of the object.
+	BucketDest string `protobuf:"bytes,2,opt,name=bucketDest,proto3" json:"bucketDest,omitempty"`
+	// The object key to be copied.
+	KeySrc string `protobuf:"bytes,3,opt,name=keySrc,proto3" json:"keySrc,omitempty"`
+	// New object key.
+	KeyDest string `protobuf:"bytes,4,opt,name=keyDest,proto3" json:"keyDest,omitempty"`
+}
+
+func (x *CopyObjectRequest) Reset() {
+	*x = CopyObjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyObjectRequest) ProtoMessage() {}
+
+func (x *CopyObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyObjectRequest.ProtoReflect.Descriptor instead.
+func (*CopyObjectRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CopyObjectRequest) GetBucketSrc() string {
+	if x != nil {
+		return x.BucketSrc
+	}
+	return ""
+}
+
+func (x *CopyObjectRequest) GetBucketDest() string {
+	if x != nil {
+		return x.BucketDest
+	}
+	return ""
+}
+
+func (x *CopyObjectRequest) GetKeySrc() string {
+	if x != nil {
+		return x.KeySrc
+	}
+	return ""
+}
+
+func (x *CopyObjectRequest) GetKeyDest() string {
+	if x != nil {
+		return x.KeyDest
+	}
+	return ""
+}
+
+// CopyObjectResponse is the response for copy an object.
+type CopyObjectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The object key that copied successfully.
+	Copied string `protobuf:"bytes,1,opt,name=copied,proto3" json:"copied,omitempty"`
+	// How the copy was verified against its source.
+	Verification CopyVerification `protobuf:"varint,2,opt,name=verification,proto3,enum=upload.CopyVerification" json:"verification,omitempty"`
+}
+
+func (x *CopyObjectResponse) Reset() {
+	*x = CopyObjectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyObjectResponse) ProtoMessage() {}
+
+func (x *CopyObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyObjectResponse.ProtoReflect.Descriptor instead.
+func (*CopyObjectResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CopyObjectResponse) GetCopied() string {
+	if x != nil {
+		return x.Copied
+	}
+	return ""
+}
+
+func (x *CopyObjectResponse) GetVerification() CopyVerification {
+	if x != nil {
+		return x.Verification
+	}
+	return CopyVerification_COPY_VERIFICATION_UNSPECIFIED
+}
+
+// MoveObjectRequest is the request for move object between buckets.
+type MoveObjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The source bucket of the object.
+	BucketSrc string `protobuf:"bytes,1,opt,name=bucketSrc,proto3" json:"bucketSrc,omitempty"`
+	// The source bucket of the object.
+	BucketDest string `protobuf:"bytes,2,opt,name=bucketDest,proto3" json:"bucketDest,omitempty"`
+	// The object key to be moved.
+	KeySrc string `protobuf:"bytes,3,opt,name=keySrc,proto3" json:"keySrc,omitempty"`
+	// New object key.
+	KeyDest string `protobuf:"bytes,4,opt,name=keyDest,proto3" json:"keyDest,omitempty"`
+}
+
+func (x *MoveObjectRequest) Reset() {
+	*x = MoveObjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveObjectRequest) ProtoMessage() {}
+
+func (x *MoveObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveObjectRequest.ProtoReflect.Descriptor instead.
+func (*MoveObjectRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MoveObjectRequest) GetBucketSrc() string {
+	if x != nil {
+		return x.BucketSrc
+	}
+	return ""
+}
+
+func (x *MoveObjectRequest) GetBucketDest() string {
+	if x != nil {
+		return x.BucketDest
+	}
+	return ""
+}
+
+func (x *MoveObjectRequest) GetKeySrc() string {
+	if x != nil {
+		return x.KeySrc
+	}
+	return ""
+}
+
+func (x *MoveObjectRequest) GetKeyDest() string {
+	if x != nil {
+		return x.KeyDest
+	}
+	return ""
+}
+
+// MoveObjectResponse is the response for moving an object.
+// A move that failed after the object was copied fails with an Aborted status
+// whose ErrorInfo metadata has the move's final state under "moveState".
+type MoveObjectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The object keys that moved successfully.
+	Moved string `protobuf:"bytes,1,opt,name=moved,proto3" json:"moved,omitempty"`
+	// The final state of the move.
+	State MoveState `protobuf:"varint,2,opt,name=state,proto3,enum=upload.MoveState" json:"state,omitempty"`
+	// How the copy of the object was verified against its source.
+	Verification CopyVerification `protobuf:"varint,3,opt,name=verification,proto3,enum=upload.CopyVerification" json:"verification,omitempty"`
+}
+
+func (x *MoveObjectResponse) Reset() {
+	*x = MoveObjectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveObjectResponse) ProtoMessage() {}
+
+func (x *MoveObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveObjectResponse.ProtoReflect.Descriptor instead.
+func (*MoveObjectResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MoveObjectResponse) GetMoved() string {
+	if x != nil {
+		return x.Moved
+	}
+	return ""
+}
+
+func (x *MoveObjectResponse) GetState() MoveState {
+	if x != nil {
+		return x.State
+	}
+	return MoveState_MOVE_STATE_UNSPECIFIED
+}
+
+func (x *MoveObjectResponse) GetVerification() CopyVerification {
+	if x != nil {
+		return x.Verification
+	}
+	return CopyVerification_COPY_VERIFICATION_UNSPECIFIED
+}
+
+// BatchObjectItem is an object to copy or move in a batch.
+type BatchObjectItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The source bucket of the object.
+	BucketSrc string `protobuf:"bytes,1,opt,name=bucketSrc,proto3" json:"bucketSrc,omitempty"`
+	// The destination bucket of the object.
+	BucketDest string `protobuf:"bytes,2,opt,name=bucketDest,proto3" json:"bucketDest,omitempty"`
+	// The object key to be copied or moved.
+	KeySrc string `protobuf:"bytes,3,opt,name=keySrc,proto3" json:"keySrc,omitempty"`
+	// New object key.
+	KeyDest string `protobuf:"bytes,4,opt,name=keyDest,proto3" json:"keyDest,omitempty"`
+}
+
+func (x *BatchObjectItem) Reset() {
+	*x = BatchObjectItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchObjectItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchObjectItem) ProtoMessage() {}
+
+func (x *BatchObjectItem) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchObjectItem.ProtoReflect.Descriptor instead.
+func (*BatchObjectItem) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BatchObjectItem) GetBucketSrc() string {
+	if x != nil {
+		return x.BucketSrc
+	}
+	return ""
+}
+
+func (x *BatchObjectItem) GetBucketDest() string {
+	if x != nil {
+		return x.BucketDest
+	}
+	return ""
+}
+
+func (x *BatchObjectItem) GetKeySrc() string {
+	if x != nil {
+		return x.KeySrc
+	}
+	return ""
+}
+
+func (x *BatchObjectItem) GetKeyDest() string {
+	if x != nil {
+		return x.KeyDest
+	}
+	return ""
+}
+
+// BatchObjectsRequest is the request for copying or moving many objects.
+type BatchObjectsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The objects to copy or move, at most 10000.
+	Items []*BatchObjectItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// The maximal number of objects copied or moved concurrently, unset for the server's limit.
+	// It can't exceed the server's limit.
+	Concurrency int32 `protobuf:"varint,2,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+}
+
+func (x *BatchObjectsRequest) Reset() {
+	*x = BatchObjectsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchObjectsRequest) ProtoMessage() {}
+
+func (x *BatchObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchObjectsRequest.ProtoReflect.Descriptor instead.
+func (*BatchObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BatchObjectsRequest) GetItems() []*BatchObjectItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchObjectsRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+// BatchObjectResult is the result of copying or moving an object in a batch.
+// Results are streamed in the order the objects finished, not in the order of the request.
+type BatchObjectResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The index of the object in the request's items.
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The object that was copied or moved.
+	Item *BatchObjectItem `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	// The final state of a move, unset for copies.
+	State MoveState `protobuf:"varint,3,opt,name=state,proto3,enum=upload.MoveState" json:"state,omitempty"`
+	// The error of an object that failed to copy or move, unset if it succeeded.
+	Error *ErrorStatus `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BatchObjectResult) Reset() {
+	*x = BatchObjectResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchObjectResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchObjectResult) ProtoMessage() {}
+
+func (x *BatchObjectResult) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchObjectResult.ProtoReflect.Descriptor instead.
+func (*BatchObjectResult) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BatchObjectResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchObjectResult) GetItem() *BatchObjectItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *BatchObjectResult) GetState() MoveState {
+	if x != nil {
+		return x.State
+	}
+	return MoveState_MOVE_STATE_UNSPECIFIED
+}
+
+func (x *BatchObjectResult) GetError() *ErrorStatus {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// PrefixRequest is the request for copying or moving every object under a prefix.
+// Each object's key has the source prefix replaced with the destination prefix.
+type PrefixRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The source bucket of the objects.
+	BucketSrc string `protobuf:"bytes,1,opt,name=bucketSrc,proto3" json:"bucketSrc,omitempty"`
+	// The destination bucket of the objects.
+	BucketDest string `protobuf:"bytes,2,opt,name=bucketDest,proto3" json:"bucketDest,omitempty"`
+	// The prefix of the keys of the objects to copy or move.
+	PrefixSrc string `protobuf:"bytes,3,opt,name=prefixSrc,proto3" json:"prefixSrc,omitempty"`
+	// The prefix that replaces prefixSrc in the keys of the copies.
+	// In the same bucket it must not be inside prefixSrc.
+	PrefixDest string `protobuf:"bytes,4,opt,name=prefixDest,proto3" json:"prefixDest,omitempty"`
+	// The continuationToken of the last progress of a previous run, which resumes it
+	// after its last finished page, unset to start from the first object.
+	ContinuationToken string `protobuf:"bytes,5,opt,name=continuationToken,proto3" json:"continuationToken,omitempty"`
+	// The maximal number of objects copied or moved concurrently, unset for the server's limit.
+	// It can't exceed the server's limit.
+	Concurrency int32 `protobuf:"varint,6,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+}
+
+func (x *PrefixRequest) Reset() {
+	*x = PrefixRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixRequest) ProtoMessage() {}
+
+func (x *PrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixRequest.ProtoReflect.Descriptor instead.
+func (*PrefixRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PrefixRequest) GetBucketSrc() string {
+	if x != nil {
+		return x.BucketSrc
+	}
+	return ""
+}
+
+func (x *PrefixRequest) GetBucketDest() string {
+	if x != nil {
+		return x.BucketDest
+	}
+	return ""
+}
+
+func (x *PrefixRequest) GetPrefixSrc() string {
+	if x != nil {
+		return x.PrefixSrc
+	}
+	return ""
+}
+
+func (x *PrefixRequest) GetPrefixDest() string {
+	if x != nil {
+		return x.PrefixDest
+	}
+	return ""
+}
+
+func (x *PrefixRequest) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+func (x *PrefixRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+// DeletePrefixRequest is the request for deleting every object under a prefix.
+type DeletePrefixRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bucket of the objects.
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The prefix of the keys of the objects to delete, required.
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// The continuationToken of the last progress of a previous run, which resumes it
+	// after its last finished page, unset to start from the first object.
+	ContinuationToken string `protobuf:"bytes,3,opt,name=continuationToken,proto3" json:"continuationToken,omitempty"`
+}
+
+func (x *DeletePrefixRequest) Reset() {
+	*x = DeletePrefixRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletePrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePrefixRequest) ProtoMessage() {}
+
+func (x *DeletePrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePrefixRequest.ProtoReflect.Descriptor instead.
+func (*DeletePrefixRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeletePrefixRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DeletePrefixRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *DeletePrefixRequest) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+// PrefixProgress is the progress of a prefix operation, streamed after each page of up to 1000 objects.
+type PrefixProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of objects processed so far in this run, including the failed objects.
+	Processed int64 `protobuf:"varint,1,opt,name=processed,proto3" json:"processed,omitempty"`
+	// The number of objects that failed so far in this run.
+	Failed int64 `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	// The objects of the last page that failed.
+	Failures []*PrefixFailure `protobuf:"bytes,3,rep,name=failures,proto3" json:"failures,omitempty"`
+	// Resumes the operation after the last page if it stops before it's done, unset once it's done.
+	ContinuationToken string `protobuf:"bytes,4,opt,name=continuationToken,proto3" json:"continuationToken,omitempty"`
+	// Whether all of the objects under the prefix were processed.
+	Done bool `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (x *PrefixProgress) Reset() {
+	*x = PrefixProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixProgress) ProtoMessage() {}
+
+func (x *PrefixProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixProgress.ProtoReflect.Descriptor instead.
+func (*PrefixProgress) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PrefixProgress) GetProcessed() int64 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *PrefixProgress) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *PrefixProgress) GetFailures() []*PrefixFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+func (x *PrefixProgress) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+func (x *PrefixProgress) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// PrefixFailure is an object that a prefix operation failed to process.
+type PrefixFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key of the object.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The error of the object.
+	Error *ErrorStatus `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *PrefixFailure) Reset() {
+	*x = PrefixFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixFailure) ProtoMessage() {}
+
+func (x *PrefixFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixFailure.ProtoReflect.Descriptor instead.
+func (*PrefixFailure) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PrefixFailure) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PrefixFailure) GetError() *ErrorStatus {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// DownloadObjectRequest is the request for downloading an object.
+type DownloadObjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// File key to download from S3
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The bucket to download the file from
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Optional byte range to download, in the HTTP Range header format,
+	// for example "bytes=0-1023". If empty, the whole object is downloaded.
+	Range string `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
+}
+
+func (x *DownloadObjectRequest) Reset() {
+	*x = DownloadObjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadObjectRequest) ProtoMessage() {}
+
+func (x *DownloadObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadObjectRequest.ProtoReflect.Descriptor instead.
+func (*DownloadObjectRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DownloadObjectRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DownloadObjectRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DownloadObjectRequest) GetRange() string {
+	if x != nil {
+		return x.Range
+	}
+	return ""
+}
+
+// DownloadObjectResponse is a chunk of a downloaded object.
+// The object's details are only set in the first message of the stream.
+type DownloadObjectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// File data chunk
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// The mime-type of the file.
+	ContentType string `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The size of the downloaded content, which is the range size if a range was requested.
+	ContentLength int64 `protobuf:"varint,3,opt,name=contentLength,proto3" json:"contentLength,omitempty"`
+	// The ETag of the object.
+	ETag string `protobuf:"bytes,4,opt,name=eTag,proto3" json:"eTag,omitempty"`
+	// File metadata
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The range of the downloaded content out of the whole object, if a range was requested.
+	ContentRange string `protobuf:"bytes,6,opt,name=contentRange,proto3" json:"contentRange,omitempty"`
+	// The verified checksum of the whole object, unset if it was uploaded without a checksum.
+	Checksum *Checksum `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *DownloadObjectResponse) Reset() {
+	*x = DownloadObjectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadObjectResponse) ProtoMessage() {}
+
+func (x *DownloadObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadObjectResponse.ProtoReflect.Descriptor instead.
+func (*DownloadObjectResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DownloadObjectResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *DownloadObjectResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DownloadObjectResponse) GetContentLength() int64 {
+	if x != nil {
+		return x.ContentLength
+	}
+	return 0
+}
+
+func (x *DownloadObjectResponse) GetETag() string {
+	if x != nil {
+		return x.ETag
+	}
+	return ""
+}
+
+func (x *DownloadObjectResponse) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *DownloadObjectResponse) GetContentRange() string {
+	if x != nil {
+		return x.ContentRange
+	}
+	return ""
+}
+
+func (x *DownloadObjectResponse) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+// UploadStreamRequest is a message of a streamed upload.
+// The first message of the stream must contain the file's details
+// and the rest of the messages must contain the file's data chunks.
+type UploadStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*UploadStreamRequest_Details
+	//	*UploadStreamRequest_Chunk
+	Data isUploadStreamRequest_Data `protobuf_oneof:"data"`
+}
+
+func (x *UploadStreamRequest) Reset() {
+	*x = UploadStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStreamRequest) ProtoMessage() {}
+
+func (x *UploadStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStreamRequest.ProtoReflect.Descriptor instead.
+func (*UploadStreamRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (m *UploadStreamRequest) GetData() isUploadStreamRequest_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *UploadStreamRequest) GetDetails() *UploadStreamDetails {
+	if x, ok := x.GetData().(*UploadStreamRequest_Details); ok {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *UploadStreamRequest) GetChunk() []byte {
+	if x, ok := x.GetData().(*UploadStreamRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isUploadStreamRequest_Data interface {
+	isUploadStreamRequest_Data()
+}
+
+type UploadStreamRequest_Details struct {
+	// The file's details, sent only in the first message
+	Details *UploadStreamDetails `protobuf:"bytes,1,opt,name=details,proto3,oneof"`
+}
+
+type UploadStreamRequest_Chunk struct {
+	// File data chunk
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadStreamRequest_Details) isUploadStreamRequest_Data() {}
+
+func (*UploadStreamRequest_Chunk) isUploadStreamRequest_Data() {}
+
+// UploadStreamDetails is the details of a file uploaded with UploadStream
+type UploadStreamDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// File key to store in S3
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The bucket to upload the file to
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The mime-type of the file.
+	ContentType string `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// File metadata
+	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The expected checksum of the file, not verified if unset.
+	Checksum *Checksum `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *UploadStreamDetails) Reset() {
+	*x = UploadStreamDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadStreamDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStreamDetails) ProtoMessage() {}
+
+func (x *UploadStreamDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStreamDetails.ProtoReflect.Descriptor instead.
+func (*UploadStreamDetails) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UploadStreamDetails) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *UploadStreamDetails) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *UploadStreamDetails) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *UploadStreamDetails) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadStreamDetails) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+// UploadStreamResponse is the response for streamed upload
+type UploadStreamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The location that the file was uploaded to
+	Location string `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+}
+
+func (x *UploadStreamResponse) Reset() {
+	*x = UploadStreamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadStreamResponse) ProtoMessage() {}
+
+func (x *UploadStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadStreamResponse.ProtoReflect.Descriptor instead.
+func (*UploadStreamResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UploadStreamResponse) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+// ListObjectsRequest is the request for listing the objects in a bucket.
+type ListObjectsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bucket to list the objects of
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Only objects whose key begins with prefix are listed
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Objects whose key contains the delimiter after the prefix are grouped
+	// into a common prefix, for example "/" to list a single folder.
+	Delimiter string `protobuf:"bytes,3,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	// The nextPageToken of the previous page, empty for the first page.
+	PageToken string `protobuf:"bytes,4,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// The maximal number of objects and common prefixes in the page, up to 1000.
+	// If zero, 1000 is used.
+	PageSize int64 `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+}
+
+func (x *ListObjectsRequest) Reset() {
+	*x = ListObjectsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsRequest) ProtoMessage() {}
+
+func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListObjectsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ObjectInfo is the details of a listed object.
+type ObjectInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The object's key
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The size of the object in bytes
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// The ETag of the object
+	ETag string `protobuf:"bytes,3,opt,name=eTag,proto3" json:"eTag,omitempty"`
+	// The time the object was last modified, in unix time seconds
+	LastModified int64 `protobuf:"varint,4,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
+	// The mime-type of the object
+	ContentType string `protobuf:"bytes,5,opt,name=contentType,proto3" json:"contentType,omitempty"`
+}
+
+func (x *ObjectInfo) Reset() {
+	*x = ObjectInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ObjectInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectInfo) ProtoMessage() {}
+
+func (x *ObjectInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectInfo.ProtoReflect.Descriptor instead.
+func (*ObjectInfo) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ObjectInfo) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ObjectInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ObjectInfo) GetETag() string {
+	if x != nil {
+		return x.ETag
+	}
+	return ""
+}
+
+func (x *ObjectInfo) GetLastModified() int64 {
+	if x != nil {
+		return x.LastModified
+	}
+	return 0
+}
+
+func (x *ObjectInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// ListObjectsResponse is a page of the objects in a bucket.
+type ListObjectsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The objects in the page, sorted by their key
+	Objects []*ObjectInfo `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	// The common prefixes of the keys that contain the delimiter, sorted
+	CommonPrefixes []string `protobuf:"bytes,2,rep,name=commonPrefixes,proto3" json:"commonPrefixes,omitempty"`
+	// The token of the next page, empty if this is the last page
+	NextPageToken string `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *ListObjectsResponse) Reset() {
+	*x = ListObjectsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsResponse) ProtoMessage() {}
+
+func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListObjectsResponse) GetObjects() []*ObjectInfo {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetCommonPrefixes() []string {
+	if x != nil {
+		return x.CommonPrefixes
+	}
+	return nil
+}
+
+func (x *ListObjectsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// StatObjectRequest is the request for an object's details.
+type StatObjectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key of the object
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The bucket of the object
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+}
+
+func (x *StatObjectRequest) Reset() {
+	*x = StatObjectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatObjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatObjectRequest) ProtoMessage() {}
+
+func (x *StatObjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatObjectRequest.ProtoReflect.Descriptor instead.
+func (*StatObjectRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *StatObjectRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StatObjectRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// StatObjectResponse is the details of an object.
+type StatObjectResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The size of the object in bytes
+	ContentLength int64 `protobuf:"varint,1,opt,name=contentLength,proto3" json:"contentLength,omitempty"`
+	// The mime-type of the object
+	ContentType string `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The ETag of the object
+	ETag string `protobuf:"bytes,3,opt,name=eTag,proto3" json:"eTag,omitempty"`
+	// The time the object was last modified, in unix time seconds
+	LastModified int64 `protobuf:"varint,4,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
+	// The storage class of the object, for example "STANDARD"
+	StorageClass string `protobuf:"bytes,5,opt,name=storageClass,proto3" json:"storageClass,omitempty"`
+	// The object's user metadata
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The verified checksum of the object, unset if it was uploaded without a checksum.
+	Checksum *Checksum `protobuf:"bytes,7,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *StatObjectResponse) Reset() {
+	*x = StatObjectResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatObjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatObjectResponse) ProtoMessage() {}
+
+func (x *StatObjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatObjectResponse.ProtoReflect.Descriptor instead.
+func (*StatObjectResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *StatObjectResponse) GetContentLength() int64 {
+	if x != nil {
+		return x.ContentLength
+	}
+	return 0
+}
+
+func (x *StatObjectResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *StatObjectResponse) GetETag() string {
+	if x != nil {
+		return x.ETag
+	}
+	return ""
+}
+
+func (x *StatObjectResponse) GetLastModified() int64 {
+	if x != nil {
+		return x.LastModified
+	}
+	return 0
+}
+
+func (x *StatObjectResponse) GetStorageClass() string {
+	if x != nil {
+		return x.StorageClass
+	}
+	return ""
+}
+
+func (x *StatObjectResponse) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *StatObjectResponse) GetChecksum() *Checksum {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+// GeneratePresignedURLRequest is the request for a presigned URL of an object,
+// which lets a client download or upload the object directly in the storage.
+type GeneratePresignedURLRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The HTTP method of the URL, either "GET" to download the object or "PUT" to upload it
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// The bucket of the object
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The key of the object
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// The number of seconds until the URL expires, up to 7 days.
+	// If zero, the URL expires after 15 minutes.
+	ExpiresIn int64 `protobuf:"varint,4,opt,name=expiresIn,proto3" json:"expiresIn,omitempty"`
+	// For PUT, the mime-type the object must be uploaded with.
+	// For GET, the mime-type the object is downloaded with.
+	ContentType string `protobuf:"bytes,5,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// For PUT, the metadata the object must be uploaded with.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *GeneratePresignedURLRequest) Reset() {
+	*x = GeneratePresignedURLRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeneratePresignedURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePresignedURLRequest) ProtoMessage() {}
+
+func (x *GeneratePresignedURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePresignedURLRequest.ProtoReflect.Descriptor instead.
+func (*GeneratePresignedURLRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GeneratePresignedURLRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *GeneratePresignedURLRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GeneratePresignedURLRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *GeneratePresignedURLRequest) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *GeneratePresignedURLRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GeneratePresignedURLRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// GeneratePresignedURLResponse is a presigned URL of an object.
+type GeneratePresignedURLResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The presigned URL
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The headers that must be sent with the request to the URL
+	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The time the URL expires, in unix time seconds
+	Expiration int64 `protobuf:"varint,3,opt,name=expiration,proto3" json:"expiration,omitempty"`
+}
+
+func (x *GeneratePresignedURLResponse) Reset() {
+	*x = GeneratePresignedURLResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeneratePresignedURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratePresignedURLResponse) ProtoMessage() {}
+
+func (x *GeneratePresignedURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratePresignedURLResponse.ProtoReflect.Descriptor instead.
+func (*GeneratePresignedURLResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GeneratePresignedURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GeneratePresignedURLResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *GeneratePresignedURLResponse) GetExpiration() int64 {
+	if x != nil {
+		return x.Expiration
+	}
+	return 0
+}
+
+// ListBucketsRequest is the request for listing all of the buckets.
+type ListBucketsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListBucketsRequest) Reset() {
+	*x = ListBucketsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBucketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketsRequest) ProtoMessage() {}
+
+func (x *ListBucketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketsRequest.ProtoReflect.Descriptor instead.
+func (*ListBucketsRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{50}
+}
+
+// Bucket is a listed bucket.
+type Bucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the bucket
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The time the bucket was created, in unix time seconds
+	CreationDate int64 `protobuf:"varint,2,opt,name=creationDate,proto3" json:"creationDate,omitempty"`
+}
+
+func (x *Bucket) Reset() {
+	*x = Bucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Bucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bucket) ProtoMessage() {}
+
+func (x *Bucket) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
+func (*Bucket) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Bucket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Bucket) GetCreationDate() int64 {
+	if x != nil {
+		return x.CreationDate
+	}
+	return 0
+}
+
+// ListBucketsResponse is the response for listing all of the buckets.
+type ListBucketsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The buckets, sorted by their name
+	Buckets []*Bucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *ListBucketsResponse) Reset() {
+	*x = ListBucketsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBucketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBucketsResponse) ProtoMessage() {}
+
+func (x *ListBucketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBucketsResponse.ProtoReflect.Descriptor instead.
+func (*ListBucketsResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListBucketsResponse) GetBuckets() []*Bucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+// CreateBucketRequest is the request for creating a bucket.
+type CreateBucketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the bucket to create, it is normalized to a valid bucket name
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+}
+
+func (x *CreateBucketRequest) Reset() {
+	*x = CreateBucketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketRequest) ProtoMessage() {}
+
+func (x *CreateBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketRequest.ProtoReflect.Descriptor instead.
+func (*CreateBucketRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CreateBucketRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// CreateBucketResponse is the response for creating a bucket.
+type CreateBucketResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The normalized name of the created bucket
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+}
+
+func (x *CreateBucketResponse) Reset() {
+	*x = CreateBucketResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBucketResponse) ProtoMessage() {}
+
+func (x *CreateBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBucketResponse.ProtoReflect.Descriptor instead.
+func (*CreateBucketResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CreateBucketResponse) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// DeleteBucketRequest is the request for deleting a bucket.
+type DeleteBucketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the bucket to delete
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Delete all of the bucket's objects and abort its multipart uploads before deleting it.
+	// If false, the bucket must be empty.
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *DeleteBucketRequest) Reset() {
+	*x = DeleteBucketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteBucketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBucketRequest) ProtoMessage() {}
+
+func (x *DeleteBucketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBucketRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBucketRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *DeleteBucketRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *DeleteBucketRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// DeleteBucketResponse is the response for deleting a bucket.
+type DeleteBucketResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of objects deleted from the bucket
+	DeletedObjects int64 `protobuf:"varint,1,opt,name=deletedObjects,proto3" json:"deletedObjects,omitempty"`
+	// The number of multipart uploads aborted in the bucket
+	AbortedUploads int64 `protobuf:"varint,2,opt,name=abortedUploads,proto3" json:"abortedUploads,omitempty"`
+}
+
+func (x *DeleteBucketResponse) Reset() {
+	*x = DeleteBucketResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteBucketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBucketResponse) ProtoMessage() {}
+
+func (x *DeleteBucketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBucketResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBucketResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DeleteBucketResponse) GetDeletedObjects() int64 {
+	if x != nil {
+		return x.DeletedObjects
+	}
+	return 0
+}
+
+func (x *DeleteBucketResponse) GetAbortedUploads() int64 {
+	if x != nil {
+		return x.AbortedUploads
+	}
+	return 0
+}
+
+// GetBucketInfoRequest is the request for a bucket's details.
+type GetBucketInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the bucket
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+}
+
+func (x *GetBucketInfoRequest) Reset() {
+	*x = GetBucketInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBucketInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketInfoRequest) ProtoMessage() {}
+
+func (x *GetBucketInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetBucketInfoRequest) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetBucketInfoRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+// GetBucketInfoResponse is the details of a bucket.
+type GetBucketInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The normalized name of the bucket
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The time the bucket was created, in unix time seconds
+	CreationDate int64 `protobuf:"varint,2,opt,name=creationDate,proto3" json:"creationDate,omitempty"`
+	// The number of objects in the bucket
+	ObjectCount int64 `protobuf:"varint,3,opt,name=objectCount,proto3" json:"objectCount,omitempty"`
+	// The total size of the objects in the bucket in bytes
+	Size int64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// The number of in-progress multipart uploads in the bucket
+	UploadCount int64 `protobuf:"varint,5,opt,name=uploadCount,proto3" json:"uploadCount,omitempty"`
+}
+
+func (x *GetBucketInfoResponse) Reset() {
+	*x = GetBucketInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_upload_service_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBucketInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBucketInfoResponse) ProtoMessage() {}
+
+func (x *GetBucketInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_upload_service_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBucketInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetBucketInfoResponse) Descriptor() ([]byte, []int) {
+	return file_upload_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetBucketInfoResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetBucketInfoResponse) GetCreationDate() int64 {
+	if x != nil {
+		return x.CreationDate
+	}
+	return 0
+}
+
+func (x *GetBucketInfoResponse) GetObjectCount() int64 {
+	if x != nil {
+		return x.ObjectCount
+	}
+	return 0
+}
+
+func (x *GetBucketInfoResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetBucketInfoResponse) GetUploadCount() int64 {
+	if x != nil {
+		return x.UploadCount
+	}
+	return 0
+}
+
+var File_upload_service_proto protoreflect.FileDescriptor
+
+var file_upload_service_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xa2,
+	0x01, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x22, 0x59, 0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12,
+	0x37, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x09, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x31,
+	0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0xad, 0x02, 0x0a, 0x16, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x48, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x35, 0x0a, 0x17, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe9, 0x02, 0x0a, 0x11, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x43, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x61,
+	0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x74, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x22, 0xd1, 0x01, 0x0a, 0x11, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x70, 0x61, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0xd1,
+	0x01, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x54, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x65, 0x54, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x3b, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0xd0, 0x01, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x2b, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x22, 0x43, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x50,
+	0x61, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x65, 0x54, 0x61, 0x67, 0x22, 0x60, 0x0a, 0x16, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x74, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22,
+	0xa8, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x50, 0x61, 0x72, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x54, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x65, 0x54, 0x61, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x08,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d,
+	0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x22, 0x87, 0x04, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x49,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2d, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x05, 0x70, 0x61,
+	0x72, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x22, 0x0a,
+	0x0c, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xa5, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x68, 0x61,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x68,
+	0x61, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xb3, 0x01, 0x0a,
+	0x13, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x22, 0x7b, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70,
+	0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x07, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78,
+	0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0x6d, 0x0a, 0x1c, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72,
+	0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x22, 0x76,
+	0x0a, 0x1b, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x93, 0x01, 0x0a, 0x1d, 0x41, 0x62, 0x6f, 0x72, 0x74,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x61, 0x62, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12,
+	0x3b, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x22, 0x70, 0x0a, 0x12,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22, 0x2d,
+	0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x42, 0x0a,
+	0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79,
+	0x73, 0x22, 0x76, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x06,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x4d, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x70,
+	0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x12, 0x1e, 0x0a, 0x0a,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6b, 0x65, 0x79, 0x53, 0x72, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65,
+	0x79, 0x53, 0x72, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73, 0x74, 0x22, 0x6a,
+	0x0a, 0x12, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x0c,
+	0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x18, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x70, 0x79,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x4d,
+	0x6f, 0x76, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x12, 0x1e,
+	0x0a, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x72, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6b, 0x65, 0x79, 0x53, 0x72, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73, 0x74,
+	0x22, 0x91, 0x01, 0x0a, 0x12, 0x4d, 0x6f, 0x76, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x27, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x81, 0x01, 0x0a, 0x0f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x53, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x44, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x72, 0x63,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6b, 0x65, 0x79, 0x53, 0x72, 0x63, 0x12, 0x18,
+	0x0a, 0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6b, 0x65, 0x79, 0x44, 0x65, 0x73, 0x74, 0x22, 0x66, 0x0a, 0x13, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2d, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x22, 0xaa, 0x01, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b, 0x0a, 0x04,
+	0x69, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xdb, 0x01,
+	0x0a, 0x0d, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x72, 0x63, 0x12, 0x1e, 0x0a,
+	0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x72, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x72, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x44, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x44, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x63,
+	0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x73, 0x0a, 0x13, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x12, 0x2c, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63,
+	0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0xbb, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x08, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11,
+	0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f,
+	0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x22, 0x4c,
+	0x0a, 0x0d, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x29, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x57, 0x0a, 0x15,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x22, 0xe3, 0x02, 0x0a, 0x16, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x12,
+	0x0a, 0x04, 0x65, 0x54, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x54,
+	0x61, 0x67, 0x12, 0x48, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6e, 0x0a, 0x13, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x37, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x48, 0x00, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x05, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x93, 0x02, 0x0a, 0x13,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x45, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2e, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x32, 0x0a, 0x14, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9c, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1c, 0x0a, 0x09,
+	0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x0a, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x54, 0x61,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x54, 0x61, 0x67, 0x12, 0x22, 0x0a,
+	0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3d, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x74, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x22, 0xe9, 0x02, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x74, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x54, 0x61, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x54, 0x61, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x22, 0x0a,
+	0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x12, 0x44, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xab, 0x02, 0x0a, 0x1b, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x49, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x4d, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0xd9, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x4b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55,
+	0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x14, 0x0a, 0x12,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x40, 0x0a, 0x06, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x61, 0x74, 0x65, 0x22, 0x3f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x22, 0x2e, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x22, 0x43, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x66, 0x0a, 0x14, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x62, 0x6f,
+	0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x73, 0x22, 0x2e, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x22, 0xa7, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0x58, 0x0a, 0x11, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x12, 0x22, 0x0a, 0x1e, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x53, 0x55, 0x4d, 0x5f, 0x41, 0x4c, 0x47,
+	0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x35, 0x10, 0x01, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x43,
+	0x33, 0x32, 0x43, 0x10, 0x03, 0x2a, 0x6d, 0x0a, 0x10, 0x43, 0x6f, 0x70, 0x79, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x50,
+	0x59, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
+	0x53, 0x49, 0x5a, 0x45, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x53,
+	0x49, 0x5a, 0x45, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x4d, 0x44, 0x35, 0x10, 0x02, 0x12, 0x15, 0x0a,
+	0x11, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x41, 0x4e, 0x44, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x53,
+	0x55, 0x4d, 0x10, 0x03, 0x2a, 0x62, 0x0a, 0x09, 0x4d, 0x6f, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a,
+	0x05, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f,
+	0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x4c, 0x4c, 0x45,
+	0x44, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x55, 0x50, 0x4c,
+	0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x10, 0x04, 0x32, 0xdd, 0x0d, 0x0a, 0x06, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x48, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x12, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x65,
+	0x64, 0x69, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a,
+	0x0f, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74,
+	0x12, 0x1e, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x69,
+	0x74, 0x12, 0x19, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x69, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0a, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x12, 0x19, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x51, 0x0a, 0x0e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x12, 0x19, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x4d, 0x6f, 0x76,
+	0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x19, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4d, 0x6f, 0x76, 0x65,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x53, 0x0a, 0x0e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x12, 0x1d, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
+	0x6f, 0x61, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x28, 0x01, 0x12, 0x48, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45,
+	0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x19, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x14, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x52, 0x4c, 0x12, 0x23, 0x2e,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x52, 0x4c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x10, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x6f, 0x70, 0x79, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x1b,
+	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x10, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x6f, 0x76, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x1b,
+	0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x0a, 0x43, 0x6f,
+	0x70, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x15, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x0a, 0x4d,
+	0x6f, 0x76, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x15, 0x2e, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x0c,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1b, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x14, 0x4c,
+	0x69, 0x73, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x73, 0x12, 0x23, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x66, 0x0a, 0x15, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61,
+	0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x24, 0x2e, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x61, 0x72,
+	0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x70, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xc1, 0x02, 0x0a, 0x0b, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x48, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
+	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1a, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x4b, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x1b, 0x2e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d,
+	0x47, 0x65, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x2e,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_upload_service_proto_rawDescOnce sync.Once
+	file_upload_service_proto_rawDescData = file_upload_service_proto_rawDesc
+)
+
+func file_upload_service_proto_rawDescGZIP() []byte {
+	file_upload_service_proto_rawDescOnce.Do(func() {
+		file_upload_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_upload_service_proto_rawDescData)
+	})
+	return file_upload_service_proto_rawDescData
+}
+
+var file_upload_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_upload_service_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
+var file_upload_service_proto_goTypes = []interface{}{
+	(ChecksumAlgorithm)(0),                // 0: upload.ChecksumAlgorithm
+	(CopyVerification)(0),                 // 1: upload.CopyVerification
+	(MoveState)(0),                        // 2: upload.MoveState
+	(*UploadMediaRequest)(nil),            // 3: upload.UploadMediaRequest
+	(*Checksum)(nil),                      // 4: upload.Checksum
+	(*UploadMediaResponse)(nil),           // 5: upload.UploadMediaResponse
+	(*UploadMultipartRequest)(nil),        // 6: upload.UploadMultipartRequest
+	(*UploadMultipartResponse)(nil),       // 7: upload.UploadMultipartResponse
+	(*UploadInitRequest)(nil),             // 8: upload.UploadInitRequest
+	(*UploadInitResponse)(nil),            // 9: upload.UploadInitResponse
+	(*UploadPartRequest)(nil),             // 10: upload.UploadPartRequest
+	(*UploadPartResponse)(nil),            // 11: upload.UploadPartResponse
+	(*ErrorStatus)(nil),                   // 12: upload.ErrorStatus
+	(*UploadCompleteRequest)(nil),         // 13: upload.UploadCompleteRequest
+	(*CompletedPart)(nil),                 // 14: upload.CompletedPart
+	(*UploadCompleteResponse)(nil),        // 15: upload.UploadCompleteResponse
+	(*GetUploadStatusRequest)(nil),        // 16: upload.GetUploadStatusRequest
+	(*UploadedPart)(nil),                  // 17: upload.UploadedPart
+	(*GetUploadStatusResponse)(nil),       // 18: upload.GetUploadStatusResponse
+	(*ListMultipartUploadsRequest)(nil),   // 19: upload.ListMultipartUploadsRequest
+	(*MultipartUploadInfo)(nil),           // 20: upload.MultipartUploadInfo
+	(*ListMultipartUploadsResponse)(nil),  // 21: upload.ListMultipartUploadsResponse
+	(*AbortMultipartUploadsRequest)(nil),  // 22: upload.AbortMultipartUploadsRequest
+	(*AbortMultipartUploadFailure)(nil),   // 23: upload.AbortMultipartUploadFailure
+	(*AbortMultipartUploadsResponse)(nil), // 24: upload.AbortMultipartUploadsResponse
+	(*UploadAbortRequest)(nil),            // 25: upload.UploadAbortRequest
+	(*UploadAbortResponse)(nil),           // 26: upload.UploadAbortResponse
+	(*DeleteObjectsRequest)(nil),          // 27: upload.DeleteObjectsRequest
+	(*DeleteObjectsResponse)(nil),         // 28: upload.DeleteObjectsResponse
+	(*DeleteError)(nil),                   // 29: upload.DeleteError
+	(*CopyObjectRequest)(nil),             // 30: upload.CopyObjectRequest
+	(*CopyObjectResponse)(nil),            // 31: upload.CopyObjectResponse
+	(*MoveObjectRequest)(nil),             // 32: upload.MoveObjectRequest
+	(*MoveObjectResponse)(nil),            // 33: upload.MoveObjectResponse
+	(*BatchObjectItem)(nil),               // 34: upload.BatchObjectItem
+	(*BatchObjectsRequest)(nil),           // 35: upload.BatchObjectsRequest
+	(*BatchObjectResult)(nil),             // 36: upload.BatchObjectResult
+	(*PrefixRequest)(nil),                 // 37: upload.PrefixRequest
+	(*DeletePrefixRequest)(nil),           // 38: upload.DeletePrefixRequest
+	(*PrefixProgress)(nil),                // 39: upload.PrefixProgress
+	(*PrefixFailure)(nil),                 // 40: upload.PrefixFailure
+	(*DownloadObjectRequest)(nil),         // 41: upload.DownloadObjectRequest
+	(*DownloadObjectResponse)(nil),        // 42: upload.DownloadObjectResponse
+	(*UploadStreamRequest)(nil),           // 43: upload.UploadStreamRequest
+	(*UploadStreamDetails)(nil),           // 44: upload.UploadStreamDetails
+	(*UploadStreamResponse)(nil),          // 45: upload.UploadStreamResponse
+	(*ListObjectsRequest)(nil),            // 46: upload.ListObjectsRequest
+	(*ObjectInfo)(nil),                    // 47: upload.ObjectInfo
+	(*ListObjectsResponse)(nil),           // 48: upload.ListObjectsResponse
+	(*StatObjectRequest)(nil),             // 49: upload.StatObjectRequest
+	(*StatObjectResponse)(nil),            // 50: upload.StatObjectResponse
+	(*GeneratePresignedURLRequest)(nil),   // 51: upload.GeneratePresignedURLRequest
+	(*GeneratePresignedURLResponse)(nil),  // 52: upload.GeneratePresignedURLResponse
+	(*ListBucketsRequest)(nil),            // 53: upload.ListBucketsRequest
+	(*Bucket)(nil),                        // 54: upload.Bucket
+	(*ListBucketsResponse)(nil),           // 55: upload.ListBucketsResponse
+	(*CreateBucketRequest)(nil),           // 56: upload.CreateBucketRequest
+	(*CreateBucketResponse)(nil),          // 57: upload.CreateBucketResponse
+	(*DeleteBucketRequest)(nil),           // 58: upload.DeleteBucketRequest
+	(*DeleteBucketResponse)(nil),          // 59: upload.DeleteBucketResponse
+	(*GetBucketInfoRequest)(nil),          // 60: upload.GetBucketInfoRequest
+	(*GetBucketInfoResponse)(nil),         // 61: upload.GetBucketInfoResponse
+	nil,                                   // 62: upload.UploadMultipartRequest.MetadataEntry
+	nil,                                   // 63: upload.UploadInitRequest.MetadataEntry
+	nil,                                   // 64: upload.GetUploadStatusResponse.MetadataEntry
+	nil,                                   // 65: upload.DownloadObjectResponse.MetadataEntry
+	nil,                                   // 66: upload.UploadStreamDetails.MetadataEntry
+	nil,                                   // 67: upload.StatObjectResponse.MetadataEntry
+	nil,                                   // 68: upload.GeneratePresignedURLRequest.MetadataEntry
+	nil,                                   // 69: upload.GeneratePresignedURLResponse.HeadersEntry
+}
+var file_upload_service_proto_depIdxs = []int32{
+	4,  // 0: upload.UploadMediaRequest.checksum:type_name -> upload.Checksum
+	0,  // 1: upload.Checksum.algorithm:type_name -> upload.ChecksumAlgorithm
+	62, // 2: upload.UploadMultipartRequest.metadata:type_name -> upload.UploadMultipartRequest.MetadataEntry
+	4,  // 3: upload.UploadMultipartRequest.checksum:type_name -> upload.Checksum
+	63, // 4: upload.UploadInitRequest.metadata:type_name -> upload.UploadInitRequest.MetadataEntry
+	4,  // 5: upload.UploadInitRequest.checksum:type_name -> upload.Checksum
+	4,  // 6: upload.UploadPartRequest.checksum:type_name -> upload.Checksum
+	12, // 7: upload.UploadPartResponse.error:type_name -> upload.ErrorStatus
+	14, // 8: upload.UploadCompleteRequest.parts:type_name -> upload.CompletedPart
+	4,  // 9: upload.UploadedPart.checksum:type_name -> upload.Checksum
+	64, // 10: upload.GetUploadStatusResponse.metadata:type_name -> upload.GetUploadStatusResponse.MetadataEntry
+	17, // 11: upload.GetUploadStatusResponse.parts:type_name -> upload.UploadedPart
+	4,  // 12: upload.GetUploadStatusResponse.checksum:type_name -> upload.Checksum
+	20, // 13: upload.ListMultipartUploadsResponse.uploads:type_name -> upload.MultipartUploadInfo
+	20, // 14: upload.AbortMultipartUploadsRequest.uploads:type_name -> upload.MultipartUploadInfo
+	12, // 15: upload.AbortMultipartUploadFailure.error:type_name -> upload.ErrorStatus
+	20, // 16: upload.AbortMultipartUploadsResponse.aborted:type_name -> upload.MultipartUploadInfo
+	23, // 17: upload.AbortMultipartUploadsResponse.failed:type_name -> upload.AbortMultipartUploadFailure
+	29, // 18: upload.DeleteObjectsResponse.errors:type_name -> upload.DeleteError
+	1,  // 19: upload.CopyObjectResponse.verification:type_name -> upload.CopyVerification
+	2,  // 20: upload.MoveObjectResponse.state:type_name -> upload.MoveState
+	1,  // 21: upload.MoveObjectResponse.verification:type_name -> upload.CopyVerification
+	34, // 22: upload.BatchObjectsRequest.items:type_name -> upload.BatchObjectItem
+	34, // 23: upload.BatchObjectResult.item:type_name -> upload.BatchObjectItem
+	2,  // 24: upload.BatchObjectResult.state:type_name -> upload.MoveState
+	12, // 25: upload.BatchObjectResult.error:type_name -> upload.ErrorStatus
+	40, // 26: upload.PrefixProgress.failures:type_name -> upload.PrefixFailure
+	12, // 27: upload.PrefixFailure.error:type_name -> upload.ErrorStatus
+	65, // 28: upload.DownloadObjectResponse.metadata:type_name -> upload.DownloadObjectResponse.MetadataEntry
+	4,  // 29: upload.DownloadObjectResponse.checksum:type_name -> upload.Checksum
+	44, // 30: upload.UploadStreamRequest.details:type_name -> upload.UploadStreamDetails
+	66, // 31: upload.UploadStreamDetails.metadata:type_name -> upload.UploadStreamDetails.MetadataEntry
+	4,  // 32: upload.UploadStreamDetails.checksum:type_name -> upload.Checksum
+	47, // 33: upload.ListObjectsResponse.objects:type_name -> upload.ObjectInfo
+	67, // 34: upload.StatObjectResponse.metadata:type_name -> upload.StatObjectResponse.MetadataEntry
+	4,  // 35: upload.StatObjectResponse.checksum:type_name -> upload.Checksum
+	68, // 36: upload.GeneratePresignedURLRequest.metadata:type_name -> upload.GeneratePresignedURLRequest.MetadataEntry
+	69, // 37: upload.GeneratePresignedURLResponse.headers:type_name -> upload.GeneratePresignedURLResponse.HeadersEntry
+	54, // 38: upload.ListBucketsResponse.buckets:type_name -> upload.Bucket
+	3,  // 39: upload.Upload.UploadMedia:input_type -> upload.UploadMediaRequest
+	6,  // 40: upload.Upload.UploadMultipart:input_type -> upload.UploadMultipartRequest
+	8,  // 41: upload.Upload.UploadInit:input_type -> upload.UploadInitRequest
+	10, // 42: upload.Upload.UploadPart:input_type -> upload.UploadPartRequest
+	13, // 43: upload.Upload.UploadComplete:input_type -> upload.UploadCompleteRequest
+	25, // 44: upload.Upload.UploadAbort:input_type -> upload.UploadAbortRequest
+	27, // 45: upload.Upload.DeleteObjects:input_type -> upload.DeleteObjectsRequest
+	30, // 46: upload.Upload.CopyObject:input_type -> upload.CopyObjectRequest
+	32, // 47: upload.Upload.MoveObject:input_type -> upload.MoveObjectRequest
+	41, // 48: upload.Upload.DownloadObject:input_type -> upload.DownloadObjectRequest
+	43, // 49: upload.Upload.UploadStream:input_type -> upload.UploadStreamRequest
+	46, // 50: upload.Upload.ListObjects:input_type -> upload.ListObjectsRequest
+	49, // 51: upload.Upload.StatObject:input_type -> upload.StatObjectRequest
+	51, // 52: upload.Upload.GeneratePresignedURL:input_type -> upload.GeneratePresignedURLRequest
+	35, // 53: upload.Upload.BatchCopyObjects:input_type -> upload.BatchObjectsRequest
+	35, // 54: upload.Upload.BatchMoveObjects:input_type -> upload.BatchObjectsRequest
+	37, // 55: upload.Upload.CopyPrefix:input_type -> upload.PrefixRequest
+	37, // 56: upload.Upload.MovePrefix:input_type -> upload.PrefixRequest
+	38, // 57: upload.Upload.DeletePrefix:input_type -> upload.DeletePrefixRequest
+	16, // 58: upload.Upload.GetUploadStatus:input_type -> upload.GetUploadStatusRequest
+	19, // 59: upload.Upload.ListMultipartUploads:input_type -> upload.ListMultipartUploadsRequest
+	22, // 60: upload.Upload.AbortMultipartUploads:input_type -> upload.AbortMultipartUploadsRequest
+	53, // 61: upload.BucketAdmin.ListBuckets:input_type -> upload.ListBucketsRequest
+	56, // 62: upload.BucketAdmin.CreateBucket:input_type -> upload.CreateBucketRequest
+	58, // 63: upload.BucketAdmin.DeleteBucket:input_type -> upload.DeleteBucketRequest
+	60, // 64: upload.BucketAdmin.GetBucketInfo:input_type -> upload.GetBucketInfoRequest
+	5,  // 65: upload.Upload.UploadMedia:output_type -> upload.UploadMediaResponse
+	7,  // 66: upload.Upload.UploadMultipart:output_type -> upload.UploadMultipartResponse
+	9,  // 67: upload.Upload.UploadInit:output_type -> upload.UploadInitResponse
+	11, // 68: upload.Upload.UploadPart:output_type -> upload.UploadPartResponse
+	15, // 69: upload.Upload.UploadComplete:output_type -> upload.UploadCompleteResponse
+	26, // 70: upload.Upload.UploadAbort:output_type -> upload.UploadAbortResponse
+	28, // 71: upload.Upload.DeleteObjects:output_type -> upload.DeleteObjectsResponse
+	31, // 72: upload.Upload.CopyObject:output_type -> upload.CopyObjectResponse
+	33, // 73: upload.Upload.MoveObject:output_type -> upload.MoveObjectResponse
+	42, // 74: upload.Upload.DownloadObject:output_type -> upload.DownloadObjectResponse
+	45, // 75: upload.Upload.UploadStream:output_type -> upload.UploadStreamResponse
+	48, // 76: upload.Upload.ListObjects:output_type -> upload.ListObjectsResponse
+	50, // 77: upload.Upload.StatObject:output_type -> upload.StatObjectResponse
+	52, // 78: upload.Upload.GeneratePresignedURL:output_type -> upload.GeneratePresignedURLResponse
+	36, // 79: upload.Upload.BatchCopyObjects:output_type -> upload.BatchObjectResult
+	36, // 80: upload.Upload.BatchMoveObjects:output_type -> upload.BatchObjectResult
+	39, // 81: upload.Upload.CopyPrefix:output_type -> upload.PrefixProgress
+	39, // 82: upload.Upload.MovePrefix:output_type -> upload.PrefixProgress
+	39, // 83: upload.Upload.DeletePrefix:output_type -> upload.PrefixProgress
+	18, // 84: upload.Upload.GetUploadStatus:output_type -> upload.GetUploadStatusResponse
+	21, // 85: upload.Upload.ListMultipartUploads:output_type -> upload.ListMultipartUploadsResponse
+	24, // 86: upload.Upload.AbortMultipartUploads:output_type -> upload.AbortMultipartUploadsResponse
+	55, // 87: upload.BucketAdmin.ListBuckets:output_type -> upload.ListBucketsResponse
+	57, // 88: upload.BucketAdmin.CreateBucket:output_type -> upload.CreateBucketResponse
+	59, // 89: upload.BucketAdmin.DeleteBucket:output_type -> upload.DeleteBucketResponse
+	61, // 90: upload.BucketAdmin.GetBucketInfo:output_type -> upload.GetBucketInfoResponse
+	65, // [65:91] is the sub-list for method output_type
+	39, // [39:65] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_upload_service_proto_init() }
+func file_upload_service_proto_init() {
+	if File_upload_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_upload_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadMediaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Checksum); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadMediaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadMultipartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadMultipartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadInitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadInitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadPartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadPartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadCompleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompletedPart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadCompleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUploadStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadedPart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUploadStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMultipartUploadsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultipartUploadInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMultipartUploadsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortMultipartUploadsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortMultipartUploadFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortMultipartUploadsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAbortRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadAbortResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteObjectsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteObjectsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyObjectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyObjectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveObjectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveObjectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchObjectItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchObjectsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchObjectResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefixRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletePrefixRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefixProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefixFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadObjectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadObjectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1428,8 +5902,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadMediaResponse); i {
+		file_upload_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadStreamDetails); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1440,8 +5914,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadMultipartRequest); i {
+		file_upload_service_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1452,8 +5926,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadMultipartResponse); i {
+		file_upload_service_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListObjectsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1464,8 +5938,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadInitRequest); i {
+		file_upload_service_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ObjectInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1476,8 +5950,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadInitResponse); i {
+		file_upload_service_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListObjectsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1488,8 +5962,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadPartRequest); i {
+		file_upload_service_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatObjectRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1500,8 +5974,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadPartResponse); i {
+		file_upload_service_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatObjectResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1512,8 +5986,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadCompleteRequest); i {
+		file_upload_service_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeneratePresignedURLRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1524,8 +5998,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadCompleteResponse); i {
+		file_upload_service_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeneratePresignedURLResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1536,8 +6010,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadAbortRequest); i {
+		file_upload_service_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBucketsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1548,8 +6022,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UploadAbortResponse); i {
+		file_upload_service_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Bucket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1560,8 +6034,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteObjectsRequest); i {
+		file_upload_service_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBucketsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1572,8 +6046,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteObjectsResponse); i {
+		file_upload_service_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBucketRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1584,8 +6058,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CopyObjectRequest); i {
+		file_upload_service_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBucketResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1596,8 +6070,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CopyObjectResponse); i {
+		file_upload_service_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteBucketRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1608,8 +6082,8 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoveObjectRequest); i {
+		file_upload_service_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteBucketResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1620,8 +6094,20 @@ func file_upload_service_proto_init() {
 				return nil
 			}
 		}
-		file_upload_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoveObjectResponse); i {
+		file_upload_service_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBucketInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_upload_service_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBucketInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1633,18 +6119,23 @@ func file_upload_service_proto_init() {
 			}
 		}
 	}
+	file_upload_service_proto_msgTypes[40].OneofWrappers = []interface{}{
+		(*UploadStreamRequest_Details)(nil),
+		(*UploadStreamRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_upload_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   20,
+			NumEnums:      3,
+			NumMessages:   67,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_upload_service_proto_goTypes,
 		DependencyIndexes: file_upload_service_proto_depIdxs,
+		EnumInfos:         file_upload_service_proto_enumTypes,
 		MessageInfos:      file_upload_service_proto_msgTypes,
 	}.Build()
 	File_upload_service_proto = out.File
@@ -1679,6 +6170,19 @@ type UploadClient interface {
 	DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error)
 	CopyObject(ctx context.Context, in *CopyObjectRequest, opts ...grpc.CallOption) (*CopyObjectResponse, error)
 	MoveObject(ctx context.Context, in *MoveObjectRequest, opts ...grpc.CallOption) (*MoveObjectResponse, error)
+	DownloadObject(ctx context.Context, in *DownloadObjectRequest, opts ...grpc.CallOption) (Upload_DownloadObjectClient, error)
+	UploadStream(ctx context.Context, opts ...grpc.CallOption) (Upload_UploadStreamClient, error)
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
+	StatObject(ctx context.Context, in *StatObjectRequest, opts ...grpc.CallOption) (*StatObjectResponse, error)
+	GeneratePresignedURL(ctx context.Context, in *GeneratePresignedURLRequest, opts ...grpc.CallOption) (*GeneratePresignedURLResponse, error)
+	BatchCopyObjects(ctx context.Context, in *BatchObjectsRequest, opts ...grpc.CallOption) (Upload_BatchCopyObjectsClient, error)
+	BatchMoveObjects(ctx context.Context, in *BatchObjectsRequest, opts ...grpc.CallOption) (Upload_BatchMoveObjectsClient, error)
+	CopyPrefix(ctx context.Context, in *PrefixRequest, opts ...grpc.CallOption) (Upload_CopyPrefixClient, error)
+	MovePrefix(ctx context.Context, in *PrefixRequest, opts ...grpc.CallOption) (Upload_MovePrefixClient, error)
+	DeletePrefix(ctx context.Context, in *DeletePrefixRequest, opts ...grpc.CallOption) (Upload_DeletePrefixClient, error)
+	GetUploadStatus(ctx context.Context, in *GetUploadStatusRequest, opts ...grpc.CallOption) (*GetUploadStatusResponse, error)
+	ListMultipartUploads(ctx context.Context, in *ListMultipartUploadsRequest, opts ...grpc.CallOption) (*ListMultipartUploadsResponse, error)
+	AbortMultipartUploads(ctx context.Context, in *AbortMultipartUploadsRequest, opts ...grpc.CallOption) (*AbortMultipartUploadsResponse, error)
 }
 
 type uploadClient struct {
@@ -1716,76 +6220,356 @@ func (c *uploadClient) UploadInit(ctx context.Context, in *UploadInitRequest, op
 	return out, nil
 }
 
-func (c *uploadClient) UploadPart(ctx context.Context, opts ...grpc.CallOption) (Upload_UploadPartClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[0], "/upload.Upload/UploadPart", opts...)
+func (c *uploadClient) UploadPart(ctx context.Context, opts ...grpc.CallOption) (Upload_UploadPartClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[0], "/upload.Upload/UploadPart", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &uploadUploadPartClient{stream}
+	return x, nil
+}
+
+type Upload_UploadPartClient interface {
+	Send(*UploadPartRequest) error
+	Recv() (*UploadPartResponse, error)
+	grpc.ClientStream
+}
+
+type uploadUploadPartClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadUploadPartClient) Send(m *UploadPartRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *uploadUploadPartClient) Recv() (*UploadPartResponse, error) {
+	m := new(UploadPartResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *uploadClient) UploadComplete(ctx context.Context, in *UploadCompleteRequest, opts ...grpc.CallOption) (*UploadCompleteResponse, error) {
+	out := new(UploadCompleteResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/UploadComplete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) UploadAbort(ctx context.Context, in *UploadAbortRequest, opts ...grpc.CallOption) (*UploadAbortResponse, error) {
+	out := new(UploadAbortResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/UploadAbort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
+	out := new(DeleteObjectsResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/DeleteObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) CopyObject(ctx context.Context, in *CopyObjectRequest, opts ...grpc.CallOption) (*CopyObjectResponse, error) {
+	out := new(CopyObjectResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/CopyObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) MoveObject(ctx context.Context, in *MoveObjectRequest, opts ...grpc.CallOption) (*MoveObjectResponse, error) {
+	out := new(MoveObjectResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/MoveObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) DownloadObject(ctx context.Context, in *DownloadObjectRequest, opts ...grpc.CallOption) (Upload_DownloadObjectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[1], "/upload.Upload/DownloadObject", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &uploadDownloadObjectClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Upload_DownloadObjectClient interface {
+	Recv() (*DownloadObjectResponse, error)
+	grpc.ClientStream
+}
+
+type uploadDownloadObjectClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadDownloadObjectClient) Recv() (*DownloadObjectResponse, error) {
+	m := new(DownloadObjectResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *uploadClient) UploadStream(ctx context.Context, opts ...grpc.CallOption) (Upload_UploadStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[2], "/upload.Upload/UploadStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &uploadUploadStreamClient{stream}
+	return x, nil
+}
+
+type Upload_UploadStreamClient interface {
+	Send(*UploadStreamRequest) error
+	CloseAndRecv() (*UploadStreamResponse, error)
+	grpc.ClientStream
+}
+
+type uploadUploadStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadUploadStreamClient) Send(m *UploadStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *uploadUploadStreamClient) CloseAndRecv() (*UploadStreamResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *uploadClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error) {
+	out := new(ListObjectsResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/ListObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) StatObject(ctx context.Context, in *StatObjectRequest, opts ...grpc.CallOption) (*StatObjectResponse, error) {
+	out := new(StatObjectResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/StatObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) GeneratePresignedURL(ctx context.Context, in *GeneratePresignedURLRequest, opts ...grpc.CallOption) (*GeneratePresignedURLResponse, error) {
+	out := new(GeneratePresignedURLResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/GeneratePresignedURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uploadClient) BatchCopyObjects(ctx context.Context, in *BatchObjectsRequest, opts ...grpc.CallOption) (Upload_BatchCopyObjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[3], "/upload.Upload/BatchCopyObjects", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &uploadBatchCopyObjectsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Upload_BatchCopyObjectsClient interface {
+	Recv() (*BatchObjectResult, error)
+	grpc.ClientStream
+}
+
+type uploadBatchCopyObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadBatchCopyObjectsClient) Recv() (*BatchObjectResult, error) {
+	m := new(BatchObjectResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *uploadClient) BatchMoveObjects(ctx context.Context, in *BatchObjectsRequest, opts ...grpc.CallOption) (Upload_BatchMoveObjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[4], "/upload.Upload/BatchMoveObjects", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &uploadBatchMoveObjectsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Upload_BatchMoveObjectsClient interface {
+	Recv() (*BatchObjectResult, error)
+	grpc.ClientStream
+}
+
+type uploadBatchMoveObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadBatchMoveObjectsClient) Recv() (*BatchObjectResult, error) {
+	m := new(BatchObjectResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *uploadClient) CopyPrefix(ctx context.Context, in *PrefixRequest, opts ...grpc.CallOption) (Upload_CopyPrefixClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[5], "/upload.Upload/CopyPrefix", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &uploadCopyPrefixClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Upload_CopyPrefixClient interface {
+	Recv() (*PrefixProgress, error)
+	grpc.ClientStream
+}
+
+type uploadCopyPrefixClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadCopyPrefixClient) Recv() (*PrefixProgress, error) {
+	m := new(PrefixProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *uploadClient) MovePrefix(ctx context.Context, in *PrefixRequest, opts ...grpc.CallOption) (Upload_MovePrefixClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[6], "/upload.Upload/MovePrefix", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &uploadUploadPartClient{stream}
+	x := &uploadMovePrefixClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
 	return x, nil
 }
 
-type Upload_UploadPartClient interface {
-	Send(*UploadPartRequest) error
-	Recv() (*UploadPartResponse, error)
+type Upload_MovePrefixClient interface {
+	Recv() (*PrefixProgress, error)
 	grpc.ClientStream
 }
 
-type uploadUploadPartClient struct {
+type uploadMovePrefixClient struct {
 	grpc.ClientStream
 }
 
-func (x *uploadUploadPartClient) Send(m *UploadPartRequest) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *uploadUploadPartClient) Recv() (*UploadPartResponse, error) {
-	m := new(UploadPartResponse)
+func (x *uploadMovePrefixClient) Recv() (*PrefixProgress, error) {
+	m := new(PrefixProgress)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *uploadClient) UploadComplete(ctx context.Context, in *UploadCompleteRequest, opts ...grpc.CallOption) (*UploadCompleteResponse, error) {
-	out := new(UploadCompleteResponse)
-	err := c.cc.Invoke(ctx, "/upload.Upload/UploadComplete", in, out, opts...)
+func (c *uploadClient) DeletePrefix(ctx context.Context, in *DeletePrefixRequest, opts ...grpc.CallOption) (Upload_DeletePrefixClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Upload_serviceDesc.Streams[7], "/upload.Upload/DeletePrefix", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &uploadDeletePrefixClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *uploadClient) UploadAbort(ctx context.Context, in *UploadAbortRequest, opts ...grpc.CallOption) (*UploadAbortResponse, error) {
-	out := new(UploadAbortResponse)
-	err := c.cc.Invoke(ctx, "/upload.Upload/UploadAbort", in, out, opts...)
-	if err != nil {
+type Upload_DeletePrefixClient interface {
+	Recv() (*PrefixProgress, error)
+	grpc.ClientStream
+}
+
+type uploadDeletePrefixClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadDeletePrefixClient) Recv() (*PrefixProgress, error) {
+	m := new(PrefixProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *uploadClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
-	out := new(DeleteObjectsResponse)
-	err := c.cc.Invoke(ctx, "/upload.Upload/DeleteObjects", in, out, opts...)
+func (c *uploadClient) GetUploadStatus(ctx context.Context, in *GetUploadStatusRequest, opts ...grpc.CallOption) (*GetUploadStatusResponse, error) {
+	out := new(GetUploadStatusResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/GetUploadStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *uploadClient) CopyObject(ctx context.Context, in *CopyObjectRequest, opts ...grpc.CallOption) (*CopyObjectResponse, error) {
-	out := new(CopyObjectResponse)
-	err := c.cc.Invoke(ctx, "/upload.Upload/CopyObject", in, out, opts...)
+func (c *uploadClient) ListMultipartUploads(ctx context.Context, in *ListMultipartUploadsRequest, opts ...grpc.CallOption) (*ListMultipartUploadsResponse, error) {
+	out := new(ListMultipartUploadsResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/ListMultipartUploads", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *uploadClient) MoveObject(ctx context.Context, in *MoveObjectRequest, opts ...grpc.CallOption) (*MoveObjectResponse, error) {
-	out := new(MoveObjectResponse)
-	err := c.cc.Invoke(ctx, "/upload.Upload/MoveObject", in, out, opts...)
+func (c *uploadClient) AbortMultipartUploads(ctx context.Context, in *AbortMultipartUploadsRequest, opts ...grpc.CallOption) (*AbortMultipartUploadsResponse, error) {
+	out := new(AbortMultipartUploadsResponse)
+	err := c.cc.Invoke(ctx, "/upload.Upload/AbortMultipartUploads", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1808,6 +6592,19 @@ type UploadServer interface {
 	DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error)
 	CopyObject(context.Context, *CopyObjectRequest) (*CopyObjectResponse, error)
 	MoveObject(context.Context, *MoveObjectRequest) (*MoveObjectResponse, error)
+	DownloadObject(*DownloadObjectRequest, Upload_DownloadObjectServer) error
+	UploadStream(Upload_UploadStreamServer) error
+	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
+	StatObject(context.Context, *StatObjectRequest) (*StatObjectResponse, error)
+	GeneratePresignedURL(context.Context, *GeneratePresignedURLRequest) (*GeneratePresignedURLResponse, error)
+	BatchCopyObjects(*BatchObjectsRequest, Upload_BatchCopyObjectsServer) error
+	BatchMoveObjects(*BatchObjectsRequest, Upload_BatchMoveObjectsServer) error
+	CopyPrefix(*PrefixRequest, Upload_CopyPrefixServer) error
+	MovePrefix(*PrefixRequest, Upload_MovePrefixServer) error
+	DeletePrefix(*DeletePrefixRequest, Upload_DeletePrefixServer) error
+	GetUploadStatus(context.Context, *GetUploadStatusRequest) (*GetUploadStatusResponse, error)
+	ListMultipartUploads(context.Context, *ListMultipartUploadsRequest) (*ListMultipartUploadsResponse, error)
+	AbortMultipartUploads(context.Context, *AbortMultipartUploadsRequest) (*AbortMultipartUploadsResponse, error)
 }
 
 // UnimplementedUploadServer can be embedded to have forward compatible implementations.
@@ -1841,177 +6638,476 @@ func (*UnimplementedUploadServer) CopyObject(context.Context, *CopyObjectRequest
 func (*UnimplementedUploadServer) MoveObject(context.Context, *MoveObjectRequest) (*MoveObjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MoveObject not implemented")
 }
+func (*UnimplementedUploadServer) DownloadObject(*DownloadObjectRequest, Upload_DownloadObjectServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadObject not implemented")
+}
+func (*UnimplementedUploadServer) UploadStream(Upload_UploadStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadStream not implemented")
+}
+func (*UnimplementedUploadServer) ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
+}
+func (*UnimplementedUploadServer) StatObject(context.Context, *StatObjectRequest) (*StatObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatObject not implemented")
+}
+func (*UnimplementedUploadServer) GeneratePresignedURL(context.Context, *GeneratePresignedURLRequest) (*GeneratePresignedURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GeneratePresignedURL not implemented")
+}
+func (*UnimplementedUploadServer) BatchCopyObjects(*BatchObjectsRequest, Upload_BatchCopyObjectsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchCopyObjects not implemented")
+}
+func (*UnimplementedUploadServer) BatchMoveObjects(*BatchObjectsRequest, Upload_BatchMoveObjectsServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchMoveObjects not implemented")
+}
+func (*UnimplementedUploadServer) CopyPrefix(*PrefixRequest, Upload_CopyPrefixServer) error {
+	return status.Errorf(codes.Unimplemented, "method CopyPrefix not implemented")
+}
+func (*UnimplementedUploadServer) MovePrefix(*PrefixRequest, Upload_MovePrefixServer) error {
+	return status.Errorf(codes.Unimplemented, "method MovePrefix not implemented")
+}
+func (*UnimplementedUploadServer) DeletePrefix(*DeletePrefixRequest, Upload_DeletePrefixServer) error {
+	return status.Errorf(codes.Unimplemented, "method DeletePrefix not implemented")
+}
+func (*UnimplementedUploadServer) GetUploadStatus(context.Context, *GetUploadStatusRequest) (*GetUploadStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadStatus not implemented")
+}
+func (*UnimplementedUploadServer) ListMultipartUploads(context.Context, *ListMultipartUploadsRequest) (*ListMultipartUploadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMultipartUploads not implemented")
+}
+func (*UnimplementedUploadServer) AbortMultipartUploads(context.Context, *AbortMultipartUploadsRequest) (*AbortMultipartUploadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortMultipartUploads not implemented")
+}
 
 func RegisterUploadServer(s *grpc.Server, srv UploadServer) {
 	s.RegisterService(&_Upload_serviceDesc, srv)
 }
 
-func _Upload_UploadMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UploadMediaRequest)
+func _Upload_UploadMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).UploadMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/UploadMedia",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).UploadMedia(ctx, req.(*UploadMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_UploadMultipart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadMultipartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).UploadMultipart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/UploadMultipart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).UploadMultipart(ctx, req.(*UploadMultipartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_UploadInit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).UploadInit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/UploadInit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).UploadInit(ctx, req.(*UploadInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_UploadPart_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UploadServer).UploadPart(&uploadUploadPartServer{stream})
+}
+
+type Upload_UploadPartServer interface {
+	Send(*UploadPartResponse) error
+	Recv() (*UploadPartRequest, error)
+	grpc.ServerStream
+}
+
+type uploadUploadPartServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadUploadPartServer) Send(m *UploadPartResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *uploadUploadPartServer) Recv() (*UploadPartRequest, error) {
+	m := new(UploadPartRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Upload_UploadComplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadCompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).UploadComplete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/UploadComplete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).UploadComplete(ctx, req.(*UploadCompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_UploadAbort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadAbortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).UploadAbort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/UploadAbort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).UploadAbort(ctx, req.(*UploadAbortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_DeleteObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).DeleteObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/DeleteObjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).DeleteObjects(ctx, req.(*DeleteObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_CopyObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).CopyObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/CopyObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).CopyObject(ctx, req.(*CopyObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_MoveObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).MoveObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/MoveObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).MoveObject(ctx, req.(*MoveObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Upload_DownloadObject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadObjectRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UploadServer).DownloadObject(m, &uploadDownloadObjectServer{stream})
+}
+
+type Upload_DownloadObjectServer interface {
+	Send(*DownloadObjectResponse) error
+	grpc.ServerStream
+}
+
+type uploadDownloadObjectServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadDownloadObjectServer) Send(m *DownloadObjectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Upload_UploadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UploadServer).UploadStream(&uploadUploadStreamServer{stream})
+}
+
+type Upload_UploadStreamServer interface {
+	SendAndClose(*UploadStreamResponse) error
+	Recv() (*UploadStreamRequest, error)
+	grpc.ServerStream
+}
+
+type uploadUploadStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadUploadStreamServer) SendAndClose(m *UploadStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *uploadUploadStreamServer) Recv() (*UploadStreamRequest, error) {
+	m := new(UploadStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Upload_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UploadServer).UploadMedia(ctx, in)
+		return srv.(UploadServer).ListObjects(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/upload.Upload/UploadMedia",
+		FullMethod: "/upload.Upload/ListObjects",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).UploadMedia(ctx, req.(*UploadMediaRequest))
+		return srv.(UploadServer).ListObjects(ctx, req.(*ListObjectsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Upload_UploadMultipart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UploadMultipartRequest)
+func _Upload_StatObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatObjectRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UploadServer).UploadMultipart(ctx, in)
+		return srv.(UploadServer).StatObject(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/upload.Upload/UploadMultipart",
+		FullMethod: "/upload.Upload/StatObject",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).UploadMultipart(ctx, req.(*UploadMultipartRequest))
+		return srv.(UploadServer).StatObject(ctx, req.(*StatObjectRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Upload_UploadInit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UploadInitRequest)
+func _Upload_GeneratePresignedURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeneratePresignedURLRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UploadServer).UploadInit(ctx, in)
+		return srv.(UploadServer).GeneratePresignedURL(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/upload.Upload/UploadInit",
+		FullMethod: "/upload.Upload/GeneratePresignedURL",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).UploadInit(ctx, req.(*UploadInitRequest))
+		return srv.(UploadServer).GeneratePresignedURL(ctx, req.(*GeneratePresignedURLRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Upload_UploadPart_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(UploadServer).UploadPart(&uploadUploadPartServer{stream})
+func _Upload_BatchCopyObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UploadServer).BatchCopyObjects(m, &uploadBatchCopyObjectsServer{stream})
 }
 
-type Upload_UploadPartServer interface {
-	Send(*UploadPartResponse) error
-	Recv() (*UploadPartRequest, error)
+type Upload_BatchCopyObjectsServer interface {
+	Send(*BatchObjectResult) error
 	grpc.ServerStream
 }
 
-type uploadUploadPartServer struct {
+type uploadBatchCopyObjectsServer struct {
 	grpc.ServerStream
 }
 
-func (x *uploadUploadPartServer) Send(m *UploadPartResponse) error {
+func (x *uploadBatchCopyObjectsServer) Send(m *BatchObjectResult) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *uploadUploadPartServer) Recv() (*UploadPartRequest, error) {
-	m := new(UploadPartRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func _Upload_BatchMoveObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return m, nil
+	return srv.(UploadServer).BatchMoveObjects(m, &uploadBatchMoveObjectsServer{stream})
 }
 
-func _Upload_UploadComplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UploadCompleteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UploadServer).UploadComplete(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/upload.Upload/UploadComplete",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).UploadComplete(ctx, req.(*UploadCompleteRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type Upload_BatchMoveObjectsServer interface {
+	Send(*BatchObjectResult) error
+	grpc.ServerStream
 }
 
-func _Upload_UploadAbort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UploadAbortRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UploadServer).UploadAbort(ctx, in)
+type uploadBatchMoveObjectsServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadBatchMoveObjectsServer) Send(m *BatchObjectResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Upload_CopyPrefix_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrefixRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/upload.Upload/UploadAbort",
+	return srv.(UploadServer).CopyPrefix(m, &uploadCopyPrefixServer{stream})
+}
+
+type Upload_CopyPrefixServer interface {
+	Send(*PrefixProgress) error
+	grpc.ServerStream
+}
+
+type uploadCopyPrefixServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadCopyPrefixServer) Send(m *PrefixProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Upload_MovePrefix_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrefixRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).UploadAbort(ctx, req.(*UploadAbortRequest))
+	return srv.(UploadServer).MovePrefix(m, &uploadMovePrefixServer{stream})
+}
+
+type Upload_MovePrefixServer interface {
+	Send(*PrefixProgress) error
+	grpc.ServerStream
+}
+
+type uploadMovePrefixServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadMovePrefixServer) Send(m *PrefixProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Upload_DeletePrefix_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeletePrefixRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(UploadServer).DeletePrefix(m, &uploadDeletePrefixServer{stream})
 }
 
-func _Upload_DeleteObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteObjectsRequest)
+type Upload_DeletePrefixServer interface {
+	Send(*PrefixProgress) error
+	grpc.ServerStream
+}
+
+type uploadDeletePrefixServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadDeletePrefixServer) Send(m *PrefixProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Upload_GetUploadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UploadServer).DeleteObjects(ctx, in)
+		return srv.(UploadServer).GetUploadStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/upload.Upload/DeleteObjects",
+		FullMethod: "/upload.Upload/GetUploadStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).DeleteObjects(ctx, req.(*DeleteObjectsRequest))
+		return srv.(UploadServer).GetUploadStatus(ctx, req.(*GetUploadStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Upload_CopyObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CopyObjectRequest)
+func _Upload_ListMultipartUploads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMultipartUploadsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UploadServer).CopyObject(ctx, in)
+		return srv.(UploadServer).ListMultipartUploads(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/upload.Upload/CopyObject",
+		FullMethod: "/upload.Upload/ListMultipartUploads",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).CopyObject(ctx, req.(*CopyObjectRequest))
+		return srv.(UploadServer).ListMultipartUploads(ctx, req.(*ListMultipartUploadsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Upload_MoveObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MoveObjectRequest)
+func _Upload_AbortMultipartUploads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortMultipartUploadsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UploadServer).MoveObject(ctx, in)
+		return srv.(UploadServer).AbortMultipartUploads(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/upload.Upload/MoveObject",
+		FullMethod: "/upload.Upload/AbortMultipartUploads",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UploadServer).MoveObject(ctx, req.(*MoveObjectRequest))
+		return srv.(UploadServer).AbortMultipartUploads(ctx, req.(*AbortMultipartUploadsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -2052,6 +7148,30 @@ var _Upload_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MoveObject",
 			Handler:    _Upload_MoveObject_Handler,
 		},
+		{
+			MethodName: "ListObjects",
+			Handler:    _Upload_ListObjects_Handler,
+		},
+		{
+			MethodName: "StatObject",
+			Handler:    _Upload_StatObject_Handler,
+		},
+		{
+			MethodName: "GeneratePresignedURL",
+			Handler:    _Upload_GeneratePresignedURL_Handler,
+		},
+		{
+			MethodName: "GetUploadStatus",
+			Handler:    _Upload_GetUploadStatus_Handler,
+		},
+		{
+			MethodName: "ListMultipartUploads",
+			Handler:    _Upload_ListMultipartUploads_Handler,
+		},
+		{
+			MethodName: "AbortMultipartUploads",
+			Handler:    _Upload_AbortMultipartUploads_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2060,6 +7180,221 @@ var _Upload_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DownloadObject",
+			Handler:       _Upload_DownloadObject_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadStream",
+			Handler:       _Upload_UploadStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BatchCopyObjects",
+			Handler:       _Upload_BatchCopyObjects_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BatchMoveObjects",
+			Handler:       _Upload_BatchMoveObjects_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyPrefix",
+			Handler:       _Upload_CopyPrefix_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "MovePrefix",
+			Handler:       _Upload_MovePrefix_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DeletePrefix",
+			Handler:       _Upload_DeletePrefix_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "upload_service.proto",
+}
+
+// BucketAdminClient is the client API for BucketAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BucketAdminClient interface {
+	ListBuckets(ctx context.Context, in *ListBucketsRequest, opts ...grpc.CallOption) (*ListBucketsResponse, error)
+	CreateBucket(ctx context.Context, in *CreateBucketRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error)
+	DeleteBucket(ctx context.Context, in *DeleteBucketRequest, opts ...grpc.CallOption) (*DeleteBucketResponse, error)
+	GetBucketInfo(ctx context.Context, in *GetBucketInfoRequest, opts ...grpc.CallOption) (*GetBucketInfoResponse, error)
+}
+
+type bucketAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBucketAdminClient(cc grpc.ClientConnInterface) BucketAdminClient {
+	return &bucketAdminClient{cc}
+}
+
+func (c *bucketAdminClient) ListBuckets(ctx context.Context, in *ListBucketsRequest, opts ...grpc.CallOption) (*ListBucketsResponse, error) {
+	out := new(ListBucketsResponse)
+	err := c.cc.Invoke(ctx, "/upload.BucketAdmin/ListBuckets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bucketAdminClient) CreateBucket(ctx context.Context, in *CreateBucketRequest, opts ...grpc.CallOption) (*CreateBucketResponse, error) {
+	out := new(CreateBucketResponse)
+	err := c.cc.Invoke(ctx, "/upload.BucketAdmin/CreateBucket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bucketAdminClient) DeleteBucket(ctx context.Context, in *DeleteBucketRequest, opts ...grpc.CallOption) (*DeleteBucketResponse, error) {
+	out := new(DeleteBucketResponse)
+	err := c.cc.Invoke(ctx, "/upload.BucketAdmin/DeleteBucket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bucketAdminClient) GetBucketInfo(ctx context.Context, in *GetBucketInfoRequest, opts ...grpc.CallOption) (*GetBucketInfoResponse, error) {
+	out := new(GetBucketInfoResponse)
+	err := c.cc.Invoke(ctx, "/upload.BucketAdmin/GetBucketInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BucketAdminServer is the server API for BucketAdmin service.
+type BucketAdminServer interface {
+	ListBuckets(context.Context, *ListBucketsRequest) (*ListBucketsResponse, error)
+	CreateBucket(context.Context, *CreateBucketRequest) (*CreateBucketResponse, error)
+	DeleteBucket(context.Context, *DeleteBucketRequest) (*DeleteBucketResponse, error)
+	GetBucketInfo(context.Context, *GetBucketInfoRequest) (*GetBucketInfoResponse, error)
+}
+
+// UnimplementedBucketAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedBucketAdminServer struct {
+}
+
+func (*UnimplementedBucketAdminServer) ListBuckets(context.Context, *ListBucketsRequest) (*ListBucketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBuckets not implemented")
+}
+func (*UnimplementedBucketAdminServer) CreateBucket(context.Context, *CreateBucketRequest) (*CreateBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBucket not implemented")
+}
+func (*UnimplementedBucketAdminServer) DeleteBucket(context.Context, *DeleteBucketRequest) (*DeleteBucketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBucket not implemented")
+}
+func (*UnimplementedBucketAdminServer) GetBucketInfo(context.Context, *GetBucketInfoRequest) (*GetBucketInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBucketInfo not implemented")
+}
+
+func RegisterBucketAdminServer(s *grpc.Server, srv BucketAdminServer) {
+	s.RegisterService(&_BucketAdmin_serviceDesc, srv)
+}
+
+func _BucketAdmin_ListBuckets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBucketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BucketAdminServer).ListBuckets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.BucketAdmin/ListBuckets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BucketAdminServer).ListBuckets(ctx, req.(*ListBucketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BucketAdmin_CreateBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BucketAdminServer).CreateBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.BucketAdmin/CreateBucket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BucketAdminServer).CreateBucket(ctx, req.(*CreateBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BucketAdmin_DeleteBucket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBucketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BucketAdminServer).DeleteBucket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.BucketAdmin/DeleteBucket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BucketAdminServer).DeleteBucket(ctx, req.(*DeleteBucketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BucketAdmin_GetBucketInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBucketInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BucketAdminServer).GetBucketInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.BucketAdmin/GetBucketInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BucketAdminServer).GetBucketInfo(ctx, req.(*GetBucketInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BucketAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "upload.BucketAdmin",
+	HandlerType: (*BucketAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListBuckets",
+			Handler:    _BucketAdmin_ListBuckets_Handler,
+		},
+		{
+			MethodName: "CreateBucket",
+			Handler:    _BucketAdmin_CreateBucket_Handler,
+		},
+		{
+			MethodName: "DeleteBucket",
+			Handler:    _BucketAdmin_DeleteBucket_Handler,
+		},
+		{
+			MethodName: "GetBucketInfo",
+			Handler:    _BucketAdmin_GetBucketInfo_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "upload_service.proto",
 }
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.elastic.co/apm"
+	"go.elastic.co/apm/module/apmhttp"
+)
+
+// statusRecorder is an http.ResponseWriter that records the status of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records status and writes it.
+func (w *statusRecorder) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes data, with a 200 status if no status was written.
+func (w *statusRecorder) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush flushes the response if the underlying writer supports it, for streamed responses.
+func (w *statusRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// WrapHTTPHandler wraps an HTTP handler served along with the grpc server with the same things
+// the grpc server's interceptors do: each request is traced by the APM agent and logged with
+// its status, duration and trace ID, and a panic is recovered, reported to APM and logged,
+// responding with a 500 status if no status was written.
+func WrapHTTPHandler(handler http.Handler, logger *logrus.Logger) http.Handler {
+	logged := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		entry := logger.WithFields(logrus.Fields{
+			"http.method": r.Method,
+			"http.path":   r.URL.Path,
+		})
+
+		// The transaction is nil if the APM agent isn't active.
+		tx := apm.TransactionFromContext(r.Context())
+		if tx != nil {
+			entry = entry.WithField("trace.id", tx.TraceContext().Trace.String())
+		}
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recorder.status == 0 {
+					recorder.WriteHeader(http.StatusInternalServerError)
+				}
+
+				e := apm.DefaultTracer.Recovered(recovered)
+				if tx != nil {
+					e.SetTransaction(tx)
+				}
+				e.Send()
+
+				entry = entry.WithField("panic", recovered)
+			}
+
+			if recorder.status == 0 {
+				recorder.status = http.StatusOK
+			}
+
+			entry = entry.WithFields(logrus.Fields{
+				"http.status":  recorder.status,
+				"http.time_ms": float32(time.Since(start).Nanoseconds()/1000) / 1000,
+			})
+			if recorder.status >= http.StatusInternalServerError {
+				entry.Errorf("finished HTTP call with status %d", recorder.status)
+				return
+			}
+
+			entry.Infof("finished HTTP call with status %d", recorder.status)
+		}()
+
+		handler.ServeHTTP(recorder, r)
+	})
+
+	return apmhttp.Wrap(logged)
+}
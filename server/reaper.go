@@ -0,0 +1,364 @@
+package server
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/meateam/upload-service/bucket"
+	"github.com/meateam/upload-service/object"
+	"github.com/sirupsen/logrus"
+	"go.elastic.co/apm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxAbortUploads is the maximal number of uploads aborted by a single AbortMultipartUploads call.
+	maxAbortUploads = 1000
+
+	// DefaultUploadReaperTTL is the default age of a multipart upload after which it's abandoned.
+	DefaultUploadReaperTTL = 24 * time.Hour
+
+	// DefaultUploadReaperInterval is the default interval between scans for abandoned uploads.
+	DefaultUploadReaperInterval = time.Hour
+)
+
+// Verify that UploadReaper implements apm.MetricsGatherer.
+var _ apm.MetricsGatherer = (*UploadReaper)(nil)
+
+// LeaderElector decides whether this instance of the service is the leader,
+// which is the only instance that runs leader-only work.
+type LeaderElector interface {
+	IsLeader(ctx context.Context) bool
+}
+
+// alwaysLeader is a LeaderElector of a service that runs a single instance, which is always the leader.
+type alwaysLeader struct{}
+
+// IsLeader returns true.
+func (alwaysLeader) IsLeader(context.Context) bool {
+	return true
+}
+
+// hostnameLeader is a LeaderElector whose leader is the instance running on a configured host,
+// such as the first pod of a stateful set.
+type hostnameLeader struct {
+	hostname string
+}
+
+// IsLeader returns true if the instance runs on the leader's host.
+func (l hostnameLeader) IsLeader(context.Context) bool {
+	hostname, err := os.Hostname()
+
+	return err == nil && hostname == l.hostname
+}
+
+// NewLeaderElector returns a LeaderElector whose leader is the instance running on the host
+// named hostname, or a LeaderElector that's always the leader if hostname is empty.
+func NewLeaderElector(hostname string) LeaderElector {
+	if hostname == "" {
+		return alwaysLeader{}
+	}
+
+	return hostnameLeader{hostname: hostname}
+}
+
+// ReapResult is the result of a scan for abandoned uploads.
+type ReapResult struct {
+	// Buckets is the number of buckets scanned.
+	Buckets int
+
+	// Found is the number of abandoned uploads found.
+	Found int
+
+	// Expired is the number of uploads found whose session expired, which are aborted
+	// before the buckets are scanned.
+	Expired int
+
+	// Aborted is the number of abandoned uploads aborted, zero in a dry run.
+	Aborted int
+
+	// Failed is the number of abandoned uploads that failed to abort.
+	Failed int
+
+	// FailedBuckets is the number of buckets whose scan failed.
+	FailedBuckets int
+}
+
+// UploadReaperStats are the running totals of an UploadReaper's scans.
+type UploadReaperStats struct {
+	Scans         int64
+	Found         int64
+	Expired       int64
+	Aborted       int64
+	Failed        int64
+	FailedBuckets int64
+}
+
+// UploadReaper aborts multipart uploads whose session expired, and multipart uploads without
+// a session that were initiated more than a TTL ago in every bucket, which were abandoned by
+// clients that never completed or aborted them.
+type UploadReaper struct {
+	service  *object.Service
+	buckets  *bucket.Service
+	logger   *logrus.Logger
+	ttl      time.Duration
+	interval time.Duration
+	dryRun   bool
+	elector  LeaderElector
+
+	// The following statistics are updated atomically.
+	scans         int64
+	found         int64
+	expired       int64
+	aborted       int64
+	failed        int64
+	failedBuckets int64
+}
+
+// NewUploadReaper creates an UploadReaper that scans for uploads older than ttl once an interval,
+// when elector elects this instance as the leader. In a dry run the abandoned uploads are only
+// logged and counted. A ttl or an interval that isn't positive is set to its default, and
+// a nil elector is always the leader.
+func NewUploadReaper(
+	service *object.Service,
+	logger *logrus.Logger,
+	ttl time.Duration,
+	interval time.Duration,
+	dryRun bool,
+	elector LeaderElector,
+) *UploadReaper {
+	if ttl <= 0 {
+		ttl = DefaultUploadReaperTTL
+	}
+
+	if interval <= 0 {
+		interval = DefaultUploadReaperInterval
+	}
+
+	if elector == nil {
+		elector = alwaysLeader{}
+	}
+
+	return &UploadReaper{
+		service:  service,
+		buckets:  bucket.NewService(service.GetBackend()),
+		logger:   logger,
+		ttl:      ttl,
+		interval: interval,
+		dryRun:   dryRun,
+		elector:  elector,
+	}
+}
+
+// Run scans for abandoned uploads once an interval until ctx is done.
+// Scans are skipped while this instance isn't the leader.
+func (r *UploadReaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if r.elector.IsLeader(ctx) {
+			if _, err := r.Reap(ctx); err != nil {
+				r.logger.Errorf("upload reaper: scan failed: %v", err)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Reap aborts the uploads whose session expired, then scans every bucket for uploads without
+// a session initiated more than the reaper's TTL ago and aborts them, or only logs them in
+// a dry run. Uploads with a session are kept until it expires, whatever their age. Uploads that
+// fail to abort and buckets whose scan fails are logged and counted, and the scan goes on.
+// Returns an error if the expired sessions or the buckets can't be listed.
+func (r *UploadReaper) Reap(ctx context.Context) (*ReapResult, error) {
+	atomic.AddInt64(&r.scans, 1)
+	result := &ReapResult{}
+
+	if err := r.reapExpired(ctx, result); err != nil {
+		return result, err
+	}
+
+	buckets, err := r.buckets.ListBuckets(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	initiatedBefore := time.Now().Add(-r.ttl)
+	for _, b := range buckets {
+		result.Buckets++
+		if err := r.reapBucket(ctx, b.Name, initiatedBefore, result); err != nil {
+			result.FailedBuckets++
+			atomic.AddInt64(&r.failedBuckets, 1)
+			r.logger.Errorf("upload reaper: failed to scan bucket %s: %v", aws.StringValue(b.Name), err)
+		}
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"buckets":       result.Buckets,
+		"found":         result.Found,
+		"expired":       result.Expired,
+		"aborted":       result.Aborted,
+		"failed":        result.Failed,
+		"failedBuckets": result.FailedBuckets,
+		"dryRun":        r.dryRun,
+	}).Infof("upload reaper: scanned for uploads older than %v", r.ttl)
+
+	return result, nil
+}
+
+// reapBucket aborts the uploads in bucket without a session initiated before initiatedBefore,
+// page by page, and adds them to result. Uploads with a session are left to reapExpired.
+func (r *UploadReaper) reapBucket(ctx context.Context, bucketName *string, initiatedBefore time.Time, result *ReapResult) error {
+	pageToken := ""
+	for {
+		list, err := r.service.ListMultipartUploads(ctx, bucketName, nil, initiatedBefore, aws.String(pageToken), nil)
+		if err != nil {
+			return err
+		}
+
+		// Every session has an expiry, so only uploads without a session have no expiry.
+		abandoned := make([]*object.MultipartUpload, 0, len(list.Uploads))
+		for _, upload := range list.Uploads {
+			if upload.Expires.IsZero() {
+				abandoned = append(abandoned, upload)
+			}
+		}
+
+		result.Found += len(abandoned)
+		atomic.AddInt64(&r.found, int64(len(abandoned)))
+
+		// A page has at most 1000 uploads, the most AbortMultipartUploads aborts.
+		if err := r.abortUploads(ctx, bucketName, abandoned, result); err != nil {
+			return err
+		}
+
+		if list.NextPageToken == "" {
+			return nil
+		}
+
+		pageToken = list.NextPageToken
+	}
+}
+
+// reapExpired aborts the uploads whose session expired, bucket by bucket, and adds them to result.
+func (r *UploadReaper) reapExpired(ctx context.Context, result *ReapResult) error {
+	sessions, err := r.service.GetSessionStore().ListExpired(time.Now())
+	if err != nil {
+		return err
+	}
+
+	result.Expired += len(sessions)
+	atomic.AddInt64(&r.expired, int64(len(sessions)))
+
+	uploadsByBucket := make(map[string][]*object.MultipartUpload)
+	for _, uploadSession := range sessions {
+		uploadsByBucket[uploadSession.Bucket] = append(uploadsByBucket[uploadSession.Bucket], &object.MultipartUpload{
+			UploadID:    uploadSession.UploadID,
+			Key:         uploadSession.Key,
+			Initiated:   uploadSession.Created,
+			ContentType: uploadSession.ContentType,
+			Owner:       uploadSession.Owner,
+			Expires:     uploadSession.Expires,
+		})
+	}
+
+	for bucketName, uploads := range uploadsByBucket {
+		for len(uploads) > 0 {
+			chunk := uploads
+			if len(chunk) > maxAbortUploads {
+				chunk = chunk[:maxAbortUploads]
+			}
+			uploads = uploads[len(chunk):]
+
+			if err := r.abortUploads(ctx, aws.String(bucketName), chunk, result); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// abortUploads aborts up to 1000 abandoned uploads in bucket, or only logs them in a dry run,
+// and adds them to result. An upload that no longer exists has its session deleted
+// by the abort, and isn't counted.
+func (r *UploadReaper) abortUploads(ctx context.Context, bucketName *string, uploads []*object.MultipartUpload, result *ReapResult) error {
+	if r.dryRun {
+		for _, upload := range uploads {
+			r.logger.Infof(
+				"upload reaper: dry run, would abort upload %s of %s/%s initiated at %v",
+				upload.UploadID,
+				*bucketName,
+				upload.Key,
+				upload.Initiated,
+			)
+		}
+
+		return nil
+	}
+
+	if len(uploads) == 0 {
+		return nil
+	}
+
+	aborts, err := r.service.AbortMultipartUploads(ctx, bucketName, uploads)
+	if err != nil {
+		return err
+	}
+
+	for _, abort := range aborts {
+		if status.Code(abort.Err) == codes.NotFound {
+			r.logger.Infof("upload reaper: upload %s of %s/%s no longer exists", abort.UploadID, *bucketName, abort.Key)
+			continue
+		}
+
+		if abort.Err != nil {
+			result.Failed++
+			atomic.AddInt64(&r.failed, 1)
+			r.logger.Errorf("upload reaper: failed to abort upload %s of %s/%s: %v", abort.UploadID, *bucketName, abort.Key, abort.Err)
+
+			continue
+		}
+
+		result.Aborted++
+		atomic.AddInt64(&r.aborted, 1)
+		r.logger.Infof("upload reaper: aborted upload %s of %s/%s", abort.UploadID, *bucketName, abort.Key)
+	}
+
+	return nil
+}
+
+// Stats returns the running totals of the reaper's scans.
+func (r *UploadReaper) Stats() UploadReaperStats {
+	return UploadReaperStats{
+		Scans:         atomic.LoadInt64(&r.scans),
+		Found:         atomic.LoadInt64(&r.found),
+		Expired:       atomic.LoadInt64(&r.expired),
+		Aborted:       atomic.LoadInt64(&r.aborted),
+		Failed:        atomic.LoadInt64(&r.failed),
+		FailedBuckets: atomic.LoadInt64(&r.failedBuckets),
+	}
+}
+
+// GatherMetrics adds the reaper's running totals to the APM metrics m.
+func (r *UploadReaper) GatherMetrics(ctx context.Context, m *apm.Metrics) error {
+	stats := r.Stats()
+	m.Add("upload.reaper.scans", nil, float64(stats.Scans))
+	m.Add("upload.reaper.found", nil, float64(stats.Found))
+	m.Add("upload.reaper.expired", nil, float64(stats.Expired))
+	m.Add("upload.reaper.aborted", nil, float64(stats.Aborted))
+	m.Add("upload.reaper.failed", nil, float64(stats.Failed))
+	m.Add("upload.reaper.failed_buckets", nil, float64(stats.FailedBuckets))
+
+	return nil
+}
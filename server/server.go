@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"net"
 	"net/http"
 	"strings"
@@ -12,10 +13,16 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	ilogger "github.com/meateam/elasticsearch-logger"
+	"github.com/meateam/upload-service/bucket"
+	"github.com/meateam/upload-service/gateway"
 	"github.com/meateam/upload-service/object"
 	pb "github.com/meateam/upload-service/proto"
+	uploadsession "github.com/meateam/upload-service/session"
+	"github.com/meateam/upload-service/storage"
+	"github.com/meateam/upload-service/tus"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"go.elastic.co/apm"
 	"go.elastic.co/apm/module/apmhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -32,6 +39,40 @@ const (
 	configS3SecretKey          = "s3_secret_key"
 	configS3Region             = "s3_region"
 	configS3SSL                = "s3_ssl"
+	configStorageBackend       = "storage_backend"
+	configStorageFSRoot        = "storage_fs_root"
+	configPartStreamLimit      = "upload_part_stream_concurrency"
+	configPartGlobalLimit      = "upload_part_global_concurrency"
+	configCopyThreshold        = "copy_multipart_threshold"
+	configCopyPartSize         = "copy_part_size"
+	configBatchConcurrency     = "batch_concurrency"
+	configReaperEnabled        = "upload_reaper_enabled"
+	configReaperTTL            = "upload_reaper_ttl"
+	configReaperInterval       = "upload_reaper_interval"
+	configReaperDryRun         = "upload_reaper_dry_run"
+	configReaperLeader         = "upload_reaper_leader"
+	configSessionStore         = "upload_session_store"
+	configSessionTTL           = "upload_session_ttl"
+	configTusPort              = "tus_port"
+	configTusBasePath          = "tus_base_path"
+	configTusPartSize          = "tus_part_size"
+	configTusMaxSize           = "tus_max_size"
+	configTusMaxBufferSize     = "tus_max_buffer_size"
+	configGatewayPort          = "gateway_port"
+	configGatewayBasePath      = "gateway_base_path"
+	configGatewayMaxPartSize   = "gateway_max_part_size"
+)
+
+// DefaultSessionStorePath is the default path of the bolt database file of the upload sessions.
+const DefaultSessionStorePath = "upload-sessions.db"
+
+// sessionStoreMemory is the `UPLOAD_SESSION_STORE` that keeps the upload sessions in memory.
+const sessionStoreMemory = "memory"
+
+// Storage backends that can be configured with `STORAGE_BACKEND`.
+const (
+	storageBackendS3         = "s3"
+	storageBackendFilesystem = "filesystem"
 )
 
 func init() {
@@ -44,6 +85,28 @@ func init() {
 	viper.SetDefault(configS3SecretKey, "")
 	viper.SetDefault(configS3Region, "us-east-1")
 	viper.SetDefault(configS3SSL, false)
+	viper.SetDefault(configStorageBackend, storageBackendS3)
+	viper.SetDefault(configStorageFSRoot, "./data")
+	viper.SetDefault(configPartStreamLimit, object.DefaultPartStreamConcurrency)
+	viper.SetDefault(configPartGlobalLimit, object.DefaultPartGlobalConcurrency)
+	viper.SetDefault(configCopyThreshold, object.MaxCopyObjectSize)
+	viper.SetDefault(configCopyPartSize, object.DefaultCopyPartSize)
+	viper.SetDefault(configBatchConcurrency, object.DefaultBatchConcurrency)
+	viper.SetDefault(configReaperEnabled, false)
+	viper.SetDefault(configReaperTTL, DefaultUploadReaperTTL)
+	viper.SetDefault(configReaperInterval, DefaultUploadReaperInterval)
+	viper.SetDefault(configReaperDryRun, false)
+	viper.SetDefault(configReaperLeader, "")
+	viper.SetDefault(configSessionStore, DefaultSessionStorePath)
+	viper.SetDefault(configSessionTTL, object.DefaultSessionTTL)
+	viper.SetDefault(configTusPort, "")
+	viper.SetDefault(configTusBasePath, tus.DefaultBasePath)
+	viper.SetDefault(configTusPartSize, tus.DefaultPartSize)
+	viper.SetDefault(configTusMaxSize, int64(tus.DefaultMaxSize))
+	viper.SetDefault(configTusMaxBufferSize, tus.DefaultMaxBufferSize)
+	viper.SetDefault(configGatewayPort, "")
+	viper.SetDefault(configGatewayBasePath, gateway.DefaultBasePath)
+	viper.SetDefault(configGatewayMaxPartSize, int64(gateway.DefaultMaxPartSize))
 	viper.AutomaticEnv()
 }
 
@@ -54,6 +117,17 @@ type UploadServer struct {
 	tcpPort             string
 	healthCheckInterval int
 	objectHandler       *object.Handler
+	bucketHandler       *object.BucketHandler
+	uploadReaper        *UploadReaper
+	tusPort             string
+	tusHandler          *tus.Handler
+	gatewayPort         string
+	gatewayHandler      *gateway.Handler
+
+	// stopReaper stops the upload reaper, and unregisterMetrics unregister the server's
+	// APM metrics gatherers. They're called when the server is stopped.
+	stopReaper        context.CancelFunc
+	unregisterMetrics []func()
 }
 
 // GetHandler returns a copy of the underlying upload handler.
@@ -61,6 +135,26 @@ func (s *UploadServer) GetHandler() *object.Handler {
 	return s.objectHandler
 }
 
+// GetBucketHandler returns the underlying bucket administration handler.
+func (s *UploadServer) GetBucketHandler() *object.BucketHandler {
+	return s.bucketHandler
+}
+
+// GetUploadReaper returns the reaper of abandoned uploads, or nil if it isn't enabled.
+func (s *UploadServer) GetUploadReaper() *UploadReaper {
+	return s.uploadReaper
+}
+
+// GetTusHandler returns the handler of the tus HTTP endpoint, or nil if it isn't enabled.
+func (s *UploadServer) GetTusHandler() *tus.Handler {
+	return s.tusHandler
+}
+
+// GetGatewayHandler returns the handler of the REST/JSON gateway, or nil if it isn't enabled.
+func (s *UploadServer) GetGatewayHandler() *gateway.Handler {
+	return s.gatewayHandler
+}
+
 // Serve accepts incoming connections on the listener `lis`, creating a new
 // ServerTransport and service goroutine for each. The service goroutines
 // read gRPC requests and then call the registered handlers to reply to them.
@@ -69,7 +163,17 @@ func (s *UploadServer) GetHandler() *object.Handler {
 // If `lis` is nil then Serve creates a `net.Listener` with "tcp" network listening
 // on the configured `TCP_PORT`, which defaults to "8080".
 // Serve will return a non-nil error unless Stop or GracefulStop is called.
+// If the tus endpoint is enabled then it's served on the configured `TUS_PORT` in the background,
+// and if the REST/JSON gateway is enabled then it's served on the configured `GATEWAY_PORT`.
 func (s UploadServer) Serve(lis net.Listener) {
+	if s.tusHandler != nil {
+		go s.serveTus()
+	}
+
+	if s.gatewayHandler != nil {
+		go s.serveGateway()
+	}
+
 	listener := lis
 	if lis == nil {
 		l, err := net.Listen("tcp", ":"+s.tcpPort)
@@ -86,10 +190,82 @@ func (s UploadServer) Serve(lis net.Listener) {
 	}
 }
 
+// Stop stops the grpc server the same as grpc.Server.Stop, then stops the upload reaper
+// and the reporting of the server's APM metrics, and closes the upload session store.
+func (s *UploadServer) Stop() {
+	s.Server.Stop()
+	s.shutdown()
+}
+
+// GracefulStop stops the grpc server the same as grpc.Server.GracefulStop, then stops
+// the upload reaper and the reporting of the server's APM metrics, and closes the upload
+// session store.
+func (s *UploadServer) GracefulStop() {
+	s.Server.GracefulStop()
+	s.shutdown()
+}
+
+// shutdown stops the upload reaper, unregisters the server's APM metrics gatherers
+// and closes the upload session store.
+func (s *UploadServer) shutdown() {
+	if s.stopReaper != nil {
+		s.stopReaper()
+	}
+
+	for _, unregister := range s.unregisterMetrics {
+		unregister()
+	}
+	s.unregisterMetrics = nil
+
+	if err := s.objectHandler.GetService().GetSessionStore().Close(); err != nil {
+		s.logger.Errorf("failed to close upload session store: %v", err)
+	}
+}
+
+// serveTus serves the tus HTTP endpoint on the configured `TUS_PORT`.
+func (s UploadServer) serveTus() {
+	s.logger.Infof("listening and serving tus endpoint on port %s", s.tusPort)
+	if err := http.ListenAndServe(":"+s.tusPort, WrapHTTPHandler(s.tusHandler, s.logger)); err != nil {
+		s.logger.Fatalf("%v", err)
+	}
+}
+
+// serveGateway serves the REST/JSON gateway on the configured `GATEWAY_PORT`.
+func (s UploadServer) serveGateway() {
+	s.logger.Infof("listening and serving REST/JSON gateway on port %s", s.gatewayPort)
+	if err := http.ListenAndServe(":"+s.gatewayPort, WrapHTTPHandler(s.gatewayHandler, s.logger)); err != nil {
+		s.logger.Fatalf("%v", err)
+	}
+}
+
 // NewServer configures and creates a grpc.Server instance with the upload service
 // health check service.
 // Configure using environment variables.
 // `HEALTH_CHECK_INTERVAL`: Interval to update serving state of the health check server.
+// `STORAGE_BACKEND`: The storage backend to store objects in, "s3" or "filesystem", defaults to "s3".
+// `STORAGE_FS_ROOT`: The root directory of the filesystem storage backend, defaults to "./data".
+// `UPLOAD_PART_STREAM_CONCURRENCY`: Limit of concurrent part uploads in each UploadPart stream, defaults to 4.
+// `UPLOAD_PART_GLOBAL_CONCURRENCY`: Limit of concurrent part uploads in all UploadPart streams, defaults to 16.
+// `COPY_MULTIPART_THRESHOLD`: Size in bytes above which objects are copied in parts, defaults to and at most 5GB.
+// `COPY_PART_SIZE`: Size in bytes of the parts of a multipart copy, defaults to 512MB.
+// `BATCH_CONCURRENCY`: Limit of concurrent copies or moves in each BatchCopyObjects or BatchMoveObjects request, defaults to 16.
+// `UPLOAD_REAPER_ENABLED`: Enable the background reaper that aborts abandoned multipart uploads, defaults to false.
+// `UPLOAD_REAPER_TTL`: Age after which a multipart upload without a session is abandoned and aborted by the reaper, defaults to 24h.
+// `UPLOAD_REAPER_INTERVAL`: Interval between the reaper's scans of all buckets, defaults to 1h.
+// `UPLOAD_REAPER_DRY_RUN`: Only log and count the abandoned uploads instead of aborting them, defaults to false.
+// `UPLOAD_REAPER_LEADER`: Hostname of the only instance that runs the reaper, defaults to every instance.
+// `UPLOAD_SESSION_STORE`: Path of the bolt database file of the upload sessions, defaults to "upload-sessions.db".
+// "memory" keeps them in memory, where they're lost on restart and aren't shared with other instances,
+// so the owner checks of their uploads pass for every user.
+// `UPLOAD_SESSION_TTL`: Time until a multipart upload expires if it's initiated without a TTL, defaults to 24h.
+// `TUS_PORT`: TCP port on which the tus resumable upload HTTP endpoint would serve on, defaults to disabled.
+// `TUS_BASE_PATH`: Path of the tus upload creation URL, defaults to "/files/".
+// `TUS_PART_SIZE`: Size in bytes of the parts tus uploads are buffered into, defaults to 5MB.
+// `TUS_MAX_SIZE`: Maximal size in bytes of a tus upload, defaults to 5TB.
+// `TUS_MAX_BUFFER_SIZE`: Maximal total size in bytes of the data buffered by tus uploads, defaults to 1GB.
+// `GATEWAY_PORT`: TCP port on which the REST/JSON gateway of the upload RPCs would serve on, defaults to disabled.
+// `GATEWAY_BASE_PATH`: Path under which the gateway serves each RPC by its name, defaults to "/v1/".
+// `GATEWAY_MAX_PART_SIZE`: Maximal size in bytes of a part uploaded by the gateway's UploadPart, defaults to 5GB.
 // `S3_ACCESS_KEY`: S3 accress key to connect with s3 backend.
 // `S3_SECRET_KEY`: S3 secret key to connect with s3 backend.
 // `S3_ENDPOINT`: S3 endpoint of s3 backend to connect to.
@@ -98,38 +274,35 @@ func (s UploadServer) Serve(lis net.Listener) {
 // `S3_SSL`: Enable or Disable SSL on S3 connection.
 // `TCP_PORT`: TCP port on which the grpc server would serve on.
 func NewServer(logger *logrus.Logger) *UploadServer {
-	// Configuration variables
-	s3AccessKey := viper.GetString(configS3AccessKey)
-	s3SecretKey := viper.GetString(configS3SecretKey)
-	s3Endpoint := viper.GetString(configS3Endpoint)
-	s3Token := viper.GetString(configS3Token)
-	s3Region := viper.GetString(configS3Region)
-	s3SSL := viper.GetBool(configS3SSL)
-
-	// Configure to use S3 Server
-	s3Config := &aws.Config{
-		Credentials:      credentials.NewStaticCredentials(s3AccessKey, s3SecretKey, s3Token),
-		Endpoint:         aws.String(s3Endpoint),
-		Region:           aws.String(s3Region),
-		DisableSSL:       aws.Bool(!s3SSL),
-		S3ForcePathStyle: aws.Bool(true),
-		HTTPClient:       apmhttp.WrapClient(http.DefaultClient),
+	// If no logger is given, create a new default logger for the server.
+	if logger == nil {
+		logger = ilogger.NewLogger()
 	}
 
+	return NewServerWithBackend(logger, newStorageBackend(logger))
+}
+
+// NewServerWithBackend configures and creates a grpc.Server instance with the upload service
+// health check service, which stores objects in the given storage backend.
+// It is configured the same as NewServer, except for the storage backend configuration.
+func NewServerWithBackend(logger *logrus.Logger, backend storage.Backend) *UploadServer {
 	// If no logger is given, create a new default logger for the server.
 	if logger == nil {
 		logger = ilogger.NewLogger()
 	}
 
-	// Open a session to s3.
-	newSession, err := session.NewSession(s3Config)
-	if err != nil {
-		logger.Fatalf(err.Error())
-	}
-	logger.Infof("connected to S3 - %s", s3Endpoint)
+	return NewServerWithSessionStore(logger, backend, newSessionStore(logger))
+}
 
-	// Create a client from the s3 session.
-	s3Client := s3.New(newSession)
+// NewServerWithSessionStore configures and creates a grpc.Server instance with the upload service
+// health check service, which stores objects in the given storage backend and keeps its upload
+// sessions in sessions. It is configured the same as NewServerWithBackend, except for the
+// session store configuration. sessions is closed when the server is stopped.
+func NewServerWithSessionStore(logger *logrus.Logger, backend storage.Backend, sessions uploadsession.Store) *UploadServer {
+	// If no logger is given, create a new default logger for the server.
+	if logger == nil {
+		logger = ilogger.NewLogger()
+	}
 
 	// Set up grpc server opts with logger interceptor.
 	serverOpts := append(
@@ -141,13 +314,37 @@ func NewServer(logger *logrus.Logger) *UploadServer {
 		serverOpts...,
 	)
 
+	// Create a limiter of concurrent part uploads and report its statistics as APM metrics.
+	partLimiter := object.NewPartLimiter(
+		viper.GetInt(configPartStreamLimit),
+		viper.GetInt(configPartGlobalLimit),
+	)
+	unregisterPartLimiter := apm.DefaultTracer.RegisterMetricsGatherer(partLimiter)
+
+	// Create an upload service that keeps its upload sessions in the given store.
+	objectService := object.NewServiceWithMultipartCopy(
+		backend,
+		viper.GetInt64(configCopyThreshold),
+		viper.GetInt64(configCopyPartSize),
+	)
+	objectService.SetSessionStore(sessions, viper.GetDuration(configSessionTTL))
+
 	// Create a upload handler and register it on the grpc server.
-	objectHandler := object.NewHandler(
-		object.NewService(s3Client),
+	objectHandler := object.NewHandlerWithPartLimiter(
+		objectService,
 		logger,
+		partLimiter,
 	)
+	objectHandler.SetBatchConcurrency(viper.GetInt(configBatchConcurrency))
 	pb.RegisterUploadServer(grpcServer, objectHandler)
 
+	// Create a bucket administration handler and register it on the grpc server.
+	bucketHandler := object.NewBucketHandler(
+		bucket.NewService(backend),
+		logger,
+	)
+	pb.RegisterBucketAdminServer(grpcServer, bucketHandler)
+
 	// Create a health server and register it on the grpc server.
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
@@ -158,14 +355,136 @@ func NewServer(logger *logrus.Logger) *UploadServer {
 		tcpPort:             viper.GetString(configPort),
 		healthCheckInterval: viper.GetInt(configHealthCheckInterval),
 		objectHandler:       objectHandler,
+		bucketHandler:       bucketHandler,
+		unregisterMetrics:   []func(){unregisterPartLimiter},
+	}
+
+	// Create a tus handler of resumable uploads over HTTP, served along with the grpc server.
+	if tusPort := viper.GetString(configTusPort); tusPort != "" {
+		uploadServer.tusPort = tusPort
+		uploadServer.tusHandler = tus.NewHandler(
+			objectHandler.GetService(),
+			logger,
+			viper.GetString(configTusBasePath),
+			viper.GetInt64(configTusPartSize),
+			viper.GetInt64(configTusMaxSize),
+		)
+		uploadServer.tusHandler.SetMaxBufferSize(viper.GetInt64(configTusMaxBufferSize))
+	}
+
+	// Create a REST/JSON gateway to the upload handler, served along with the grpc server.
+	if gatewayPort := viper.GetString(configGatewayPort); gatewayPort != "" {
+		uploadServer.gatewayPort = gatewayPort
+		uploadServer.gatewayHandler = gateway.NewHandler(
+			objectHandler,
+			logger,
+			viper.GetString(configGatewayBasePath),
+		)
+		uploadServer.gatewayHandler.SetMaxPartSize(viper.GetInt64(configGatewayMaxPartSize))
 	}
 
 	// Health check validation goroutine worker.
 	go uploadServer.healthCheckWorker(healthServer)
 
+	// Create a reaper of abandoned uploads, report its totals as APM metrics and run it in the background.
+	if viper.GetBool(configReaperEnabled) {
+		uploadServer.uploadReaper = NewUploadReaper(
+			objectHandler.GetService(),
+			logger,
+			viper.GetDuration(configReaperTTL),
+			viper.GetDuration(configReaperInterval),
+			viper.GetBool(configReaperDryRun),
+			NewLeaderElector(viper.GetString(configReaperLeader)),
+		)
+		uploadServer.unregisterMetrics = append(
+			uploadServer.unregisterMetrics,
+			apm.DefaultTracer.RegisterMetricsGatherer(uploadServer.uploadReaper),
+		)
+
+		reaperCtx, stopReaper := context.WithCancel(context.Background())
+		uploadServer.stopReaper = stopReaper
+		go uploadServer.uploadReaper.Run(reaperCtx)
+	}
+
 	return uploadServer
 }
 
+// newSessionStore creates the upload session store configured by `UPLOAD_SESSION_STORE`.
+func newSessionStore(logger *logrus.Logger) uploadsession.Store {
+	path := viper.GetString(configSessionStore)
+	if path == sessionStoreMemory {
+		logger.Warnf(
+			"keeping upload sessions in memory, they're lost on restart and aren't shared with other instances, " +
+				"so the owner checks of their uploads pass for every user",
+		)
+		return uploadsession.NewMemoryStore()
+	}
+
+	if path == "" {
+		path = DefaultSessionStorePath
+	}
+
+	store, err := uploadsession.NewBoltStore(path)
+	if err != nil {
+		logger.Fatalf(err.Error())
+	}
+	logger.Infof("keeping upload sessions in bolt database - %s", path)
+
+	return store
+}
+
+// newStorageBackend creates the storage backend configured by `STORAGE_BACKEND`.
+func newStorageBackend(logger *logrus.Logger) storage.Backend {
+	switch storageBackend := viper.GetString(configStorageBackend); storageBackend {
+	case storageBackendS3:
+		return newS3Backend(logger)
+	case storageBackendFilesystem:
+		root := viper.GetString(configStorageFSRoot)
+		backend, err := storage.NewFSBackend(root)
+		if err != nil {
+			logger.Fatalf(err.Error())
+		}
+		logger.Infof("storing objects in filesystem - %s", backend.GetRoot())
+
+		return backend
+	default:
+		logger.Fatalf("unknown storage backend %s", storageBackend)
+	}
+
+	return nil
+}
+
+// newS3Backend creates an S3 storage backend connected to the configured S3 endpoint.
+func newS3Backend(logger *logrus.Logger) storage.Backend {
+	// Configuration variables
+	s3AccessKey := viper.GetString(configS3AccessKey)
+	s3SecretKey := viper.GetString(configS3SecretKey)
+	s3Endpoint := viper.GetString(configS3Endpoint)
+	s3Token := viper.GetString(configS3Token)
+	s3Region := viper.GetString(configS3Region)
+	s3SSL := viper.GetBool(configS3SSL)
+
+	// Configure to use S3 Server
+	s3Config := &aws.Config{
+		Credentials:      credentials.NewStaticCredentials(s3AccessKey, s3SecretKey, s3Token),
+		Endpoint:         aws.String(s3Endpoint),
+		Region:           aws.String(s3Region),
+		DisableSSL:       aws.Bool(!s3SSL),
+		S3ForcePathStyle: aws.Bool(true),
+		HTTPClient:       apmhttp.WrapClient(http.DefaultClient),
+	}
+
+	// Open a session to s3.
+	newSession, err := session.NewSession(s3Config)
+	if err != nil {
+		logger.Fatalf(err.Error())
+	}
+	logger.Infof("connected to S3 - %s", s3Endpoint)
+
+	// Create a client from the s3 session.
+	return storage.NewS3Backend(s3.New(newSession))
+}
+
 // serverLoggerInterceptor configures the logger interceptor for the upload server.
 func serverLoggerInterceptor(logger *logrus.Logger) []grpc.ServerOption {
 	// Create new logrus entry for logger interceptor.
@@ -177,6 +496,8 @@ func serverLoggerInterceptor(logger *logrus.Logger) []grpc.ServerOption {
 			"/upload.Upload/UploadMedia",
 			"/upload.Upload/UploadMultipart",
 			"/upload.Upload/UploadPart",
+			"/upload.Upload/DownloadObject",
+			"/upload.Upload/UploadStream",
 		)...,
 	)
 
@@ -186,6 +507,7 @@ func serverLoggerInterceptor(logger *logrus.Logger) []grpc.ServerOption {
 			"/upload.Upload/UploadMedia",
 			"/upload.Upload/UploadMultipart",
 			"/upload.Upload/UploadPart",
+			"/upload.Upload/UploadStream",
 		)...,
 	)
 
@@ -208,10 +530,10 @@ func serverLoggerInterceptor(logger *logrus.Logger) []grpc.ServerOption {
 // healthCheckWorker is running an infinite loop that sets the serving status once
 // in s.healthCheckInterval seconds.
 func (s UploadServer) healthCheckWorker(healthServer *health.Server) {
-	s3Client := s.objectHandler.GetService().GetS3Client()
+	backend := s.objectHandler.GetService().GetBackend()
 
 	for {
-		_, err := s3Client.ListBuckets(&s3.ListBucketsInput{})
+		_, err := backend.ListBuckets(aws.BackgroundContext(), &s3.ListBucketsInput{})
 		if err != nil {
 			healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 		} else {
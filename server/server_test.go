@@ -0,0 +1,329 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/meateam/upload-service/object"
+	"github.com/meateam/upload-service/server"
+	"github.com/meateam/upload-service/session"
+	"github.com/meateam/upload-service/storage"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestUploadReaper_Reap(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	backend := storage.NewMemoryBackend()
+	service := object.NewService(backend)
+
+	// Uploads initiated directly in the storage have no session, like uploads of another service.
+	for _, bucket := range []string{"testbucket", "testbucket1"} {
+		if _, err := service.UploadInit(ctx, aws.String("file"), aws.String(bucket), aws.String("text/plain"), nil); err != nil {
+			t.Fatalf("Service.UploadInit() error = %v", err)
+		}
+
+		input := &s3.CreateMultipartUploadInput{Bucket: aws.String(bucket), Key: aws.String("nosession")}
+		if _, err := backend.CreateMultipartUpload(ctx, input); err != nil {
+			t.Fatalf("Backend.CreateMultipartUpload() error = %v", err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	tests := []struct {
+		name          string
+		ttl           time.Duration
+		dryRun        bool
+		want          server.ReapResult
+		wantRemaining int
+	}{
+		{
+			name:          "reap recent uploads",
+			ttl:           time.Hour,
+			want:          server.ReapResult{Buckets: 2},
+			wantRemaining: 4,
+		},
+		{
+			name:          "reap abandoned uploads in dry run",
+			ttl:           time.Millisecond,
+			dryRun:        true,
+			want:          server.ReapResult{Buckets: 2, Found: 2},
+			wantRemaining: 4,
+		},
+		{
+			name:          "reap abandoned uploads and keep uploads with an active session",
+			ttl:           time.Millisecond,
+			want:          server.ReapResult{Buckets: 2, Found: 2, Aborted: 2},
+			wantRemaining: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reaper := server.NewUploadReaper(service, logger, tt.ttl, time.Hour, tt.dryRun, nil)
+			got, err := reaper.Reap(ctx)
+			if err != nil {
+				t.Fatalf("UploadReaper.Reap() error = %v", err)
+			}
+
+			if *got != tt.want {
+				t.Errorf("UploadReaper.Reap() = %+v, want %+v", *got, tt.want)
+			}
+
+			stats := reaper.Stats()
+			if stats.Scans != 1 || stats.Found != int64(tt.want.Found) || stats.Aborted != int64(tt.want.Aborted) {
+				t.Errorf("UploadReaper.Stats() = %+v, want the result of a single scan", stats)
+			}
+
+			remaining := 0
+			for _, bucket := range []string{"testbucket", "testbucket1"} {
+				list, err := service.ListMultipartUploads(ctx, aws.String(bucket), nil, time.Time{}, nil, nil)
+				if err != nil {
+					t.Fatalf("Service.ListMultipartUploads() error = %v", err)
+				}
+
+				remaining += len(list.Uploads)
+			}
+
+			if remaining != tt.wantRemaining {
+				t.Errorf("UploadReaper.Reap() left %d uploads, want %d", remaining, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+// failingListBackend is a storage backend whose listing of a bucket's multipart uploads fails.
+type failingListBackend struct {
+	storage.Backend
+	bucket string
+}
+
+// ListMultipartUploads fails for the failing bucket, and lists the uploads of any other bucket.
+func (b failingListBackend) ListMultipartUploads(
+	ctx aws.Context,
+	input *s3.ListMultipartUploadsInput,
+) (*s3.ListMultipartUploadsOutput, error) {
+	if aws.StringValue(input.Bucket) == b.bucket {
+		return nil, errors.New("listing failed")
+	}
+
+	return b.Backend.ListMultipartUploads(ctx, input)
+}
+
+func TestUploadReaper_ReapFailedBucket(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	backend := storage.NewMemoryBackend()
+	for _, bucket := range []string{"failingbucket", "testbucket"} {
+		if _, err := backend.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(bucket)}); err != nil {
+			t.Fatalf("Backend.CreateBucket() error = %v", err)
+		}
+
+		input := &s3.CreateMultipartUploadInput{Bucket: aws.String(bucket), Key: aws.String("nosession")}
+		if _, err := backend.CreateMultipartUpload(ctx, input); err != nil {
+			t.Fatalf("Backend.CreateMultipartUpload() error = %v", err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	service := object.NewService(failingListBackend{Backend: backend, bucket: "failingbucket"})
+	reaper := server.NewUploadReaper(service, logger, time.Millisecond, time.Hour, false, nil)
+	got, err := reaper.Reap(ctx)
+	if err != nil {
+		t.Fatalf("UploadReaper.Reap() error = %v", err)
+	}
+
+	if want := (server.ReapResult{Buckets: 2, Found: 1, Aborted: 1, FailedBuckets: 1}); *got != want {
+		t.Errorf("UploadReaper.Reap() = %+v, want %+v", *got, want)
+	}
+
+	if stats := reaper.Stats(); stats.FailedBuckets != 1 {
+		t.Errorf("UploadReaper.Stats() FailedBuckets = %d, want 1", stats.FailedBuckets)
+	}
+
+	list, err := service.ListMultipartUploads(ctx, aws.String("testbucket"), nil, time.Time{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Service.ListMultipartUploads() error = %v", err)
+	}
+
+	if len(list.Uploads) != 0 {
+		t.Errorf("UploadReaper.Reap() left uploads %+v in the bucket after the failing bucket", list.Uploads)
+	}
+}
+
+func TestUploadReaper_ReapExpired(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	service := object.NewService(storage.NewMemoryBackend())
+	expired, _, err := service.UploadInitWithOptions(ctx, aws.String("expired"), aws.String("testbucket"), nil, nil, &object.UploadOptions{TTL: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("Service.UploadInitWithOptions() error = %v", err)
+	}
+
+	if _, err := service.UploadInit(ctx, aws.String("active"), aws.String("testbucket"), nil, nil); err != nil {
+		t.Fatalf("Service.UploadInit() error = %v", err)
+	}
+
+	reaper := server.NewUploadReaper(service, logger, time.Hour, time.Hour, false, nil)
+	got, err := reaper.Reap(ctx)
+	if err != nil {
+		t.Fatalf("UploadReaper.Reap() error = %v", err)
+	}
+
+	if want := (server.ReapResult{Buckets: 1, Expired: 1, Aborted: 1}); *got != want {
+		t.Errorf("UploadReaper.Reap() = %+v, want %+v", *got, want)
+	}
+
+	if _, err := service.GetSessionStore().Get(*expired.UploadId); err != session.ErrNotFound {
+		t.Errorf("Session store Get() of the expired upload error = %v, want %v", err, session.ErrNotFound)
+	}
+
+	list, err := service.ListMultipartUploads(ctx, aws.String("testbucket"), nil, time.Time{}, nil, nil)
+	if err != nil {
+		t.Fatalf("Service.ListMultipartUploads() error = %v", err)
+	}
+
+	if len(list.Uploads) != 1 || list.Uploads[0].Key != "active" {
+		t.Errorf("UploadReaper.Reap() left uploads %+v, want only the active upload", list.Uploads)
+	}
+}
+
+func TestUploadReaper_RunNotLeader(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	reaper := server.NewUploadReaper(
+		object.NewService(storage.NewMemoryBackend()),
+		logger,
+		time.Millisecond,
+		time.Millisecond,
+		false,
+		server.NewLeaderElector("not-this-host"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	reaper.Run(ctx)
+	if stats := reaper.Stats(); stats.Scans != 0 {
+		t.Errorf("UploadReaper.Run() scanned %d times, want no scans when not the leader", stats.Scans)
+	}
+}
+
+func TestUploadServer_Stop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "upload-service-sessions")
+	if err != nil {
+		t.Fatalf("Could not create temp dir with error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sessions.db")
+	os.Setenv("UPLOAD_SESSION_STORE", path)
+	os.Setenv("UPLOAD_REAPER_ENABLED", "true")
+	os.Setenv("UPLOAD_REAPER_INTERVAL", "1ms")
+	defer os.Unsetenv("UPLOAD_SESSION_STORE")
+	defer os.Unsetenv("UPLOAD_REAPER_ENABLED")
+	defer os.Unsetenv("UPLOAD_REAPER_INTERVAL")
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	uploadServer := server.NewServerWithBackend(logger, storage.NewMemoryBackend())
+	reaper := uploadServer.GetUploadReaper()
+	if reaper == nil {
+		t.Fatalf("NewServerWithBackend() created no upload reaper")
+	}
+
+	if _, ok := uploadServer.GetHandler().GetService().GetSessionStore().(*session.BoltStore); !ok {
+		t.Fatalf("NewServerWithBackend() session store = %T, want a bolt store", uploadServer.GetHandler().GetService().GetSessionStore())
+	}
+
+	uploadServer.Stop()
+
+	// A scan that started before the stop may still be counted.
+	time.Sleep(20 * time.Millisecond)
+	scans := reaper.Stats().Scans
+	time.Sleep(20 * time.Millisecond)
+	if stats := reaper.Stats(); stats.Scans != scans {
+		t.Errorf("UploadServer.Stop() didn't stop the upload reaper, it scanned %d more times", stats.Scans-scans)
+	}
+
+	// A bolt database is opened by a single process at a time, so it can be opened only once it's closed.
+	store, err := session.NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("UploadServer.Stop() didn't close the session store: %v", err)
+	}
+	store.Close()
+}
+
+func TestNewServerWithBackend_MemorySessionStore(t *testing.T) {
+	os.Setenv("UPLOAD_SESSION_STORE", "memory")
+	defer os.Unsetenv("UPLOAD_SESSION_STORE")
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	uploadServer := server.NewServerWithBackend(logger, storage.NewMemoryBackend())
+	defer uploadServer.Stop()
+
+	if _, ok := uploadServer.GetHandler().GetService().GetSessionStore().(*session.MemoryStore); !ok {
+		t.Errorf("NewServerWithBackend() session store = %T, want a memory store", uploadServer.GetHandler().GetService().GetSessionStore())
+	}
+}
+
+func TestWrapHTTPHandler(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	handler := server.WrapHTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panic("handler failed")
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}), logger)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantLevel  logrus.Level
+		wantPanic  bool
+	}{
+		{name: "request", path: "/ok", wantStatus: http.StatusNoContent, wantLevel: logrus.InfoLevel},
+		{name: "panic", path: "/panic", wantStatus: http.StatusInternalServerError, wantLevel: logrus.ErrorLevel, wantPanic: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook.Reset()
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("WrapHTTPHandler() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+
+			entry := hook.LastEntry()
+			if entry == nil || entry.Level != tt.wantLevel || entry.Data["http.status"] != tt.wantStatus || entry.Data["http.path"] != tt.path {
+				t.Fatalf("WrapHTTPHandler() logged %+v, want a %v entry of %s with status %d", entry, tt.wantLevel, tt.path, tt.wantStatus)
+			}
+
+			if _, ok := entry.Data["panic"]; ok != tt.wantPanic {
+				t.Errorf("WrapHTTPHandler() logged panic = %v, want %v", ok, tt.wantPanic)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// sessionsBucket is the name of the bolt bucket of the sessions.
+var sessionsBucket = []byte("sessions")
+
+// BoltStore is a Store that keeps the sessions in a bolt database file, so they survive
+// restarts of the service. Sessions are JSON encoded by upload ID.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// Verify that BoltStore implements Store.
+var _ Store = (*BoltStore)(nil)
+
+// NewBoltStore opens the bolt database at path, creating it if it doesn't exist, and returns
+// a BoltStore of its sessions. A database can be opened by a single process at a time.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload session store %s: %v", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sessionsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create upload session store %s: %v", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Put stores session, replacing the upload's previous session.
+func (b *BoltStore) Put(session *Session) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return putSession(tx, session)
+	})
+}
+
+// Get returns an upload's session, or ErrNotFound if the upload has no session.
+func (b *BoltStore) Get(uploadID string) (*Session, error) {
+	var session *Session
+	err := b.db.View(func(tx *bolt.Tx) error {
+		var err error
+		session, err = getSession(tx, uploadID)
+		return err
+	})
+
+	return session, err
+}
+
+// Update applies update to an upload's session and stores it in a single transaction,
+// unless update returns an error.
+func (b *BoltStore) Update(uploadID string, update func(session *Session) error) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		session, err := getSession(tx, uploadID)
+		if err != nil {
+			return err
+		}
+
+		if err := update(session); err != nil {
+			return err
+		}
+
+		return putSession(tx, session)
+	})
+}
+
+// Delete deletes an upload's session.
+func (b *BoltStore) Delete(uploadID string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).Delete([]byte(uploadID))
+	})
+}
+
+// ListExpired returns the sessions that expired at now, ordered by expiry.
+func (b *BoltStore) ListExpired(now time.Time) ([]*Session, error) {
+	var expired []*Session
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).ForEach(func(uploadID []byte, value []byte) error {
+			session := &Session{}
+			if err := json.Unmarshal(value, session); err != nil {
+				return fmt.Errorf("failed to decode upload session %s: %v", uploadID, err)
+			}
+
+			if session.Expired(now) {
+				expired = append(expired, session)
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortByExpiry(expired)
+
+	return expired, nil
+}
+
+// Close closes the bolt database.
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
+
+// putSession stores session in the sessions bucket of tx.
+func putSession(tx *bolt.Tx, session *Session) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode upload session %s: %v", session.UploadID, err)
+	}
+
+	return tx.Bucket(sessionsBucket).Put([]byte(session.UploadID), value)
+}
+
+// getSession returns an upload's session from the sessions bucket of tx.
+func getSession(tx *bolt.Tx, uploadID string) (*Session, error) {
+	value := tx.Bucket(sessionsBucket).Get([]byte(uploadID))
+	if value == nil {
+		return nil, ErrNotFound
+	}
+
+	session := &Session{}
+	if err := json.Unmarshal(value, session); err != nil {
+		return nil, fmt.Errorf("failed to decode upload session %s: %v", uploadID, err)
+	}
+
+	return session, nil
+}
@@ -0,0 +1,238 @@
+package session
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by a Store for an upload that has no session.
+var ErrNotFound = errors.New("upload session not found")
+
+// Checksum is a checksum of uploaded content.
+type Checksum struct {
+	// Algorithm is the checksum's algorithm, e.g. "SHA256".
+	Algorithm string `json:"algorithm"`
+
+	// Value is the hex encoded checksum.
+	Value string `json:"value"`
+}
+
+// Part is a part of an upload that was uploaded.
+type Part struct {
+	// Size is the part's size in bytes.
+	Size int64 `json:"size"`
+
+	// ETag is the part's ETag.
+	ETag string `json:"eTag"`
+
+	// Checksum is the part's verified checksum, nil if the part was uploaded without one.
+	Checksum *Checksum `json:"checksum,omitempty"`
+
+	// Uploaded is the time the part was uploaded.
+	Uploaded time.Time `json:"uploaded"`
+}
+
+// Session is the state of a multipart upload kept by the upload service,
+// from its initiation until it's completed or aborted.
+type Session struct {
+	UploadID string `json:"uploadId"`
+	Key      string `json:"key"`
+	Bucket   string `json:"bucket"`
+
+	// Owner is the user who started the upload.
+	Owner string `json:"owner,omitempty"`
+
+	// ContentType and Metadata are the details the upload was initiated with.
+	ContentType string            `json:"contentType,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+
+	// Size is the total size of the upload in bytes that was promised, zero if it's unknown.
+	Size int64 `json:"size,omitempty"`
+
+	// PartCount is the number of parts of the upload that was promised, zero if it's unknown.
+	PartCount int64 `json:"partCount,omitempty"`
+
+	// Checksum is the checksum of the assembled upload that was promised, nil if it's unknown.
+	Checksum *Checksum `json:"checksum,omitempty"`
+
+	// Parts are the parts uploaded so far by part number.
+	Parts map[int64]*Part `json:"parts,omitempty"`
+
+	// Created is the time the upload was initiated.
+	Created time.Time `json:"created"`
+
+	// Expires is the time after which the upload is abandoned.
+	Expires time.Time `json:"expires"`
+}
+
+// UploadedSize returns the total size in bytes of the parts uploaded so far,
+// counting a part that was uploaded more than once only once.
+func (s *Session) UploadedSize() int64 {
+	var size int64
+	for _, part := range s.Parts {
+		size += part.Size
+	}
+
+	return size
+}
+
+// Expired returns true if the session expired at now.
+func (s *Session) Expired(now time.Time) bool {
+	return !s.Expires.IsZero() && !now.Before(s.Expires)
+}
+
+// Store is a store of upload sessions by upload ID.
+// Its methods must be safe for concurrent use.
+type Store interface {
+	// Put stores a session, replacing the upload's previous session.
+	Put(session *Session) error
+
+	// Get returns an upload's session, or ErrNotFound if the upload has no session.
+	Get(uploadID string) (*Session, error)
+
+	// Update applies update to an upload's session atomically and stores the result,
+	// unless update returns an error. Returns ErrNotFound if the upload has no session.
+	Update(uploadID string, update func(session *Session) error) error
+
+	// Delete deletes an upload's session, it's a no-op if the upload has no session.
+	Delete(uploadID string) error
+
+	// ListExpired returns the sessions that expired at now, ordered by expiry.
+	ListExpired(now time.Time) ([]*Session, error)
+
+	// Close releases the store's resources.
+	Close() error
+}
+
+// MemoryStore is a Store that keeps the sessions in memory, which are lost when the service stops.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string]*Session
+}
+
+// Verify that MemoryStore implements Store.
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty MemoryStore and returns it.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[string]*Session)}
+}
+
+// Put stores a copy of session, replacing the upload's previous session.
+func (m *MemoryStore) Put(session *Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[session.UploadID] = session.clone()
+
+	return nil
+}
+
+// Get returns a copy of an upload's session, or ErrNotFound if the upload has no session.
+func (m *MemoryStore) Get(uploadID string) (*Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, ok := m.sessions[uploadID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return session.clone(), nil
+}
+
+// Update applies update to a copy of an upload's session and stores it, unless update returns an error.
+func (m *MemoryStore) Update(uploadID string, update func(session *Session) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[uploadID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	updated := session.clone()
+	if err := update(updated); err != nil {
+		return err
+	}
+
+	m.sessions[uploadID] = updated
+
+	return nil
+}
+
+// Delete deletes an upload's session.
+func (m *MemoryStore) Delete(uploadID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, uploadID)
+
+	return nil
+}
+
+// ListExpired returns copies of the sessions that expired at now, ordered by expiry.
+func (m *MemoryStore) ListExpired(now time.Time) ([]*Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var expired []*Session
+	for _, session := range m.sessions {
+		if session.Expired(now) {
+			expired = append(expired, session.clone())
+		}
+	}
+
+	sortByExpiry(expired)
+
+	return expired, nil
+}
+
+// Close is a no-op.
+func (m *MemoryStore) Close() error {
+	return nil
+}
+
+// clone returns a deep copy of the session, so stored sessions aren't modified by their users.
+func (s *Session) clone() *Session {
+	clone := *s
+	if s.Metadata != nil {
+		clone.Metadata = make(map[string]string, len(s.Metadata))
+		for key, value := range s.Metadata {
+			clone.Metadata[key] = value
+		}
+	}
+
+	if s.Checksum != nil {
+		checksum := *s.Checksum
+		clone.Checksum = &checksum
+	}
+
+	if s.Parts != nil {
+		clone.Parts = make(map[int64]*Part, len(s.Parts))
+		for partNumber, part := range s.Parts {
+			partClone := *part
+			if part.Checksum != nil {
+				checksum := *part.Checksum
+				partClone.Checksum = &checksum
+			}
+
+			clone.Parts[partNumber] = &partClone
+		}
+	}
+
+	return &clone
+}
+
+// sortByExpiry sorts sessions by their expiry, and then by upload ID.
+func sortByExpiry(sessions []*Session) {
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].Expires.Equal(sessions[j].Expires) {
+			return sessions[i].Expires.Before(sessions[j].Expires)
+		}
+
+		return sessions[i].UploadID < sessions[j].UploadID
+	})
+}
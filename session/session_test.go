@@ -0,0 +1,189 @@
+package session_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/meateam/upload-service/session"
+)
+
+// testStore is a session store under test.
+type testStore struct {
+	name  string
+	store session.Store
+}
+
+// newTestStores creates every session store, and returns them with a function that closes them.
+func newTestStores(t *testing.T) ([]testStore, func()) {
+	dir, err := ioutil.TempDir("", "upload-service-sessions")
+	if err != nil {
+		t.Fatalf("Could not create temp dir with error: %v", err)
+	}
+
+	boltStore, err := session.NewBoltStore(filepath.Join(dir, "sessions.db"))
+	if err != nil {
+		t.Fatalf("session.NewBoltStore() error = %v", err)
+	}
+
+	stores := []testStore{
+		{name: "memory", store: session.NewMemoryStore()},
+		{name: "bolt", store: boltStore},
+	}
+
+	cleanup := func() {
+		for _, s := range stores {
+			s.store.Close()
+		}
+		os.RemoveAll(dir)
+	}
+
+	return stores, cleanup
+}
+
+func TestStore(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	newSession := func(uploadID string, expires time.Time) *session.Session {
+		return &session.Session{
+			UploadID:  uploadID,
+			Key:       "file",
+			Bucket:    "testbucket",
+			Owner:     "user",
+			Metadata:  map[string]string{"key": "value"},
+			Size:      10,
+			PartCount: 2,
+			Checksum:  &session.Checksum{Algorithm: "SHA256", Value: "abc"},
+			Created:   now,
+			Expires:   expires,
+		}
+	}
+
+	stores, cleanup := newTestStores(t)
+	defer cleanup()
+
+	for _, ts := range stores {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			if _, err := store.Get("missing"); err != session.ErrNotFound {
+				t.Errorf("Store.Get() error = %v, want %v", err, session.ErrNotFound)
+			}
+
+			for _, s := range []*session.Session{
+				newSession("later", now.Add(time.Hour)),
+				newSession("expired2", now.Add(-time.Minute)),
+				newSession("expired1", now.Add(-time.Hour)),
+			} {
+				if err := store.Put(s); err != nil {
+					t.Fatalf("Store.Put() error = %v", err)
+				}
+			}
+
+			err := store.Update("later", func(s *session.Session) error {
+				s.Parts = map[int64]*session.Part{1: {Size: 5, ETag: "etag", Uploaded: now}}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Store.Update() error = %v", err)
+			}
+
+			failure := errors.New("failed update")
+			err = store.Update("later", func(s *session.Session) error {
+				s.Owner = "other"
+				return failure
+			})
+			if err != failure {
+				t.Errorf("Store.Update() error = %v, want %v", err, failure)
+			}
+
+			if err := store.Update("missing", func(*session.Session) error { return nil }); err != session.ErrNotFound {
+				t.Errorf("Store.Update() error = %v, want %v", err, session.ErrNotFound)
+			}
+
+			got, err := store.Get("later")
+			if err != nil {
+				t.Fatalf("Store.Get() error = %v", err)
+			}
+
+			want := newSession("later", now.Add(time.Hour))
+			want.Parts = map[int64]*session.Part{1: {Size: 5, ETag: "etag", Uploaded: now}}
+			if !cmp.Equal(got, want) {
+				t.Errorf("Store.Get() mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+
+			if got.UploadedSize() != 5 {
+				t.Errorf("Session.UploadedSize() = %d, want 5", got.UploadedSize())
+			}
+
+			// A returned session is a copy that doesn't change the stored session.
+			got.Parts[2] = &session.Part{Size: 5}
+			if stored, _ := store.Get("later"); len(stored.Parts) != 1 {
+				t.Errorf("Store.Get() returned a session that shares the stored session's parts")
+			}
+
+			expired, err := store.ListExpired(now)
+			if err != nil {
+				t.Fatalf("Store.ListExpired() error = %v", err)
+			}
+
+			var expiredIDs []string
+			for _, s := range expired {
+				expiredIDs = append(expiredIDs, s.UploadID)
+			}
+
+			if want := []string{"expired1", "expired2"}; !cmp.Equal(expiredIDs, want) {
+				t.Errorf("Store.ListExpired() = %v, want %v", expiredIDs, want)
+			}
+
+			for _, uploadID := range []string{"expired1", "expired1", "missing"} {
+				if err := store.Delete(uploadID); err != nil {
+					t.Errorf("Store.Delete(%q) error = %v", uploadID, err)
+				}
+			}
+
+			if _, err := store.Get("expired1"); err != session.ErrNotFound {
+				t.Errorf("Store.Get() of a deleted session error = %v, want %v", err, session.ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestBoltStore_Reopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "upload-service-sessions")
+	if err != nil {
+		t.Fatalf("Could not create temp dir with error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sessions.db")
+	store, err := session.NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("session.NewBoltStore() error = %v", err)
+	}
+
+	if err := store.Put(&session.Session{UploadID: "upload", Key: "file", Bucket: "testbucket", Owner: "user"}); err != nil {
+		t.Fatalf("BoltStore.Put() error = %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("BoltStore.Close() error = %v", err)
+	}
+
+	store, err = session.NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("session.NewBoltStore() error = %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.Get("upload")
+	if err != nil {
+		t.Fatalf("BoltStore.Get() error = %v", err)
+	}
+
+	if got.Owner != "user" {
+		t.Errorf("BoltStore.Get() = %+v, want the session stored before the store was reopened", got)
+	}
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// S3 error codes returned by the backends that are not defined in the s3 package.
+const (
+	// ErrCodeNotFound is returned by HeadObject and HeadBucket, which have no response body.
+	ErrCodeNotFound = "NotFound"
+
+	// ErrCodeInvalidBucketName is returned when a bucket name is invalid.
+	ErrCodeInvalidBucketName = "InvalidBucketName"
+
+	// ErrCodeInvalidArgument is returned when a request argument is invalid.
+	ErrCodeInvalidArgument = "InvalidArgument"
+
+	// ErrCodeInvalidPart is returned when a completed part is missing or its ETag doesn't match.
+	ErrCodeInvalidPart = "InvalidPart"
+
+	// ErrCodeInvalidPartOrder is returned when completed parts are not in ascending order.
+	ErrCodeInvalidPartOrder = "InvalidPartOrder"
+
+	// ErrCodeEntityTooSmall is returned when a completed part, other than the last, is too small.
+	ErrCodeEntityTooSmall = "EntityTooSmall"
+
+	// ErrCodeMalformedXML is returned when a complete request has no parts, or a delete request
+	// has no objects or more than 1000 objects.
+	ErrCodeMalformedXML = "MalformedXML"
+
+	// ErrCodeKeyTooLong is returned when an object key is longer than 1024 bytes.
+	ErrCodeKeyTooLong = "KeyTooLongError"
+
+	// ErrCodeInvalidRange is returned when a requested range is not satisfiable.
+	ErrCodeInvalidRange = "InvalidRange"
+
+	// ErrCodeBucketNotEmpty is returned when deleting a bucket that has objects or multipart uploads.
+	ErrCodeBucketNotEmpty = "BucketNotEmpty"
+
+	// ErrCodePreconditionFailed is returned when a conditional request's precondition doesn't hold,
+	// for example when a copy source's ETag doesn't match CopySourceIfMatch.
+	ErrCodePreconditionFailed = "PreconditionFailed"
+
+	// ErrCodeInternalError is returned when the backend failed unexpectedly.
+	ErrCodeInternalError = "InternalError"
+)
+
+// newError returns an S3 compatible request failure error with the given code, status code and message.
+func newError(code string, statusCode int, message string) error {
+	return awserr.NewRequestFailure(awserr.New(code, message, nil), statusCode, "")
+}
+
+// internalError wraps an unexpected error of a backend as an S3 compatible InternalError.
+func internalError(err error) error {
+	return awserr.NewRequestFailure(
+		awserr.New(ErrCodeInternalError, "We encountered an internal error. Please try again.", err),
+		http.StatusInternalServerError,
+		"",
+	)
+}
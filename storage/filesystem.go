@@ -0,0 +1,985 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const (
+	// fsTempDir is the directory under the root in which files are written before
+	// they are renamed to their final path.
+	fsTempDir = ".tmp"
+
+	// fsObjectsDir is the directory under a bucket's directory that holds its objects.
+	fsObjectsDir = "objects"
+
+	// fsUploadsDir is the directory under a bucket's directory that holds its multipart uploads.
+	fsUploadsDir = "uploads"
+
+	// fsUploadInfoFile is the file in an upload's directory that holds the upload's details.
+	fsUploadInfoFile = "upload.json"
+
+	// fsTrailerLengthSize is the size of the length of the trailer at the end of stored files.
+	fsTrailerLengthSize = 8
+)
+
+// uploadIDRegexp matches a valid upload ID generated by FSBackend.
+var uploadIDRegexp = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// Verify that FSBackend implements Backend and PartReader.
+var (
+	_ Backend    = (*FSBackend)(nil)
+	_ PartReader = (*FSBackend)(nil)
+)
+
+// FSBackend is a Backend that stores objects in a local directory.
+//
+// Each bucket is a directory under the root directory. An object is stored in its
+// bucket's objects directory, in a file named after the sha256 sum of its key, which contains
+// the object's data followed by a JSON trailer of its details and the trailer's length.
+// Multipart upload parts are staged in a directory named after the upload ID,
+// and assembled to the object's file on CompleteMultipartUpload.
+// Every file is written to a temporary file first and then renamed to its path,
+// so objects are replaced atomically.
+// The listed details of the objects' files are cached, so listing a bucket reads only
+// the files that were written since it was last listed.
+type FSBackend struct {
+	root string
+
+	// mu guards bucket creation.
+	mu sync.Mutex
+
+	// listMu guards listed, the listed object files of each bucket mapped by their name.
+	listMu sync.Mutex
+	listed map[string]map[string]*listedFile
+}
+
+// listedFile is the listed details of an object's file, which are valid as long as the file
+// isn't replaced.
+type listedFile struct {
+	file os.FileInfo
+	info *objectInfo
+}
+
+// replacedBy returns true if the listed file isn't file, or was changed since it was listed.
+func (f *listedFile) replacedBy(file os.FileInfo) bool {
+	return !os.SameFile(f.file, file) || !f.file.ModTime().Equal(file.ModTime()) || f.file.Size() != file.Size()
+}
+
+// NewFSBackend creates an FSBackend that stores objects under the root directory and returns it.
+// The root directory is created if it doesn't exist.
+func NewFSBackend(root string) (*FSBackend, error) {
+	if root == "" {
+		return nil, fmt.Errorf("root directory is required")
+	}
+
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root directory %s: %v", root, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(absRoot, fsTempDir), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create root directory %s: %v", absRoot, err)
+	}
+
+	return &FSBackend{root: absRoot, listed: make(map[string]map[string]*listedFile)}, nil
+}
+
+// GetRoot returns the root directory of the backend.
+func (b *FSBackend) GetRoot() string {
+	return b.root
+}
+
+// PutObject writes an object to its bucket's directory.
+func (b *FSBackend) PutObject(ctx aws.Context, input *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	info := &objectInfo{
+		Key:         *input.Key,
+		ContentType: contentTypeOrDefault(input.ContentType),
+		Metadata:    normalizeMetadata(input.Metadata),
+	}
+
+	hasher := newETagHasher(uploadPartSize)
+	body := io.TeeReader(&contextReader{ctx: ctx, r: input.Body}, hasher)
+	err := b.writeFile(b.objectPath(*input.Bucket, *input.Key), body, func(size int64) *objectInfo {
+		info.Size = size
+		info.ETag = hasher.ETag()
+		return info
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3manager.UploadOutput{Location: b.location(*input.Bucket, *input.Key)}, nil
+}
+
+// CreateMultipartUpload creates a directory for the parts of a new multipart upload.
+func (b *FSBackend) CreateMultipartUpload(
+	ctx aws.Context,
+	input *s3.CreateMultipartUploadInput,
+) (*s3.CreateMultipartUploadOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	uploadID, err := newUploadID()
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	uploadDir := b.uploadDir(*input.Bucket, uploadID)
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		return nil, internalError(err)
+	}
+
+	info := &uploadInfo{
+		Key:         *input.Key,
+		ContentType: contentTypeOrDefault(input.ContentType),
+		Metadata:    normalizeMetadata(input.Metadata),
+		Initiated:   now(),
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	if err := b.writeFile(filepath.Join(uploadDir, fsUploadInfoFile), bytes.NewReader(data), nil); err != nil {
+		os.RemoveAll(uploadDir)
+		return nil, err
+	}
+
+	return &s3.CreateMultipartUploadOutput{
+		Bucket:   input.Bucket,
+		Key:      input.Key,
+		UploadId: aws.String(uploadID),
+	}, nil
+}
+
+// UploadPart writes a part of a multipart upload to the upload's directory.
+func (b *FSBackend) UploadPart(ctx aws.Context, input *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+	if _, err := b.getUpload(input.Bucket, input.Key, input.UploadId); err != nil {
+		return nil, err
+	}
+
+	if err := validatePartNumber(input.PartNumber); err != nil {
+		return nil, err
+	}
+
+	hash := md5.New()
+	body := io.TeeReader(&contextReader{ctx: ctx, r: input.Body}, hash)
+	var info *objectInfo
+	err := b.writeFile(b.partPath(*input.Bucket, *input.UploadId, *input.PartNumber), body, func(size int64) *objectInfo {
+		info = &objectInfo{Size: size, ETag: quoteETag(hash.Sum(nil))}
+		return info
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3.UploadPartOutput{ETag: aws.String(info.ETag)}, nil
+}
+
+// UploadPartCopy writes a part of a multipart upload to the upload's directory,
+// copied from a range of an object's file.
+func (b *FSBackend) UploadPartCopy(ctx aws.Context, input *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+	return uploadPartCopy(ctx, b, input)
+}
+
+// GetUploadPart returns a reader of a part's file in a multipart upload's directory.
+func (b *FSBackend) GetUploadPart(
+	ctx aws.Context,
+	bucket *string,
+	key *string,
+	uploadID *string,
+	partNumber *int64,
+) (io.ReadCloser, error) {
+	if _, err := b.getUpload(bucket, key, uploadID); err != nil {
+		return nil, err
+	}
+
+	if err := validatePartNumber(partNumber); err != nil {
+		return nil, err
+	}
+
+	f, _, size, err := openFile(b.partPath(*bucket, *uploadID, *partNumber))
+	if os.IsNotExist(err) {
+		return nil, invalidPart(*partNumber)
+	}
+
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return &readCloser{Reader: io.NewSectionReader(f, 0, size), Closer: f}, nil
+}
+
+// ListParts lists the parts in a multipart upload's directory.
+func (b *FSBackend) ListParts(ctx aws.Context, input *s3.ListPartsInput) (*s3.ListPartsOutput, error) {
+	if _, err := b.getUpload(input.Bucket, input.Key, input.UploadId); err != nil {
+		return nil, err
+	}
+
+	parts, err := b.listParts(*input.Bucket, *input.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	maxParts := aws.Int64Value(input.MaxParts)
+	if maxParts <= 0 || maxParts > maxListParts {
+		maxParts = maxListParts
+	}
+
+	output := &s3.ListPartsOutput{
+		Bucket:           input.Bucket,
+		Key:              input.Key,
+		UploadId:         input.UploadId,
+		MaxParts:         aws.Int64(maxParts),
+		PartNumberMarker: aws.Int64(aws.Int64Value(input.PartNumberMarker)),
+		IsTruncated:      aws.Bool(false),
+		StorageClass:     aws.String(s3.StorageClassStandard),
+		Parts:            []*s3.Part{},
+	}
+
+	for _, part := range parts {
+		if aws.Int64Value(part.PartNumber) <= aws.Int64Value(input.PartNumberMarker) {
+			continue
+		}
+
+		if int64(len(output.Parts)) == maxParts {
+			output.IsTruncated = aws.Bool(true)
+			break
+		}
+
+		output.Parts = append(output.Parts, part)
+		output.NextPartNumberMarker = part.PartNumber
+	}
+
+	return output, nil
+}
+
+// CompleteMultipartUpload assembles the given parts of a multipart upload to the object's file
+// and removes the upload's directory.
+func (b *FSBackend) CompleteMultipartUpload(
+	ctx aws.Context,
+	input *s3.CompleteMultipartUploadInput,
+) (*s3.CompleteMultipartUploadOutput, error) {
+	upload, err := b.getUpload(input.Bucket, input.Key, input.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MultipartUpload == nil || len(input.MultipartUpload.Parts) == 0 {
+		return nil, newError(
+			ErrCodeMalformedXML,
+			http.StatusBadRequest,
+			"The XML you provided was not well-formed or did not validate against our published schema.",
+		)
+	}
+
+	storedParts, err := b.listParts(*input.Bucket, *input.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	partsByNumber := make(map[int64]*s3.Part, len(storedParts))
+	for _, part := range storedParts {
+		partsByNumber[*part.PartNumber] = part
+	}
+
+	completedParts, err := validateCompletedParts(input.MultipartUpload.Parts, partsByNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	partSums := make([][]byte, 0, len(completedParts))
+	readers := make([]io.Reader, 0, len(completedParts))
+	for _, part := range completedParts {
+		sum, err := hex.DecodeString(unquoteETag(*part.ETag))
+		if err != nil {
+			return nil, internalError(err)
+		}
+		partSums = append(partSums, sum)
+
+		f, _, size, err := openFile(b.partPath(*input.Bucket, *input.UploadId, *part.PartNumber))
+		if err != nil {
+			return nil, internalError(err)
+		}
+		defer f.Close()
+
+		readers = append(readers, io.NewSectionReader(f, 0, size))
+	}
+
+	info := &objectInfo{
+		Key:         upload.Key,
+		ContentType: upload.ContentType,
+		Metadata:    upload.Metadata,
+		ETag:        multipartETag(partSums),
+	}
+
+	body := &contextReader{ctx: ctx, r: io.MultiReader(readers...)}
+	err = b.writeFile(b.objectPath(*input.Bucket, *input.Key), body, func(size int64) *objectInfo {
+		info.Size = size
+		return info
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.RemoveAll(b.uploadDir(*input.Bucket, *input.UploadId)); err != nil {
+		return nil, internalError(err)
+	}
+
+	return &s3.CompleteMultipartUploadOutput{
+		Bucket:   input.Bucket,
+		Key:      input.Key,
+		ETag:     aws.String(info.ETag),
+		Location: aws.String(b.location(*input.Bucket, *input.Key)),
+	}, nil
+}
+
+// AbortMultipartUpload removes a multipart upload's directory and its parts.
+func (b *FSBackend) AbortMultipartUpload(
+	ctx aws.Context,
+	input *s3.AbortMultipartUploadInput,
+) (*s3.AbortMultipartUploadOutput, error) {
+	if _, err := b.getUpload(input.Bucket, input.Key, input.UploadId); err != nil {
+		return nil, err
+	}
+
+	if err := os.RemoveAll(b.uploadDir(*input.Bucket, *input.UploadId)); err != nil {
+		return nil, internalError(err)
+	}
+
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+// ListMultipartUploads lists a page of the multipart upload directories of a bucket.
+func (b *FSBackend) ListMultipartUploads(
+	ctx aws.Context,
+	input *s3.ListMultipartUploadsInput,
+) (*s3.ListMultipartUploadsOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	uploads, err := b.listUploads(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return listMultipartUploads(input, uploads)
+}
+
+// HeadObject returns an object's details from its file's trailer.
+func (b *FSBackend) HeadObject(ctx aws.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		if isCode(err, s3.ErrCodeNoSuchBucket) {
+			return nil, notFound()
+		}
+
+		return nil, err
+	}
+
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	f, info, _, err := openFile(b.objectPath(*input.Bucket, *input.Key))
+	if os.IsNotExist(err) {
+		return nil, notFound()
+	}
+
+	if err != nil {
+		return nil, internalError(err)
+	}
+	f.Close()
+
+	return headObjectOutput(info), nil
+}
+
+// GetObject returns an object's details and a reader of its file's data.
+func (b *FSBackend) GetObject(ctx aws.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	f, info, size, err := openFile(b.objectPath(*input.Bucket, *input.Key))
+	if os.IsNotExist(err) {
+		return nil, noSuchKey()
+	}
+
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	head := headObjectOutput(info)
+	output := &s3.GetObjectOutput{
+		AcceptRanges:  head.AcceptRanges,
+		ContentLength: head.ContentLength,
+		ContentType:   head.ContentType,
+		ETag:          head.ETag,
+		LastModified:  head.LastModified,
+		Metadata:      head.Metadata,
+		Body:          &readCloser{Reader: io.NewSectionReader(f, 0, size), Closer: f},
+	}
+
+	if aws.StringValue(input.Range) != "" {
+		start, end, err := parseRange(*input.Range, size)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+
+		output.ContentLength = aws.Int64(end - start + 1)
+		output.ContentRange = aws.String(contentRange(start, end, size))
+		output.Body = &readCloser{Reader: io.NewSectionReader(f, start, end-start+1), Closer: f}
+	}
+
+	return output, nil
+}
+
+// CopyObject copies an object's file to the destination bucket and key.
+func (b *FSBackend) CopyObject(ctx aws.Context, input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+	srcBucket, srcKey, err := parseCopySource(input.CopySource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.checkBucket(aws.String(srcBucket)); err != nil {
+		return nil, err
+	}
+
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	f, srcInfo, size, err := openFile(b.objectPath(srcBucket, srcKey))
+	if os.IsNotExist(err) {
+		return nil, noSuchKey()
+	}
+
+	if err != nil {
+		return nil, internalError(err)
+	}
+	defer f.Close()
+
+	info := &objectInfo{
+		Key:         *input.Key,
+		ContentType: srcInfo.ContentType,
+		Metadata:    srcInfo.Metadata,
+	}
+
+	if aws.StringValue(input.MetadataDirective) == s3.MetadataDirectiveReplace {
+		info.ContentType = contentTypeOrDefault(input.ContentType)
+		info.Metadata = normalizeMetadata(input.Metadata)
+	}
+
+	// A copied object is stored as a single part, so its ETag is the md5 sum of its content.
+	hash := md5.New()
+	body := io.TeeReader(&contextReader{ctx: ctx, r: io.NewSectionReader(f, 0, size)}, hash)
+	err = b.writeFile(b.objectPath(*input.Bucket, *input.Key), body, func(size int64) *objectInfo {
+		info.Size = size
+		info.ETag = quoteETag(hash.Sum(nil))
+		return info
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3.CopyObjectOutput{
+		CopyObjectResult: &s3.CopyObjectResult{
+			ETag:         aws.String(info.ETag),
+			LastModified: aws.Time(info.LastModified),
+		},
+	}, nil
+}
+
+// ListObjectsV2 lists a page of the objects in a bucket sorted by their key.
+// The keys of the objects are read from their files' trailers.
+func (b *FSBackend) ListObjectsV2(ctx aws.Context, input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	objectsDir := filepath.Join(b.root, *input.Bucket, fsObjectsDir)
+	entries, err := ioutil.ReadDir(objectsDir)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	b.listMu.Lock()
+	cached := b.listed[*input.Bucket]
+	b.listMu.Unlock()
+
+	listed := make(map[string]*listedFile, len(entries))
+	objects := make([]*objectInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		// Only files that are new or replaced since the last list are read.
+		file, ok := cached[entry.Name()]
+		if !ok || file.replacedBy(entry) {
+			f, info, _, err := openFile(filepath.Join(objectsDir, entry.Name()))
+			// The object was deleted after its directory was read.
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			if err != nil {
+				return nil, internalError(err)
+			}
+			f.Close()
+
+			// The content type and metadata aren't listed, so they aren't kept.
+			file = &listedFile{
+				file: entry,
+				info: &objectInfo{Key: info.Key, Size: info.Size, ETag: info.ETag, LastModified: info.LastModified},
+			}
+		}
+
+		listed[entry.Name()] = file
+		objects = append(objects, file.info)
+	}
+
+	b.listMu.Lock()
+	b.listed[*input.Bucket] = listed
+	b.listMu.Unlock()
+
+	return listObjects(input, objects)
+}
+
+// DeleteObjects removes the files of the given objects, objects that don't exist are reported as deleted.
+func (b *FSBackend) DeleteObjects(
+	ctx aws.Context,
+	input *s3.DeleteObjectsInput,
+) (*s3.DeleteObjectsOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	if err := validateDeleteObjects(input); err != nil {
+		return nil, err
+	}
+
+	output := &s3.DeleteObjectsOutput{}
+	for _, object := range input.Delete.Objects {
+		if keyErr := deleteKeyError(object.Key); keyErr != nil {
+			output.Errors = append(output.Errors, keyErr)
+			continue
+		}
+
+		err := os.Remove(b.objectPath(*input.Bucket, *object.Key))
+		if err != nil && !os.IsNotExist(err) {
+			output.Errors = append(output.Errors, &s3.Error{
+				Key:     object.Key,
+				Code:    aws.String(ErrCodeInternalError),
+				Message: aws.String(err.Error()),
+			})
+			continue
+		}
+
+		output.Deleted = append(output.Deleted, &s3.DeletedObject{Key: object.Key})
+	}
+
+	return output, nil
+}
+
+// HeadBucket returns an error if the bucket's directory doesn't exist.
+func (b *FSBackend) HeadBucket(ctx aws.Context, input *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		if isCode(err, s3.ErrCodeNoSuchBucket) {
+			return nil, notFound()
+		}
+
+		return nil, err
+	}
+
+	return &s3.HeadBucketOutput{}, nil
+}
+
+// CreateBucket creates a bucket's directory.
+func (b *FSBackend) CreateBucket(ctx aws.Context, input *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
+	if err := validateBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucketDir := filepath.Join(b.root, *input.Bucket)
+	if _, err := os.Stat(bucketDir); err == nil {
+		return nil, newError(
+			s3.ErrCodeBucketAlreadyOwnedByYou,
+			http.StatusConflict,
+			"Your previous request to create the named bucket succeeded and you already own it.",
+		)
+	}
+
+	for _, dir := range []string{fsObjectsDir, fsUploadsDir} {
+		if err := os.MkdirAll(filepath.Join(bucketDir, dir), 0755); err != nil {
+			return nil, internalError(err)
+		}
+	}
+
+	return &s3.CreateBucketOutput{Location: aws.String("/" + *input.Bucket)}, nil
+}
+
+// DeleteBucket removes the directory of a bucket that has no objects and no multipart uploads.
+func (b *FSBackend) DeleteBucket(ctx aws.Context, input *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+	if err := b.checkBucket(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucketDir := filepath.Join(b.root, *input.Bucket)
+	for _, dir := range []string{fsObjectsDir, fsUploadsDir} {
+		entries, err := ioutil.ReadDir(filepath.Join(bucketDir, dir))
+		if err != nil {
+			return nil, internalError(err)
+		}
+
+		if len(entries) > 0 {
+			return nil, bucketNotEmpty()
+		}
+	}
+
+	if err := os.RemoveAll(bucketDir); err != nil {
+		return nil, internalError(err)
+	}
+
+	b.listMu.Lock()
+	delete(b.listed, *input.Bucket)
+	b.listMu.Unlock()
+
+	return &s3.DeleteBucketOutput{}, nil
+}
+
+// ListBuckets lists the bucket directories under the root directory.
+func (b *FSBackend) ListBuckets(ctx aws.Context, input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+	entries, err := ioutil.ReadDir(b.root)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	output := &s3.ListBucketsOutput{Buckets: []*s3.Bucket{}}
+	for _, entry := range entries {
+		if !entry.IsDir() || !bucketNameRegexp.MatchString(entry.Name()) {
+			continue
+		}
+
+		output.Buckets = append(output.Buckets, &s3.Bucket{
+			Name:         aws.String(entry.Name()),
+			CreationDate: aws.Time(entry.ModTime().UTC()),
+		})
+	}
+
+	return output, nil
+}
+
+// checkBucket returns an error if bucket is invalid or its directory doesn't exist.
+func (b *FSBackend) checkBucket(bucket *string) error {
+	if err := validateBucketName(bucket); err != nil {
+		return err
+	}
+
+	stat, err := os.Stat(filepath.Join(b.root, *bucket))
+	if os.IsNotExist(err) || (err == nil && !stat.IsDir()) {
+		return noSuchBucket()
+	}
+
+	if err != nil {
+		return internalError(err)
+	}
+
+	return nil
+}
+
+// getUpload returns the details of a multipart upload of key in bucket.
+// Returns a NoSuchUpload error if the upload doesn't exist or belongs to another key.
+func (b *FSBackend) getUpload(bucket *string, key *string, uploadID *string) (*uploadInfo, error) {
+	if err := b.checkBucket(bucket); err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if uploadID == nil || !uploadIDRegexp.MatchString(*uploadID) {
+		return nil, noSuchUpload()
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(b.uploadDir(*bucket, *uploadID), fsUploadInfoFile))
+	if os.IsNotExist(err) {
+		return nil, noSuchUpload()
+	}
+
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	info := &uploadInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, internalError(err)
+	}
+
+	if info.Key != *key {
+		return nil, noSuchUpload()
+	}
+
+	return info, nil
+}
+
+// listUploads returns the details of the multipart uploads of bucket mapped by their upload ID.
+// Upload directories whose details were not written yet are left out.
+func (b *FSBackend) listUploads(bucket string) (map[string]*uploadInfo, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(b.root, bucket, fsUploadsDir))
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	uploads := make(map[string]*uploadInfo, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || !uploadIDRegexp.MatchString(entry.Name()) {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(b.uploadDir(bucket, entry.Name()), fsUploadInfoFile))
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, internalError(err)
+		}
+
+		info := &uploadInfo{}
+		if err := json.Unmarshal(data, info); err != nil {
+			return nil, internalError(err)
+		}
+
+		uploads[entry.Name()] = info
+	}
+
+	return uploads, nil
+}
+
+// listParts returns the parts of a multipart upload sorted by their part number.
+func (b *FSBackend) listParts(bucket string, uploadID string) ([]*s3.Part, error) {
+	entries, err := ioutil.ReadDir(b.uploadDir(bucket, uploadID))
+	if os.IsNotExist(err) {
+		return nil, noSuchUpload()
+	}
+
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	parts := make([]*s3.Part, 0, len(entries))
+	for _, entry := range entries {
+		partNumber, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		f, info, _, err := openFile(filepath.Join(b.uploadDir(bucket, uploadID), entry.Name()))
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, internalError(err)
+		}
+		f.Close()
+
+		parts = append(parts, &s3.Part{
+			PartNumber:   aws.Int64(partNumber),
+			ETag:         aws.String(info.ETag),
+			Size:         aws.Int64(info.Size),
+			LastModified: aws.Time(info.LastModified),
+		})
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		return *parts[i].PartNumber < *parts[j].PartNumber
+	})
+
+	return parts, nil
+}
+
+// writeFile writes data read from r to a temporary file followed by a trailer of the details
+// returned by trailer, which is called with the number of bytes read from r, and then renames
+// the file to path. If trailer is nil then no trailer is written.
+func (b *FSBackend) writeFile(path string, r io.Reader, trailer func(size int64) *objectInfo) error {
+	tmp, err := ioutil.TempFile(filepath.Join(b.root, fsTempDir), "write-")
+	if err != nil {
+		return internalError(err)
+	}
+
+	// Remove the temporary file if it wasn't renamed.
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		if _, ok := err.(awserr.Error); ok {
+			return err
+		}
+
+		return internalError(err)
+	}
+
+	if trailer != nil {
+		info := trailer(size)
+		info.LastModified = now()
+		data, err := json.Marshal(info)
+		if err != nil {
+			return internalError(err)
+		}
+
+		length := make([]byte, fsTrailerLengthSize)
+		binary.BigEndian.PutUint64(length, uint64(len(data)))
+		if _, err := tmp.Write(append(data, length...)); err != nil {
+			return internalError(err)
+		}
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return internalError(err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return internalError(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return internalError(err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return internalError(err)
+	}
+
+	return nil
+}
+
+// objectPath returns the path of the file of the object with the given key in bucket.
+func (b *FSBackend) objectPath(bucket string, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(b.root, bucket, fsObjectsDir, hex.EncodeToString(sum[:]))
+}
+
+// uploadDir returns the path of the directory of the multipart upload with the given upload ID in bucket.
+func (b *FSBackend) uploadDir(bucket string, uploadID string) string {
+	return filepath.Join(b.root, bucket, fsUploadsDir, uploadID)
+}
+
+// partPath returns the path of the file of a part of a multipart upload.
+func (b *FSBackend) partPath(bucket string, uploadID string, partNumber int64) string {
+	return filepath.Join(b.uploadDir(bucket, uploadID), fmt.Sprintf("%05d", partNumber))
+}
+
+// location returns the location URL of the object with the given key in bucket.
+func (b *FSBackend) location(bucket string, key string) string {
+	return objectLocation("file://"+filepath.ToSlash(b.root), bucket, key)
+}
+
+// openFile opens the file at path and returns it with the details in its trailer and the size of its data.
+func openFile(path string) (*os.File, *objectInfo, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, 0, err
+	}
+
+	if stat.Size() < fsTrailerLengthSize {
+		f.Close()
+		return nil, nil, 0, fmt.Errorf("file %s is corrupted: missing trailer", path)
+	}
+
+	length := make([]byte, fsTrailerLengthSize)
+	if _, err := f.ReadAt(length, stat.Size()-fsTrailerLengthSize); err != nil {
+		f.Close()
+		return nil, nil, 0, err
+	}
+
+	trailerLength := int64(binary.BigEndian.Uint64(length))
+	size := stat.Size() - fsTrailerLengthSize - trailerLength
+	if trailerLength <= 0 || size < 0 {
+		f.Close()
+		return nil, nil, 0, fmt.Errorf("file %s is corrupted: invalid trailer length", path)
+	}
+
+	info := &objectInfo{}
+	if err := json.NewDecoder(io.NewSectionReader(f, size, trailerLength)).Decode(info); err != nil {
+		f.Close()
+		return nil, nil, 0, fmt.Errorf("file %s is corrupted: %v", path, err)
+	}
+
+	return f, info, size, nil
+}
+
+// newUploadID returns a new random upload ID.
+func newUploadID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+// now returns the current time in the precision S3 returns times.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
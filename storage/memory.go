@@ -0,0 +1,688 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// memoryEndpoint is the endpoint of the locations of objects stored in a MemoryBackend.
+const memoryEndpoint = "memory://localhost"
+
+// Verify that MemoryBackend implements Backend and PartReader.
+var (
+	_ Backend    = (*MemoryBackend)(nil)
+	_ PartReader = (*MemoryBackend)(nil)
+)
+
+// memoryObject is an object or an uploaded part stored in memory.
+type memoryObject struct {
+	info objectInfo
+	data []byte
+}
+
+// memoryUpload is an in-progress multipart upload stored in memory.
+type memoryUpload struct {
+	info  uploadInfo
+	parts map[int64]*memoryObject
+}
+
+// memoryBucket is a bucket stored in memory.
+type memoryBucket struct {
+	creationDate time.Time
+	objects      map[string]*memoryObject
+	uploads      map[string]*memoryUpload
+}
+
+// MemoryBackend is a Backend that stores objects in memory.
+// It behaves like S3, including multipart uploads, ETags and errors,
+// which makes it useful for testing without an S3 server.
+type MemoryBackend struct {
+	mu      sync.RWMutex
+	buckets map[string]*memoryBucket
+}
+
+// NewMemoryBackend creates an empty MemoryBackend and returns it.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{buckets: make(map[string]*memoryBucket)}
+}
+
+// GetEndpoint returns the endpoint of the locations of objects stored in the backend.
+func (b *MemoryBackend) GetEndpoint() string {
+	return memoryEndpoint
+}
+
+// PutObject stores an object's data in memory.
+func (b *MemoryBackend) PutObject(
+	ctx aws.Context,
+	input *s3manager.UploadInput,
+) (*s3manager.UploadOutput, error) {
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	// Read the body before locking so a slow body doesn't block other requests.
+	hasher := newETagHasher(uploadPartSize)
+	data, err := readAll(ctx, io.TeeReader(input.Body, hasher))
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket, err := b.getBucket(input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket.objects[*input.Key] = &memoryObject{
+		info: objectInfo{
+			Key:          *input.Key,
+			Size:         int64(len(data)),
+			ETag:         hasher.ETag(),
+			ContentType:  contentTypeOrDefault(input.ContentType),
+			Metadata:     normalizeMetadata(input.Metadata),
+			LastModified: now(),
+		},
+		data: data,
+	}
+
+	return &s3manager.UploadOutput{Location: objectLocation(memoryEndpoint, *input.Bucket, *input.Key)}, nil
+}
+
+// CreateMultipartUpload starts a new multipart upload in memory.
+func (b *MemoryBackend) CreateMultipartUpload(
+	ctx aws.Context,
+	input *s3.CreateMultipartUploadInput,
+) (*s3.CreateMultipartUploadOutput, error) {
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	uploadID, err := newUploadID()
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket, err := b.getBucket(input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket.uploads[uploadID] = &memoryUpload{
+		info: uploadInfo{
+			Key:         *input.Key,
+			ContentType: contentTypeOrDefault(input.ContentType),
+			Metadata:    normalizeMetadata(input.Metadata),
+			Initiated:   now(),
+		},
+		parts: make(map[int64]*memoryObject),
+	}
+
+	return &s3.CreateMultipartUploadOutput{
+		Bucket:   input.Bucket,
+		Key:      input.Key,
+		UploadId: aws.String(uploadID),
+	}, nil
+}
+
+// UploadPart stores a part of a multipart upload in memory.
+func (b *MemoryBackend) UploadPart(ctx aws.Context, input *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+	if err := validatePartNumber(input.PartNumber); err != nil {
+		return nil, err
+	}
+
+	hash := md5.New()
+	data, err := readAll(ctx, io.TeeReader(input.Body, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	upload, err := b.getUpload(input.Bucket, input.Key, input.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	part := &memoryObject{
+		info: objectInfo{
+			Size:         int64(len(data)),
+			ETag:         quoteETag(hash.Sum(nil)),
+			LastModified: now(),
+		},
+		data: data,
+	}
+	upload.parts[*input.PartNumber] = part
+
+	return &s3.UploadPartOutput{ETag: aws.String(part.info.ETag)}, nil
+}
+
+// UploadPartCopy stores a part of a multipart upload in memory, copied from a range of an object.
+func (b *MemoryBackend) UploadPartCopy(ctx aws.Context, input *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+	return uploadPartCopy(ctx, b, input)
+}
+
+// GetUploadPart returns a reader of an uploaded part of a multipart upload.
+func (b *MemoryBackend) GetUploadPart(
+	ctx aws.Context,
+	bucket *string,
+	key *string,
+	uploadID *string,
+	partNumber *int64,
+) (io.ReadCloser, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	upload, err := b.getUpload(bucket, key, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	part, ok := upload.parts[aws.Int64Value(partNumber)]
+	if !ok {
+		return nil, invalidPart(aws.Int64Value(partNumber))
+	}
+
+	// Parts are replaced rather than modified, so the part's data can be read without the lock.
+	return ioutil.NopCloser(bytes.NewReader(part.data)), nil
+}
+
+// ListParts lists the uploaded parts of a multipart upload.
+func (b *MemoryBackend) ListParts(ctx aws.Context, input *s3.ListPartsInput) (*s3.ListPartsOutput, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	upload, err := b.getUpload(input.Bucket, input.Key, input.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	maxParts := aws.Int64Value(input.MaxParts)
+	if maxParts <= 0 || maxParts > maxListParts {
+		maxParts = maxListParts
+	}
+
+	output := &s3.ListPartsOutput{
+		Bucket:           input.Bucket,
+		Key:              input.Key,
+		UploadId:         input.UploadId,
+		MaxParts:         aws.Int64(maxParts),
+		PartNumberMarker: aws.Int64(aws.Int64Value(input.PartNumberMarker)),
+		IsTruncated:      aws.Bool(false),
+		StorageClass:     aws.String(s3.StorageClassStandard),
+		Parts:            []*s3.Part{},
+	}
+
+	for _, part := range upload.sortedParts() {
+		if aws.Int64Value(part.PartNumber) <= aws.Int64Value(input.PartNumberMarker) {
+			continue
+		}
+
+		if int64(len(output.Parts)) == maxParts {
+			output.IsTruncated = aws.Bool(true)
+			break
+		}
+
+		output.Parts = append(output.Parts, part)
+		output.NextPartNumberMarker = part.PartNumber
+	}
+
+	return output, nil
+}
+
+// CompleteMultipartUpload assembles the given parts of a multipart upload to an object.
+func (b *MemoryBackend) CompleteMultipartUpload(
+	ctx aws.Context,
+	input *s3.CompleteMultipartUploadInput,
+) (*s3.CompleteMultipartUploadOutput, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	upload, err := b.getUpload(input.Bucket, input.Key, input.UploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MultipartUpload == nil || len(input.MultipartUpload.Parts) == 0 {
+		return nil, newError(
+			ErrCodeMalformedXML,
+			http.StatusBadRequest,
+			"The XML you provided was not well-formed or did not validate against our published schema.",
+		)
+	}
+
+	partsByNumber := make(map[int64]*s3.Part, len(upload.parts))
+	for _, part := range upload.sortedParts() {
+		partsByNumber[*part.PartNumber] = part
+	}
+
+	completedParts, err := validateCompletedParts(input.MultipartUpload.Parts, partsByNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var data bytes.Buffer
+	partSums := make([][]byte, 0, len(completedParts))
+	for _, part := range completedParts {
+		sum, err := hex.DecodeString(unquoteETag(*part.ETag))
+		if err != nil {
+			return nil, internalError(err)
+		}
+
+		partSums = append(partSums, sum)
+		data.Write(upload.parts[*part.PartNumber].data)
+	}
+
+	object := &memoryObject{
+		info: objectInfo{
+			Key:          upload.info.Key,
+			Size:         int64(data.Len()),
+			ETag:         multipartETag(partSums),
+			ContentType:  upload.info.ContentType,
+			Metadata:     upload.info.Metadata,
+			LastModified: now(),
+		},
+		data: data.Bytes(),
+	}
+
+	bucket := b.buckets[*input.Bucket]
+	bucket.objects[*input.Key] = object
+	delete(bucket.uploads, *input.UploadId)
+
+	return &s3.CompleteMultipartUploadOutput{
+		Bucket:   input.Bucket,
+		Key:      input.Key,
+		ETag:     aws.String(object.info.ETag),
+		Location: aws.String(objectLocation(memoryEndpoint, *input.Bucket, *input.Key)),
+	}, nil
+}
+
+// AbortMultipartUpload removes a multipart upload and its parts from memory.
+func (b *MemoryBackend) AbortMultipartUpload(
+	ctx aws.Context,
+	input *s3.AbortMultipartUploadInput,
+) (*s3.AbortMultipartUploadOutput, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, err := b.getUpload(input.Bucket, input.Key, input.UploadId); err != nil {
+		return nil, err
+	}
+
+	delete(b.buckets[*input.Bucket].uploads, *input.UploadId)
+
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+// ListMultipartUploads lists a page of the in-progress multipart uploads in a bucket.
+func (b *MemoryBackend) ListMultipartUploads(
+	ctx aws.Context,
+	input *s3.ListMultipartUploadsInput,
+) (*s3.ListMultipartUploadsOutput, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bucket, err := b.getBucket(input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	uploads := make(map[string]*uploadInfo, len(bucket.uploads))
+	for uploadID, upload := range bucket.uploads {
+		info := upload.info
+		uploads[uploadID] = &info
+	}
+
+	return listMultipartUploads(input, uploads)
+}
+
+// HeadObject returns an object's details.
+func (b *MemoryBackend) HeadObject(ctx aws.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	object, err := b.getObject(input.Bucket, input.Key)
+	if isCode(err, s3.ErrCodeNoSuchBucket) || isCode(err, s3.ErrCodeNoSuchKey) {
+		return nil, notFound()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return headObjectOutput(&object.info), nil
+}
+
+// GetObject returns an object's details and a reader of its data.
+func (b *MemoryBackend) GetObject(ctx aws.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	object, err := b.getObject(input.Bucket, input.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	head := headObjectOutput(&object.info)
+	output := &s3.GetObjectOutput{
+		AcceptRanges:  head.AcceptRanges,
+		ContentLength: head.ContentLength,
+		ContentType:   head.ContentType,
+		ETag:          head.ETag,
+		LastModified:  head.LastModified,
+		Metadata:      head.Metadata,
+		Body:          ioutil.NopCloser(bytes.NewReader(object.data)),
+	}
+
+	if aws.StringValue(input.Range) != "" {
+		size := int64(len(object.data))
+		start, end, err := parseRange(*input.Range, size)
+		if err != nil {
+			return nil, err
+		}
+
+		output.ContentLength = aws.Int64(end - start + 1)
+		output.ContentRange = aws.String(contentRange(start, end, size))
+		output.Body = ioutil.NopCloser(bytes.NewReader(object.data[start : end+1]))
+	}
+
+	return output, nil
+}
+
+// CopyObject copies an object to the destination bucket and key.
+func (b *MemoryBackend) CopyObject(ctx aws.Context, input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+	srcBucket, srcKey, err := parseCopySource(input.CopySource)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	source, err := b.getObject(aws.String(srcBucket), aws.String(srcKey))
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := b.getBucket(input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// A copied object is stored as a single part, so its ETag is the md5 sum of its content.
+	sum := md5.Sum(source.data)
+	object := &memoryObject{
+		info: objectInfo{
+			Key:          *input.Key,
+			Size:         source.info.Size,
+			ETag:         quoteETag(sum[:]),
+			ContentType:  source.info.ContentType,
+			Metadata:     source.info.Metadata,
+			LastModified: now(),
+		},
+		data: source.data,
+	}
+
+	if aws.StringValue(input.MetadataDirective) == s3.MetadataDirectiveReplace {
+		object.info.ContentType = contentTypeOrDefault(input.ContentType)
+		object.info.Metadata = normalizeMetadata(input.Metadata)
+	}
+
+	bucket.objects[*input.Key] = object
+
+	return &s3.CopyObjectOutput{
+		CopyObjectResult: &s3.CopyObjectResult{
+			ETag:         aws.String(object.info.ETag),
+			LastModified: aws.Time(object.info.LastModified),
+		},
+	}, nil
+}
+
+// ListObjectsV2 lists a page of the objects in a bucket sorted by their key.
+func (b *MemoryBackend) ListObjectsV2(
+	ctx aws.Context,
+	input *s3.ListObjectsV2Input,
+) (*s3.ListObjectsV2Output, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bucket, err := b.getBucket(input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*objectInfo, 0, len(bucket.objects))
+	for _, object := range bucket.objects {
+		info := object.info
+		objects = append(objects, &info)
+	}
+
+	return listObjects(input, objects)
+}
+
+// DeleteObjects deletes the given objects, objects that don't exist are reported as deleted.
+func (b *MemoryBackend) DeleteObjects(
+	ctx aws.Context,
+	input *s3.DeleteObjectsInput,
+) (*s3.DeleteObjectsOutput, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket, err := b.getBucket(input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateDeleteObjects(input); err != nil {
+		return nil, err
+	}
+
+	output := &s3.DeleteObjectsOutput{}
+	for _, object := range input.Delete.Objects {
+		if keyErr := deleteKeyError(object.Key); keyErr != nil {
+			output.Errors = append(output.Errors, keyErr)
+			continue
+		}
+
+		delete(bucket.objects, *object.Key)
+		output.Deleted = append(output.Deleted, &s3.DeletedObject{Key: object.Key})
+	}
+
+	return output, nil
+}
+
+// HeadBucket returns an error if the bucket doesn't exist.
+func (b *MemoryBackend) HeadBucket(ctx aws.Context, input *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if _, err := b.getBucket(input.Bucket); err != nil {
+		if isCode(err, s3.ErrCodeNoSuchBucket) {
+			return nil, notFound()
+		}
+
+		return nil, err
+	}
+
+	return &s3.HeadBucketOutput{}, nil
+}
+
+// CreateBucket creates an empty bucket.
+func (b *MemoryBackend) CreateBucket(
+	ctx aws.Context,
+	input *s3.CreateBucketInput,
+) (*s3.CreateBucketOutput, error) {
+	if err := validateBucketName(input.Bucket); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.buckets[*input.Bucket]; ok {
+		return nil, newError(
+			s3.ErrCodeBucketAlreadyOwnedByYou,
+			http.StatusConflict,
+			"Your previous request to create the named bucket succeeded and you already own it.",
+		)
+	}
+
+	b.buckets[*input.Bucket] = &memoryBucket{
+		creationDate: now(),
+		objects:      make(map[string]*memoryObject),
+		uploads:      make(map[string]*memoryUpload),
+	}
+
+	return &s3.CreateBucketOutput{Location: aws.String("/" + *input.Bucket)}, nil
+}
+
+// DeleteBucket deletes a bucket that has no objects and no multipart uploads.
+func (b *MemoryBackend) DeleteBucket(ctx aws.Context, input *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket, err := b.getBucket(input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bucket.objects) > 0 || len(bucket.uploads) > 0 {
+		return nil, bucketNotEmpty()
+	}
+
+	delete(b.buckets, *input.Bucket)
+
+	return &s3.DeleteBucketOutput{}, nil
+}
+
+// ListBuckets lists all of the buckets sorted by their name.
+func (b *MemoryBackend) ListBuckets(ctx aws.Context, input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	output := &s3.ListBucketsOutput{Buckets: make([]*s3.Bucket, 0, len(b.buckets))}
+	for name, bucket := range b.buckets {
+		output.Buckets = append(output.Buckets, &s3.Bucket{
+			Name:         aws.String(name),
+			CreationDate: aws.Time(bucket.creationDate),
+		})
+	}
+
+	sort.Slice(output.Buckets, func(i, j int) bool {
+		return *output.Buckets[i].Name < *output.Buckets[j].Name
+	})
+
+	return output, nil
+}
+
+// getBucket returns the bucket with the given name. b.mu must be held.
+func (b *MemoryBackend) getBucket(bucket *string) (*memoryBucket, error) {
+	if err := validateBucketName(bucket); err != nil {
+		return nil, err
+	}
+
+	memBucket, ok := b.buckets[*bucket]
+	if !ok {
+		return nil, noSuchBucket()
+	}
+
+	return memBucket, nil
+}
+
+// getObject returns the object with the given key in bucket. b.mu must be held.
+func (b *MemoryBackend) getObject(bucket *string, key *string) (*memoryObject, error) {
+	memBucket, err := b.getBucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	object, ok := memBucket.objects[*key]
+	if !ok {
+		return nil, noSuchKey()
+	}
+
+	return object, nil
+}
+
+// getUpload returns the multipart upload of key in bucket. b.mu must be held.
+// Returns a NoSuchUpload error if the upload doesn't exist or belongs to another key.
+func (b *MemoryBackend) getUpload(bucket *string, key *string, uploadID *string) (*memoryUpload, error) {
+	memBucket, err := b.getBucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	upload, ok := memBucket.uploads[aws.StringValue(uploadID)]
+	if !ok || upload.info.Key != *key {
+		return nil, noSuchUpload()
+	}
+
+	return upload, nil
+}
+
+// sortedParts returns the upload's parts sorted by their part number.
+func (u *memoryUpload) sortedParts() []*s3.Part {
+	parts := make([]*s3.Part, 0, len(u.parts))
+	for partNumber, part := range u.parts {
+		parts = append(parts, &s3.Part{
+			PartNumber:   aws.Int64(partNumber),
+			ETag:         aws.String(part.info.ETag),
+			Size:         aws.Int64(part.info.Size),
+			LastModified: aws.Time(part.info.LastModified),
+		})
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		return *parts[i].PartNumber < *parts[j].PartNumber
+	})
+
+	return parts
+}
+
+// readAll reads r until EOF and returns the data it read, or a RequestCanceled error
+// if ctx is done before that.
+func readAll(ctx aws.Context, r io.Reader) ([]byte, error) {
+	if r == nil {
+		return []byte{}, nil
+	}
+
+	data, err := ioutil.ReadAll(&contextReader{ctx: ctx, r: r})
+	if err != nil {
+		if isCode(err, request.CanceledErrorCode) {
+			return nil, err
+		}
+
+		return nil, internalError(err)
+	}
+
+	return data, nil
+}
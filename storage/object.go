@@ -0,0 +1,636 @@
+package storage
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// defaultContentType is the content type S3 gives objects uploaded without one.
+	defaultContentType = "binary/octet-stream"
+
+	// minPartSize is the minimal size of a multipart upload part, other than the last part.
+	minPartSize = 5 * 1024 * 1024
+
+	// maxKeyLength is the maximal length of an object key.
+	maxKeyLength = 1024
+
+	// maxListParts is the maximal number of parts returned by ListParts.
+	maxListParts = 1000
+
+	// maxListUploads is the maximal number of uploads returned by ListMultipartUploads.
+	maxListUploads = 1000
+
+	// maxListKeys is the maximal number of keys and common prefixes returned by ListObjectsV2.
+	maxListKeys = 1000
+
+	// maxDeleteKeys is the maximal number of keys deleted by DeleteObjects.
+	maxDeleteKeys = 1000
+)
+
+// bucketNameRegexp matches a valid S3 bucket name.
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// byteRangeRegexp matches a single HTTP byte range and captures its start and end.
+var byteRangeRegexp = regexp.MustCompile(`^bytes=(\d*)-(\d*)$`)
+
+// objectInfo is the stored details of an object or an uploaded part.
+type objectInfo struct {
+	Key          string            `json:"key,omitempty"`
+	Size         int64             `json:"size"`
+	ETag         string            `json:"etag"`
+	ContentType  string            `json:"contentType,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	LastModified time.Time         `json:"lastModified"`
+}
+
+// uploadInfo is the stored details of an in-progress multipart upload.
+type uploadInfo struct {
+	Key         string            `json:"key"`
+	ContentType string            `json:"contentType,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Initiated   time.Time         `json:"initiated"`
+}
+
+// validateBucketName returns an error if bucket is not a valid bucket name.
+func validateBucketName(bucket *string) error {
+	if bucket == nil || !bucketNameRegexp.MatchString(*bucket) {
+		return newError(ErrCodeInvalidBucketName, http.StatusBadRequest, "The specified bucket is not valid.")
+	}
+
+	return nil
+}
+
+// validateKey returns an error if key is not a valid object key.
+func validateKey(key *string) error {
+	if key == nil || *key == "" || len(*key) > maxKeyLength {
+		return newError(ErrCodeInvalidArgument, http.StatusBadRequest, "The specified key is not valid.")
+	}
+
+	return nil
+}
+
+// validatePartNumber returns an error if partNumber is not a valid part number.
+func validatePartNumber(partNumber *int64) error {
+	if partNumber == nil || *partNumber < 1 || *partNumber > 10000 {
+		return newError(
+			ErrCodeInvalidArgument,
+			http.StatusBadRequest,
+			"Part number must be an integer between 1 and 10000, inclusive.",
+		)
+	}
+
+	return nil
+}
+
+// validateDeleteObjects returns an error if a delete request has no objects or an object without a key,
+// the same way the S3 client validates the request before sending it, or if it has more than 1000
+// objects, which S3 rejects.
+func validateDeleteObjects(input *s3.DeleteObjectsInput) error {
+	if input.Delete == nil || len(input.Delete.Objects) == 0 || len(input.Delete.Objects) > maxDeleteKeys {
+		return newError(
+			ErrCodeMalformedXML,
+			http.StatusBadRequest,
+			"The XML you provided was not well-formed or did not validate against our published schema.",
+		)
+	}
+
+	for _, object := range input.Delete.Objects {
+		if object == nil || aws.StringValue(object.Key) == "" {
+			return awserr.New(request.InvalidParameterErrCode, "1 validation error(s) found.", nil)
+		}
+	}
+
+	return nil
+}
+
+// deleteKeyError returns the error S3 reports in DeleteObjects for a key that can't be deleted,
+// or nil if key can be deleted.
+func deleteKeyError(key *string) *s3.Error {
+	if len(aws.StringValue(key)) > maxKeyLength {
+		return &s3.Error{
+			Key:     key,
+			Code:    aws.String(ErrCodeKeyTooLong),
+			Message: aws.String("Your key is too long"),
+		}
+	}
+
+	return nil
+}
+
+// noSuchBucket returns an error for a request to a bucket that doesn't exist.
+func noSuchBucket() error {
+	return newError(s3.ErrCodeNoSuchBucket, http.StatusNotFound, "The specified bucket does not exist.")
+}
+
+// noSuchKey returns an error for a request to an object that doesn't exist.
+func noSuchKey() error {
+	return newError(s3.ErrCodeNoSuchKey, http.StatusNotFound, "The specified key does not exist.")
+}
+
+// noSuchUpload returns an error for a request to a multipart upload that doesn't exist.
+func noSuchUpload() error {
+	return newError(
+		s3.ErrCodeNoSuchUpload,
+		http.StatusNotFound,
+		"The specified multipart upload does not exist. The upload ID might be invalid, "+
+			"or the multipart upload might have been aborted or completed.",
+	)
+}
+
+// invalidPart returns the error of a request to a part of a multipart upload that wasn't uploaded.
+func invalidPart(partNumber int64) error {
+	return newError(
+		ErrCodeInvalidPart,
+		http.StatusBadRequest,
+		fmt.Sprintf("One or more of the specified parts could not be found. "+
+			"The part may not have been uploaded, or the specified entity tag may not match "+
+			"the part's entity tag. Part number: %d", partNumber),
+	)
+}
+
+// notFound returns the error of a HEAD request to a bucket or an object that doesn't exist.
+func notFound() error {
+	return newError(ErrCodeNotFound, http.StatusNotFound, "Not Found")
+}
+
+// preconditionFailed returns the error of a conditional request whose precondition doesn't hold.
+func preconditionFailed() error {
+	return newError(ErrCodePreconditionFailed, http.StatusPreconditionFailed, "At least one of the pre-conditions you specified did not hold")
+}
+
+// uploadPartCopy uploads a part in a multipart upload of backend by copying a range of an object,
+// using the backend's GetObject and UploadPart.
+func uploadPartCopy(ctx aws.Context, backend Backend, input *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+	srcBucket, srcKey, err := parseCopySource(input.CopySource)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := backend.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(srcBucket),
+		Key:    aws.String(srcKey),
+		Range:  input.CopySourceRange,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer source.Body.Close()
+
+	ifMatch := aws.StringValue(input.CopySourceIfMatch)
+	if ifMatch != "" && unquoteETag(ifMatch) != unquoteETag(aws.StringValue(source.ETag)) {
+		return nil, preconditionFailed()
+	}
+
+	output, err := backend.UploadPart(ctx, &s3.UploadPartInput{
+		Body:       aws.ReadSeekCloser(source.Body),
+		Bucket:     input.Bucket,
+		Key:        input.Key,
+		PartNumber: input.PartNumber,
+		UploadId:   input.UploadId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3.UploadPartCopyOutput{
+		CopyPartResult: &s3.CopyPartResult{
+			ETag:         output.ETag,
+			LastModified: aws.Time(now()),
+		},
+	}, nil
+}
+
+// normalizeMetadata returns a copy of metadata with its keys canonicalized the same way
+// S3 returns them, i.e "x-amz-meta-my-key" is returned as "My-Key".
+func normalizeMetadata(metadata map[string]*string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		normalized[http.CanonicalHeaderKey(key)] = aws.StringValue(value)
+	}
+
+	return normalized
+}
+
+// contentTypeOrDefault returns contentType, or the default content type if it's empty.
+func contentTypeOrDefault(contentType *string) string {
+	if aws.StringValue(contentType) == "" {
+		return defaultContentType
+	}
+
+	return *contentType
+}
+
+// parseCopySource parses an S3 CopySource value, "<bucket>/<key>" optionally url encoded,
+// to its bucket and key.
+func parseCopySource(copySource *string) (string, string, error) {
+	source, err := url.QueryUnescape(aws.StringValue(copySource))
+	if err != nil {
+		return "", "", newError(ErrCodeInvalidArgument, http.StatusBadRequest, "Copy Source must mention the source bucket and key: sourcebucket/sourcekey.")
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(source, "/"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", newError(ErrCodeInvalidArgument, http.StatusBadRequest, "Copy Source must mention the source bucket and key: sourcebucket/sourcekey.")
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// parseRange parses an HTTP byte range of an object of the given size to its
+// inclusive start and end offsets.
+func parseRange(byteRange string, size int64) (int64, int64, error) {
+	invalidRange := newError(ErrCodeInvalidRange, http.StatusRequestedRangeNotSatisfiable, "The requested range is not satisfiable")
+
+	matches := byteRangeRegexp.FindStringSubmatch(byteRange)
+	if matches == nil || (matches[1] == "" && matches[2] == "") {
+		return 0, 0, invalidRange
+	}
+
+	var start, end int64
+	var err error
+	switch {
+	case matches[1] == "":
+		// Suffix range, the last n bytes of the object.
+		suffix, err := strconv.ParseInt(matches[2], 10, 64)
+		if err != nil || suffix == 0 || size == 0 {
+			return 0, 0, invalidRange
+		}
+
+		if suffix > size {
+			suffix = size
+		}
+
+		start, end = size-suffix, size-1
+	case matches[2] == "":
+		if start, err = strconv.ParseInt(matches[1], 10, 64); err != nil {
+			return 0, 0, invalidRange
+		}
+
+		end = size - 1
+	default:
+		if start, err = strconv.ParseInt(matches[1], 10, 64); err != nil {
+			return 0, 0, invalidRange
+		}
+
+		if end, err = strconv.ParseInt(matches[2], 10, 64); err != nil || end < start {
+			return 0, 0, invalidRange
+		}
+
+		if end > size-1 {
+			end = size - 1
+		}
+	}
+
+	if start >= size {
+		return 0, 0, invalidRange
+	}
+
+	return start, end, nil
+}
+
+// contentRange returns the Content-Range value of the given range of an object of the given size.
+func contentRange(start int64, end int64, size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", start, end, size)
+}
+
+// quoteETag returns the quoted ETag of the given md5 sum, the way S3 returns ETags.
+func quoteETag(sum []byte) string {
+	return strconv.Quote(hex.EncodeToString(sum))
+}
+
+// unquoteETag returns etag without its surrounding quotes.
+func unquoteETag(etag string) string {
+	return strings.Trim(etag, `"`)
+}
+
+// multipartETag returns the ETag S3 gives an object assembled from parts with the given md5 sums,
+// which is the md5 sum of the concatenated part sums followed by "-<number of parts>".
+func multipartETag(partSums [][]byte) string {
+	h := md5.New()
+	for _, sum := range partSums {
+		h.Write(sum)
+	}
+
+	return strconv.Quote(fmt.Sprintf("%s-%d", hex.EncodeToString(h.Sum(nil)), len(partSums)))
+}
+
+// etagHasher is an io.Writer that computes the ETag S3 would give the written data
+// if it was uploaded by the S3 upload manager, which uploads data larger than a
+// single part as a multipart upload.
+type etagHasher struct {
+	partSize int64
+	written  int64
+	part     hash.Hash
+	partSums [][]byte
+}
+
+// newETagHasher returns an etagHasher of uploads with the given part size.
+func newETagHasher(partSize int64) *etagHasher {
+	return &etagHasher{partSize: partSize, part: md5.New()}
+}
+
+// Write writes p to the hash of the current part, starting a new part when the current one is full.
+func (h *etagHasher) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		remaining := h.partSize - h.written%h.partSize
+		chunk := p
+		if int64(len(chunk)) > remaining {
+			chunk = p[:remaining]
+		}
+
+		h.part.Write(chunk)
+		h.written += int64(len(chunk))
+		p = p[len(chunk):]
+
+		if h.written%h.partSize == 0 {
+			h.partSums = append(h.partSums, h.part.Sum(nil))
+			h.part.Reset()
+		}
+	}
+
+	return n, nil
+}
+
+// ETag returns the ETag of the written data.
+func (h *etagHasher) ETag() string {
+	partSums := h.partSums
+	if h.written == 0 || h.written%h.partSize != 0 {
+		partSums = append(partSums, h.part.Sum(nil))
+	}
+
+	if len(partSums) == 1 {
+		return quoteETag(partSums[0])
+	}
+
+	return multipartETag(partSums)
+}
+
+// objectLocation returns the location URL of the object with the given key in bucket under endpoint,
+// in the same path style format S3 returns.
+func objectLocation(endpoint string, bucket string, key string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(endpoint, "/"), bucket, (&url.URL{Path: key}).EscapedPath())
+}
+
+// headObjectOutput returns the HeadObject output of the object with the given info.
+func headObjectOutput(info *objectInfo) *s3.HeadObjectOutput {
+	return &s3.HeadObjectOutput{
+		AcceptRanges:  aws.String("bytes"),
+		ContentLength: aws.Int64(info.Size),
+		ContentType:   aws.String(info.ContentType),
+		ETag:          aws.String(info.ETag),
+		LastModified:  aws.Time(info.LastModified),
+		Metadata:      aws.StringMap(info.Metadata),
+	}
+}
+
+// validateCompletedParts validates the parts of a complete multipart upload request against the
+// uploaded parts of the upload, and returns the uploaded parts in the order they should be assembled.
+func validateCompletedParts(completed []*s3.CompletedPart, uploaded map[int64]*s3.Part) ([]*s3.Part, error) {
+	parts := make([]*s3.Part, 0, len(completed))
+	var lastPartNumber int64
+	for _, completedPart := range completed {
+		partNumber := aws.Int64Value(completedPart.PartNumber)
+		if partNumber <= lastPartNumber {
+			return nil, newError(
+				ErrCodeInvalidPartOrder,
+				http.StatusBadRequest,
+				"The list of parts was not in ascending order. The parts list must be specified in order by part number.",
+			)
+		}
+		lastPartNumber = partNumber
+
+		part, ok := uploaded[partNumber]
+		if !ok || unquoteETag(aws.StringValue(part.ETag)) != unquoteETag(aws.StringValue(completedPart.ETag)) {
+			return nil, invalidPart(partNumber)
+		}
+
+		parts = append(parts, part)
+	}
+
+	for _, part := range parts[:len(parts)-1] {
+		if aws.Int64Value(part.Size) < minPartSize {
+			return nil, newError(
+				ErrCodeEntityTooSmall,
+				http.StatusBadRequest,
+				"Your proposed upload is smaller than the minimum allowed object size.",
+			)
+		}
+	}
+
+	return parts, nil
+}
+
+// listObjects returns the page of objects requested by input out of the given objects of a bucket.
+// Keys that contain the delimiter after the prefix are rolled up into common prefixes,
+// and the continuation token of the next page is the base64 encoding of the last key or
+// common prefix in the page.
+func listObjects(input *s3.ListObjectsV2Input, objects []*objectInfo) (*s3.ListObjectsV2Output, error) {
+	maxKeys := int64(maxListKeys)
+	if input.MaxKeys != nil && *input.MaxKeys < maxKeys {
+		maxKeys = *input.MaxKeys
+	}
+
+	if maxKeys < 0 {
+		return nil, newError(ErrCodeInvalidArgument, http.StatusBadRequest, "maxKeys should be non-negative")
+	}
+
+	prefix := aws.StringValue(input.Prefix)
+	delimiter := aws.StringValue(input.Delimiter)
+	marker := aws.StringValue(input.StartAfter)
+	if token := aws.StringValue(input.ContinuationToken); token != "" {
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, newError(
+				ErrCodeInvalidArgument,
+				http.StatusBadRequest,
+				"The continuation token provided is incorrect",
+			)
+		}
+
+		marker = string(decoded)
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	output := &s3.ListObjectsV2Output{
+		Name:              input.Bucket,
+		Prefix:            aws.String(prefix),
+		Delimiter:         input.Delimiter,
+		MaxKeys:           aws.Int64(maxKeys),
+		ContinuationToken: input.ContinuationToken,
+		StartAfter:        input.StartAfter,
+		IsTruncated:       aws.Bool(false),
+		Contents:          []*s3.Object{},
+		CommonPrefixes:    []*s3.CommonPrefix{},
+	}
+
+	var count int64
+	last := ""
+	for _, object := range objects {
+		if object.Key <= marker || !strings.HasPrefix(object.Key, prefix) {
+			continue
+		}
+
+		commonPrefix := ""
+		if delimiter != "" {
+			if i := strings.Index(object.Key[len(prefix):], delimiter); i >= 0 {
+				commonPrefix = object.Key[:len(prefix)+i+len(delimiter)]
+			}
+		}
+
+		// Skip the keys of a common prefix that was already listed in this page or a previous one.
+		if commonPrefix != "" && (commonPrefix == last || commonPrefix <= marker) {
+			continue
+		}
+
+		if count == maxKeys {
+			output.IsTruncated = aws.Bool(true)
+			output.NextContinuationToken = aws.String(base64.StdEncoding.EncodeToString([]byte(last)))
+			break
+		}
+
+		if commonPrefix != "" {
+			output.CommonPrefixes = append(output.CommonPrefixes, &s3.CommonPrefix{Prefix: aws.String(commonPrefix)})
+			last = commonPrefix
+		} else {
+			output.Contents = append(output.Contents, &s3.Object{
+				Key:          aws.String(object.Key),
+				Size:         aws.Int64(object.Size),
+				ETag:         aws.String(object.ETag),
+				LastModified: aws.Time(object.LastModified),
+				StorageClass: aws.String(s3.ObjectStorageClassStandard),
+			})
+			last = object.Key
+		}
+		count++
+	}
+
+	output.KeyCount = aws.Int64(count)
+
+	return output, nil
+}
+
+// listMultipartUploads returns the page of uploads requested by input out of the given
+// uploads of a bucket, mapped by their upload ID.
+func listMultipartUploads(
+	input *s3.ListMultipartUploadsInput,
+	uploads map[string]*uploadInfo,
+) (*s3.ListMultipartUploadsOutput, error) {
+	maxUploads := int64(maxListUploads)
+	if input.MaxUploads != nil && *input.MaxUploads < maxUploads {
+		maxUploads = *input.MaxUploads
+	}
+
+	if maxUploads < 0 {
+		return nil, newError(ErrCodeInvalidArgument, http.StatusBadRequest, "maxUploads should be non-negative")
+	}
+
+	uploadIDs := make([]string, 0, len(uploads))
+	for uploadID := range uploads {
+		uploadIDs = append(uploadIDs, uploadID)
+	}
+
+	sort.Slice(uploadIDs, func(i, j int) bool {
+		keyI, keyJ := uploads[uploadIDs[i]].Key, uploads[uploadIDs[j]].Key
+		return keyI < keyJ || (keyI == keyJ && uploadIDs[i] < uploadIDs[j])
+	})
+
+	prefix := aws.StringValue(input.Prefix)
+	keyMarker := aws.StringValue(input.KeyMarker)
+	uploadIDMarker := aws.StringValue(input.UploadIdMarker)
+	output := &s3.ListMultipartUploadsOutput{
+		Bucket:         input.Bucket,
+		Prefix:         aws.String(prefix),
+		KeyMarker:      aws.String(keyMarker),
+		UploadIdMarker: aws.String(uploadIDMarker),
+		MaxUploads:     aws.Int64(maxUploads),
+		IsTruncated:    aws.Bool(false),
+		Uploads:        []*s3.MultipartUpload{},
+	}
+
+	for _, uploadID := range uploadIDs {
+		info := uploads[uploadID]
+		if !strings.HasPrefix(info.Key, prefix) {
+			continue
+		}
+
+		// Skip the uploads up to the markers, the upload ID marker is
+		// only used if the key marker is given.
+		if keyMarker != "" && (info.Key < keyMarker || (info.Key == keyMarker && uploadID <= uploadIDMarker)) {
+			continue
+		}
+
+		if int64(len(output.Uploads)) == maxUploads {
+			output.IsTruncated = aws.Bool(true)
+			break
+		}
+
+		output.Uploads = append(output.Uploads, &s3.MultipartUpload{
+			Key:          aws.String(info.Key),
+			UploadId:     aws.String(uploadID),
+			Initiated:    aws.Time(info.Initiated),
+			StorageClass: aws.String(s3.StorageClassStandard),
+		})
+		output.NextKeyMarker = aws.String(info.Key)
+		output.NextUploadIdMarker = aws.String(uploadID)
+	}
+
+	return output, nil
+}
+
+// bucketNotEmpty returns a BucketNotEmpty error.
+func bucketNotEmpty() error {
+	return newError(ErrCodeBucketNotEmpty, http.StatusConflict, "The bucket you tried to delete is not empty")
+}
+
+// isCode returns true if err is an awserr.Error with the given code.
+func isCode(err error, code string) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == code
+}
+
+// contextReader is an io.Reader that stops reading once its context is done.
+type contextReader struct {
+	ctx aws.Context
+	r   io.Reader
+}
+
+// Read reads from the underlying reader, or returns a RequestCanceled error if the context is done.
+func (r *contextReader) Read(p []byte) (int, error) {
+	select {
+	case <-r.ctx.Done():
+		return 0, awserr.New(request.CanceledErrorCode, "request context canceled", r.ctx.Err())
+	default:
+		return r.r.Read(p)
+	}
+}
+
+// readCloser is an io.ReadCloser composed of a reader and a closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// uploadPartSize is the size of each part uploaded by the S3 uploader.
+const uploadPartSize = 32 * 1024 * 1024 // 32MB per part
+
+// Verify that S3Backend implements Backend and Presigner.
+var (
+	_ Backend   = (*S3Backend)(nil)
+	_ Presigner = (*S3Backend)(nil)
+)
+
+// S3Backend is a Backend that stores objects in S3.
+type S3Backend struct {
+	s3Client *s3.S3
+}
+
+// NewS3Backend creates an S3Backend with the given s3 client and returns it.
+func NewS3Backend(s3Client *s3.S3) *S3Backend {
+	return &S3Backend{s3Client: s3Client}
+}
+
+// GetS3Client returns the internal s3 client.
+func (b *S3Backend) GetS3Client() *s3.S3 {
+	return b.s3Client
+}
+
+// PutObject uploads an object to S3 using the S3 upload manager, which splits
+// large objects to parts and uploads them concurrently.
+func (b *S3Backend) PutObject(ctx aws.Context, input *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
+	// Create an uploader with S3 client and custom options
+	uploader := s3manager.NewUploaderWithClient(b.s3Client, func(u *s3manager.Uploader) {
+		u.PartSize = uploadPartSize
+	})
+
+	return uploader.UploadWithContext(ctx, input)
+}
+
+// CreateMultipartUpload initiates a multipart upload in S3.
+func (b *S3Backend) CreateMultipartUpload(
+	ctx aws.Context,
+	input *s3.CreateMultipartUploadInput,
+) (*s3.CreateMultipartUploadOutput, error) {
+	return b.s3Client.CreateMultipartUploadWithContext(ctx, input)
+}
+
+// UploadPart uploads a part in a multipart upload in S3.
+func (b *S3Backend) UploadPart(ctx aws.Context, input *s3.UploadPartInput) (*s3.UploadPartOutput, error) {
+	return b.s3Client.UploadPartWithContext(ctx, input)
+}
+
+// UploadPartCopy uploads a part in a multipart upload in S3 by copying a range of an object.
+func (b *S3Backend) UploadPartCopy(ctx aws.Context, input *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error) {
+	return b.s3Client.UploadPartCopyWithContext(ctx, input)
+}
+
+// ListParts lists the uploaded parts of a multipart upload in S3.
+func (b *S3Backend) ListParts(ctx aws.Context, input *s3.ListPartsInput) (*s3.ListPartsOutput, error) {
+	return b.s3Client.ListPartsWithContext(ctx, input)
+}
+
+// CompleteMultipartUpload completes a multipart upload in S3.
+func (b *S3Backend) CompleteMultipartUpload(
+	ctx aws.Context,
+	input *s3.CompleteMultipartUploadInput,
+) (*s3.CompleteMultipartUploadOutput, error) {
+	return b.s3Client.CompleteMultipartUploadWithContext(ctx, input)
+}
+
+// AbortMultipartUpload aborts a multipart upload in S3.
+func (b *S3Backend) AbortMultipartUpload(
+	ctx aws.Context,
+	input *s3.AbortMultipartUploadInput,
+) (*s3.AbortMultipartUploadOutput, error) {
+	return b.s3Client.AbortMultipartUploadWithContext(ctx, input)
+}
+
+// ListMultipartUploads lists a page of the in-progress multipart uploads in a bucket.
+func (b *S3Backend) ListMultipartUploads(
+	ctx aws.Context,
+	input *s3.ListMultipartUploadsInput,
+) (*s3.ListMultipartUploadsOutput, error) {
+	return b.s3Client.ListMultipartUploadsWithContext(ctx, input)
+}
+
+// HeadObject returns an object's details from S3.
+func (b *S3Backend) HeadObject(ctx aws.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error) {
+	return b.s3Client.HeadObjectWithContext(ctx, input)
+}
+
+// GetObject returns an object from S3.
+func (b *S3Backend) GetObject(ctx aws.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	return b.s3Client.GetObjectWithContext(ctx, input)
+}
+
+// CopyObject copies an object in S3.
+func (b *S3Backend) CopyObject(ctx aws.Context, input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+	return b.s3Client.CopyObjectWithContext(ctx, input)
+}
+
+// ListObjectsV2 lists a page of the objects in a bucket.
+func (b *S3Backend) ListObjectsV2(ctx aws.Context, input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+	return b.s3Client.ListObjectsV2WithContext(ctx, input)
+}
+
+// DeleteObjects deletes multiple objects from an S3 bucket.
+func (b *S3Backend) DeleteObjects(
+	ctx aws.Context,
+	input *s3.DeleteObjectsInput,
+) (*s3.DeleteObjectsOutput, error) {
+	return b.s3Client.DeleteObjectsWithContext(ctx, input)
+}
+
+// HeadBucket checks that an S3 bucket exists and is accessible.
+func (b *S3Backend) HeadBucket(ctx aws.Context, input *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+	return b.s3Client.HeadBucketWithContext(ctx, input)
+}
+
+// CreateBucket creates an S3 bucket.
+func (b *S3Backend) CreateBucket(ctx aws.Context, input *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
+	return b.s3Client.CreateBucketWithContext(ctx, input)
+}
+
+// DeleteBucket deletes an empty bucket.
+func (b *S3Backend) DeleteBucket(ctx aws.Context, input *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error) {
+	return b.s3Client.DeleteBucketWithContext(ctx, input)
+}
+
+// ListBuckets lists all of the S3 buckets.
+func (b *S3Backend) ListBuckets(ctx aws.Context, input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+	return b.s3Client.ListBucketsWithContext(ctx, input)
+}
+
+// PresignGetObject returns a presigned URL of a GetObject request.
+func (b *S3Backend) PresignGetObject(input *s3.GetObjectInput, expiry time.Duration) (string, http.Header, error) {
+	req, _ := b.s3Client.GetObjectRequest(input)
+	return req.PresignRequest(expiry)
+}
+
+// PresignPutObject returns a presigned URL of a PutObject request.
+func (b *S3Backend) PresignPutObject(input *s3.PutObjectInput, expiry time.Duration) (string, http.Header, error) {
+	req, _ := b.s3Client.PutObjectRequest(input)
+	return req.PresignRequest(expiry)
+}
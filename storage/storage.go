@@ -0,0 +1,110 @@
+// Package storage defines the storage backend used by the upload service
+// and its implementations.
+package storage
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// Backend is the interface of an object storage backend.
+// Its operations follow the semantics of their S3 API counterparts,
+// including returning awserr.Error errors with S3 error codes,
+// so that the object and bucket services can use any implementation interchangeably.
+type Backend interface {
+	// PutObject uploads an object's data read from input.Body.
+	PutObject(ctx aws.Context, input *s3manager.UploadInput) (*s3manager.UploadOutput, error)
+
+	// CreateMultipartUpload initiates a multipart upload and returns its upload ID.
+	CreateMultipartUpload(
+		ctx aws.Context,
+		input *s3.CreateMultipartUploadInput,
+	) (*s3.CreateMultipartUploadOutput, error)
+
+	// UploadPart uploads a part in a multipart upload.
+	UploadPart(ctx aws.Context, input *s3.UploadPartInput) (*s3.UploadPartOutput, error)
+
+	// UploadPartCopy uploads a part in a multipart upload by copying a range of an existing object.
+	UploadPartCopy(ctx aws.Context, input *s3.UploadPartCopyInput) (*s3.UploadPartCopyOutput, error)
+
+	// ListParts lists the parts that have been uploaded for a multipart upload.
+	ListParts(ctx aws.Context, input *s3.ListPartsInput) (*s3.ListPartsOutput, error)
+
+	// CompleteMultipartUpload completes a multipart upload by assembling previously uploaded parts.
+	CompleteMultipartUpload(
+		ctx aws.Context,
+		input *s3.CompleteMultipartUploadInput,
+	) (*s3.CompleteMultipartUploadOutput, error)
+
+	// AbortMultipartUpload aborts a multipart upload and frees its uploaded parts.
+	AbortMultipartUpload(
+		ctx aws.Context,
+		input *s3.AbortMultipartUploadInput,
+	) (*s3.AbortMultipartUploadOutput, error)
+
+	// ListMultipartUploads lists a page of the in-progress multipart uploads in a bucket,
+	// sorted by their key and upload ID.
+	ListMultipartUploads(
+		ctx aws.Context,
+		input *s3.ListMultipartUploadsInput,
+	) (*s3.ListMultipartUploadsOutput, error)
+
+	// HeadObject returns an object's details without its content.
+	HeadObject(ctx aws.Context, input *s3.HeadObjectInput) (*s3.HeadObjectOutput, error)
+
+	// GetObject returns an object's details and a reader of its content.
+	GetObject(ctx aws.Context, input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+
+	// CopyObject creates a copy of an object.
+	CopyObject(ctx aws.Context, input *s3.CopyObjectInput) (*s3.CopyObjectOutput, error)
+
+	// ListObjectsV2 lists a page of the objects in a bucket sorted by their key.
+	ListObjectsV2(ctx aws.Context, input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
+
+	// DeleteObjects deletes multiple objects from a bucket.
+	DeleteObjects(ctx aws.Context, input *s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+
+	// HeadBucket returns an error if the bucket doesn't exist or is not accessible.
+	HeadBucket(ctx aws.Context, input *s3.HeadBucketInput) (*s3.HeadBucketOutput, error)
+
+	// CreateBucket creates a new bucket.
+	CreateBucket(ctx aws.Context, input *s3.CreateBucketInput) (*s3.CreateBucketOutput, error)
+
+	// DeleteBucket deletes a bucket, which must have no objects and no multipart uploads.
+	DeleteBucket(ctx aws.Context, input *s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error)
+
+	// ListBuckets lists all of the buckets, it is also used to check the backend's health.
+	ListBuckets(ctx aws.Context, input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error)
+}
+
+// Presigner is implemented by storage backends that can generate presigned URLs,
+// which grant clients access to an object directly in the storage for a limited time.
+type Presigner interface {
+	// PresignGetObject returns a URL for downloading an object that expires after expiry,
+	// and the headers that must be sent with it.
+	PresignGetObject(input *s3.GetObjectInput, expiry time.Duration) (string, http.Header, error)
+
+	// PresignPutObject returns a URL for uploading an object that expires after expiry,
+	// and the headers that must be sent with it.
+	PresignPutObject(input *s3.PutObjectInput, expiry time.Duration) (string, http.Header, error)
+}
+
+// PartReader is implemented by storage backends that can read the uploaded parts of
+// a multipart upload before it's completed, which S3 can't.
+type PartReader interface {
+	// GetUploadPart returns a reader of the content of an uploaded part of a multipart upload.
+	// Returns an InvalidPart error if the part wasn't uploaded. The caller is responsible
+	// for closing the returned reader.
+	GetUploadPart(
+		ctx aws.Context,
+		bucket *string,
+		key *string,
+		uploadID *string,
+		partNumber *int64,
+	) (io.ReadCloser, error)
+}
@@ -0,0 +1,1065 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/meateam/upload-service/storage"
+)
+
+// testBackend is a storage backend under test.
+type testBackend struct {
+	name     string
+	backend  storage.Backend
+	endpoint string
+	cleanup  func()
+}
+
+// newTestBackends creates every storage backend with the given buckets.
+func newTestBackends(t *testing.T, buckets ...string) []testBackend {
+	root, err := ioutil.TempDir("", "upload-service-fs")
+	if err != nil {
+		t.Fatalf("Could not create temp dir with error: %v", err)
+	}
+
+	fsBackend, err := storage.NewFSBackend(root)
+	if err != nil {
+		t.Fatalf("storage.NewFSBackend() error = %v", err)
+	}
+
+	memoryBackend := storage.NewMemoryBackend()
+	backends := []testBackend{
+		{
+			name:     "filesystem",
+			backend:  fsBackend,
+			endpoint: "file://" + fsBackend.GetRoot(),
+			cleanup:  func() { os.RemoveAll(root) },
+		},
+		{
+			name:     "memory",
+			backend:  memoryBackend,
+			endpoint: memoryBackend.GetEndpoint(),
+			cleanup:  func() {},
+		},
+	}
+
+	for _, tb := range backends {
+		for _, bucket := range buckets {
+			if _, err := tb.backend.CreateBucket(context.Background(), &s3.CreateBucketInput{Bucket: aws.String(bucket)}); err != nil {
+				t.Fatalf("%s CreateBucket() error = %v", tb.name, err)
+			}
+		}
+	}
+
+	return backends
+}
+
+// errCode returns the code of err if it's an awserr.Error.
+func errCode(err error) string {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code()
+	}
+
+	return ""
+}
+
+// md5ETag returns the quoted md5 ETag of data.
+func md5ETag(data []byte) string {
+	sum := md5.Sum(data)
+	return fmt.Sprintf("%q", hex.EncodeToString(sum[:]))
+}
+
+// multipartETag returns the ETag of an object assembled from parts.
+func multipartETag(parts ...[]byte) string {
+	h := md5.New()
+	for _, part := range parts {
+		sum := md5.Sum(part)
+		h.Write(sum[:])
+	}
+
+	return fmt.Sprintf("%q", fmt.Sprintf("%s-%d", hex.EncodeToString(h.Sum(nil)), len(parts)))
+}
+
+func TestBackend_Buckets(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			tests := []struct {
+				name     string
+				bucket   *string
+				wantCode string
+			}{
+				{name: "create bucket", bucket: aws.String("testbucket1")},
+				{name: "create bucket - already exists", bucket: aws.String("testbucket"), wantCode: s3.ErrCodeBucketAlreadyOwnedByYou},
+				{name: "create bucket - invalid name", bucket: aws.String("Test@Bucket"), wantCode: storage.ErrCodeInvalidBucketName},
+				{name: "create bucket - nil bucket", bucket: nil, wantCode: storage.ErrCodeInvalidBucketName},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					_, err := backend.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: tt.bucket})
+					if errCode(err) != tt.wantCode || (err != nil) != (tt.wantCode != "") {
+						t.Errorf("Backend.CreateBucket() error = %v, wantCode %v", err, tt.wantCode)
+					}
+				})
+			}
+
+			if _, err := backend.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String("testbucket1")}); err != nil {
+				t.Errorf("Backend.HeadBucket() error = %v", err)
+			}
+
+			_, err := backend.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String("notexistbucket")})
+			if errCode(err) != storage.ErrCodeNotFound {
+				t.Errorf("Backend.HeadBucket() error = %v, wantCode %v", err, storage.ErrCodeNotFound)
+			}
+
+			buckets, err := backend.ListBuckets(ctx, &s3.ListBucketsInput{})
+			if err != nil {
+				t.Fatalf("Backend.ListBuckets() error = %v", err)
+			}
+
+			if len(buckets.Buckets) != 2 {
+				t.Errorf("Backend.ListBuckets() = %v, want 2 buckets", buckets.Buckets)
+			}
+		})
+	}
+}
+
+func TestBackend_PutObject(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Larger than a single upload part, so its ETag is of a multipart upload.
+			hugefile := make([]byte, 40<<20)
+			if _, err := rand.Read(hugefile); err != nil {
+				t.Fatalf("Could not generate file with error: %v", err)
+			}
+
+			tests := []struct {
+				name            string
+				key             string
+				bucket          string
+				file            []byte
+				contentType     *string
+				metadata        map[string]*string
+				wantETag        string
+				wantContentType string
+				wantMetadata    map[string]*string
+				wantCode        string
+			}{
+				{
+					name:            "put text file",
+					key:             "testfile.txt",
+					bucket:          "testbucket",
+					file:            []byte("Hello, World!"),
+					contentType:     aws.String("text/plain"),
+					metadata:        map[string]*string{"test": aws.String("testt")},
+					wantETag:        md5ETag([]byte("Hello, World!")),
+					wantContentType: "text/plain",
+					wantMetadata:    map[string]*string{"Test": aws.String("testt")},
+				},
+				{
+					name:            "put empty file in a folder",
+					key:             "testfolder/testfile",
+					bucket:          "testbucket",
+					file:            []byte{},
+					wantETag:        md5ETag([]byte{}),
+					wantContentType: "binary/octet-stream",
+					wantMetadata:    map[string]*string{},
+				},
+				{
+					name:            "put huge file",
+					key:             "hugefile",
+					bucket:          "testbucket",
+					file:            hugefile,
+					contentType:     aws.String("application/octet-stream"),
+					wantETag:        multipartETag(hugefile[:32<<20], hugefile[32<<20:]),
+					wantContentType: "application/octet-stream",
+					wantMetadata:    map[string]*string{},
+				},
+				{
+					name:     "put file to bucket that does not exist",
+					key:      "testfile.txt",
+					bucket:   "notexistbucket",
+					file:     []byte("Hello, World!"),
+					wantCode: s3.ErrCodeNoSuchBucket,
+				},
+				{
+					name:     "put file with empty key",
+					key:      "",
+					bucket:   "testbucket",
+					file:     []byte("Hello, World!"),
+					wantCode: storage.ErrCodeInvalidArgument,
+				},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					output, err := backend.PutObject(ctx, &s3manager.UploadInput{
+						Bucket:      aws.String(tt.bucket),
+						Key:         aws.String(tt.key),
+						Body:        bytes.NewReader(tt.file),
+						ContentType: tt.contentType,
+						Metadata:    tt.metadata,
+					})
+					if errCode(err) != tt.wantCode || (err != nil) != (tt.wantCode != "") {
+						t.Fatalf("Backend.PutObject() error = %v, wantCode %v", err, tt.wantCode)
+					}
+
+					if err != nil {
+						return
+					}
+
+					wantLocation := fmt.Sprintf("%s/%s/%s", tb.endpoint, tt.bucket, tt.key)
+					if output.Location != wantLocation {
+						t.Errorf("Backend.PutObject() Location = %v, want %v", output.Location, wantLocation)
+					}
+
+					head, err := backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(tt.bucket), Key: aws.String(tt.key)})
+					if err != nil {
+						t.Fatalf("Backend.HeadObject() error = %v", err)
+					}
+
+					if *head.ETag != tt.wantETag {
+						t.Errorf("Backend.HeadObject() ETag = %v, want %v", *head.ETag, tt.wantETag)
+					}
+
+					if *head.ContentLength != int64(len(tt.file)) {
+						t.Errorf("Backend.HeadObject() ContentLength = %v, want %v", *head.ContentLength, len(tt.file))
+					}
+
+					if *head.ContentType != tt.wantContentType {
+						t.Errorf("Backend.HeadObject() ContentType = %v, want %v", *head.ContentType, tt.wantContentType)
+					}
+
+					if fmt.Sprint(aws.StringValueMap(head.Metadata)) != fmt.Sprint(aws.StringValueMap(tt.wantMetadata)) {
+						t.Errorf("Backend.HeadObject() Metadata = %v, want %v", head.Metadata, tt.wantMetadata)
+					}
+
+					obj, err := backend.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(tt.bucket), Key: aws.String(tt.key)})
+					if err != nil {
+						t.Fatalf("Backend.GetObject() error = %v", err)
+					}
+					defer obj.Body.Close()
+
+					body, err := ioutil.ReadAll(obj.Body)
+					if err != nil {
+						t.Fatalf("Backend.GetObject() failed reading body: %v", err)
+					}
+
+					if !bytes.Equal(body, tt.file) {
+						t.Errorf("Backend.GetObject() got %d bytes, want %d bytes", len(body), len(tt.file))
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestBackend_GetObject(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := backend.PutObject(ctx, &s3manager.UploadInput{
+				Bucket: aws.String("testbucket"),
+				Key:    aws.String("testfile.txt"),
+				Body:   bytes.NewReader([]byte("Hello, World!")),
+			})
+			if err != nil {
+				t.Fatalf("Backend.PutObject() error = %v", err)
+			}
+
+			tests := []struct {
+				name             string
+				key              string
+				byteRange        *string
+				want             string
+				wantContentRange *string
+				wantCode         string
+			}{
+				{name: "get object", key: "testfile.txt", want: "Hello, World!"},
+				{
+					name:             "get object range",
+					key:              "testfile.txt",
+					byteRange:        aws.String("bytes=7-11"),
+					want:             "World",
+					wantContentRange: aws.String("bytes 7-11/13"),
+				},
+				{
+					name:             "get object open range",
+					key:              "testfile.txt",
+					byteRange:        aws.String("bytes=7-"),
+					want:             "World!",
+					wantContentRange: aws.String("bytes 7-12/13"),
+				},
+				{
+					name:             "get object suffix range",
+					key:              "testfile.txt",
+					byteRange:        aws.String("bytes=-6"),
+					want:             "World!",
+					wantContentRange: aws.String("bytes 7-12/13"),
+				},
+				{
+					name:      "get object unsatisfiable range",
+					key:       "testfile.txt",
+					byteRange: aws.String("bytes=13-20"),
+					wantCode:  storage.ErrCodeInvalidRange,
+				},
+				{name: "get object that does not exist", key: "notexistobject", wantCode: s3.ErrCodeNoSuchKey},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					obj, err := backend.GetObject(ctx, &s3.GetObjectInput{
+						Bucket: aws.String("testbucket"),
+						Key:    aws.String(tt.key),
+						Range:  tt.byteRange,
+					})
+					if errCode(err) != tt.wantCode || (err != nil) != (tt.wantCode != "") {
+						t.Fatalf("Backend.GetObject() error = %v, wantCode %v", err, tt.wantCode)
+					}
+
+					if err != nil {
+						return
+					}
+					defer obj.Body.Close()
+
+					body, err := ioutil.ReadAll(obj.Body)
+					if err != nil {
+						t.Fatalf("Backend.GetObject() failed reading body: %v", err)
+					}
+
+					if string(body) != tt.want {
+						t.Errorf("Backend.GetObject() = %s, want %s", body, tt.want)
+					}
+
+					if *obj.ContentLength != int64(len(tt.want)) {
+						t.Errorf("Backend.GetObject() ContentLength = %v, want %v", *obj.ContentLength, len(tt.want))
+					}
+
+					if aws.StringValue(obj.ContentRange) != aws.StringValue(tt.wantContentRange) {
+						t.Errorf("Backend.GetObject() ContentRange = %v, want %v", obj.ContentRange, tt.wantContentRange)
+					}
+				})
+			}
+		})
+	}
+}
+
+//nolint:gocyclo
+func TestBackend_MultipartUpload(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			part1 := make([]byte, 5<<20)
+			if _, err := rand.Read(part1); err != nil {
+				t.Fatalf("Could not generate part with error: %v", err)
+			}
+			part2 := []byte("Hello, World!")
+
+			tests := []struct {
+				name     string
+				parts    [][]byte
+				complete func(etags []string) []*s3.CompletedPart
+				wantETag string
+				wantCode string
+			}{
+				{
+					name:  "complete upload",
+					parts: [][]byte{part1, part2},
+					complete: func(etags []string) []*s3.CompletedPart {
+						return []*s3.CompletedPart{
+							{PartNumber: aws.Int64(1), ETag: aws.String(etags[0])},
+							{PartNumber: aws.Int64(2), ETag: aws.String(etags[1])},
+						}
+					},
+					wantETag: multipartETag(part1, part2),
+				},
+				{
+					name:  "complete upload with a subset of parts",
+					parts: [][]byte{part1, part2},
+					complete: func(etags []string) []*s3.CompletedPart {
+						return []*s3.CompletedPart{{PartNumber: aws.Int64(2), ETag: aws.String(etags[1])}}
+					},
+					wantETag: multipartETag(part2),
+				},
+				{
+					name:  "complete upload with wrong etag",
+					parts: [][]byte{part1, part2},
+					complete: func(etags []string) []*s3.CompletedPart {
+						return []*s3.CompletedPart{
+							{PartNumber: aws.Int64(1), ETag: aws.String(etags[1])},
+							{PartNumber: aws.Int64(2), ETag: aws.String(etags[1])},
+						}
+					},
+					wantCode: storage.ErrCodeInvalidPart,
+				},
+				{
+					name:  "complete upload with missing part",
+					parts: [][]byte{part1},
+					complete: func(etags []string) []*s3.CompletedPart {
+						return []*s3.CompletedPart{
+							{PartNumber: aws.Int64(1), ETag: aws.String(etags[0])},
+							{PartNumber: aws.Int64(2), ETag: aws.String(etags[0])},
+						}
+					},
+					wantCode: storage.ErrCodeInvalidPart,
+				},
+				{
+					name:  "complete upload with parts out of order",
+					parts: [][]byte{part1, part2},
+					complete: func(etags []string) []*s3.CompletedPart {
+						return []*s3.CompletedPart{
+							{PartNumber: aws.Int64(2), ETag: aws.String(etags[1])},
+							{PartNumber: aws.Int64(1), ETag: aws.String(etags[0])},
+						}
+					},
+					wantCode: storage.ErrCodeInvalidPartOrder,
+				},
+				{
+					name:  "complete upload with a small part",
+					parts: [][]byte{part2, part1},
+					complete: func(etags []string) []*s3.CompletedPart {
+						return []*s3.CompletedPart{
+							{PartNumber: aws.Int64(1), ETag: aws.String(etags[0])},
+							{PartNumber: aws.Int64(2), ETag: aws.String(etags[1])},
+						}
+					},
+					wantCode: storage.ErrCodeEntityTooSmall,
+				},
+				{
+					name:  "complete upload without parts",
+					parts: [][]byte{part1},
+					complete: func(etags []string) []*s3.CompletedPart {
+						return nil
+					},
+					wantCode: storage.ErrCodeMalformedXML,
+				},
+			}
+			for i, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					key := aws.String(fmt.Sprintf("multipart%d", i))
+					bucket := aws.String("testbucket")
+					upload, err := backend.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+						Bucket:      bucket,
+						Key:         key,
+						ContentType: aws.String("text/plain"),
+						Metadata:    map[string]*string{"test": aws.String("testt")},
+					})
+					if err != nil {
+						t.Fatalf("Backend.CreateMultipartUpload() error = %v", err)
+					}
+
+					etags := make([]string, 0, len(tt.parts))
+					for partNumber, part := range tt.parts {
+						output, err := backend.UploadPart(ctx, &s3.UploadPartInput{
+							Bucket:     bucket,
+							Key:        key,
+							UploadId:   upload.UploadId,
+							PartNumber: aws.Int64(int64(partNumber + 1)),
+							Body:       bytes.NewReader(part),
+						})
+						if err != nil {
+							t.Fatalf("Backend.UploadPart() error = %v", err)
+						}
+
+						if *output.ETag != md5ETag(part) {
+							t.Errorf("Backend.UploadPart() ETag = %v, want %v", *output.ETag, md5ETag(part))
+						}
+						etags = append(etags, *output.ETag)
+					}
+
+					parts, err := backend.ListParts(ctx, &s3.ListPartsInput{Bucket: bucket, Key: key, UploadId: upload.UploadId})
+					if err != nil {
+						t.Fatalf("Backend.ListParts() error = %v", err)
+					}
+
+					if len(parts.Parts) != len(tt.parts) {
+						t.Errorf("Backend.ListParts() = %v, want %d parts", parts.Parts, len(tt.parts))
+					}
+
+					output, err := backend.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+						Bucket:          bucket,
+						Key:             key,
+						UploadId:        upload.UploadId,
+						MultipartUpload: &s3.CompletedMultipartUpload{Parts: tt.complete(etags)},
+					})
+					if errCode(err) != tt.wantCode || (err != nil) != (tt.wantCode != "") {
+						t.Fatalf("Backend.CompleteMultipartUpload() error = %v, wantCode %v", err, tt.wantCode)
+					}
+
+					if err != nil {
+						return
+					}
+
+					if *output.ETag != tt.wantETag {
+						t.Errorf("Backend.CompleteMultipartUpload() ETag = %v, want %v", *output.ETag, tt.wantETag)
+					}
+
+					head, err := backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: bucket, Key: key})
+					if err != nil {
+						t.Fatalf("Backend.HeadObject() error = %v", err)
+					}
+
+					if *head.ETag != tt.wantETag || *head.ContentType != "text/plain" || *head.Metadata["Test"] != "testt" {
+						t.Errorf("Backend.HeadObject() = %v", head)
+					}
+
+					_, err = backend.ListParts(ctx, &s3.ListPartsInput{Bucket: bucket, Key: key, UploadId: upload.UploadId})
+					if errCode(err) != s3.ErrCodeNoSuchUpload {
+						t.Errorf("Backend.ListParts() error = %v, wantCode %v", err, s3.ErrCodeNoSuchUpload)
+					}
+				})
+			}
+
+			t.Run("abort upload", func(t *testing.T) {
+				key := aws.String("abortfile")
+				bucket := aws.String("testbucket")
+				upload, err := backend.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{Bucket: bucket, Key: key})
+				if err != nil {
+					t.Fatalf("Backend.CreateMultipartUpload() error = %v", err)
+				}
+
+				_, err = backend.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+					Bucket:   bucket,
+					Key:      aws.String("otherkey"),
+					UploadId: upload.UploadId,
+				})
+				if errCode(err) != s3.ErrCodeNoSuchUpload {
+					t.Errorf("Backend.AbortMultipartUpload() error = %v, wantCode %v", err, s3.ErrCodeNoSuchUpload)
+				}
+
+				_, err = backend.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+					Bucket:   bucket,
+					Key:      key,
+					UploadId: upload.UploadId,
+				})
+				if err != nil {
+					t.Fatalf("Backend.AbortMultipartUpload() error = %v", err)
+				}
+
+				_, err = backend.UploadPart(ctx, &s3.UploadPartInput{
+					Bucket:     bucket,
+					Key:        key,
+					UploadId:   upload.UploadId,
+					PartNumber: aws.Int64(1),
+					Body:       bytes.NewReader(part2),
+				})
+				if errCode(err) != s3.ErrCodeNoSuchUpload {
+					t.Errorf("Backend.UploadPart() error = %v, wantCode %v", err, s3.ErrCodeNoSuchUpload)
+				}
+			})
+		})
+	}
+}
+
+func TestBackend_CopyAndDeleteObjects(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket", "testbucket1") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := backend.PutObject(ctx, &s3manager.UploadInput{
+				Bucket:      aws.String("testbucket"),
+				Key:         aws.String("testfile.txt"),
+				Body:        bytes.NewReader([]byte("Hello, World!")),
+				ContentType: aws.String("text/plain"),
+			})
+			if err != nil {
+				t.Fatalf("Backend.PutObject() error = %v", err)
+			}
+
+			copyTests := []struct {
+				name       string
+				copySource string
+				bucket     string
+				wantCode   string
+			}{
+				{name: "copy object", copySource: "testbucket%2Ftestfile.txt", bucket: "testbucket1"},
+				{name: "copy object that does not exist", copySource: "testbucket/notexist", bucket: "testbucket1", wantCode: s3.ErrCodeNoSuchKey},
+				{name: "copy object to bucket that does not exist", copySource: "testbucket/testfile.txt", bucket: "notexistbucket", wantCode: s3.ErrCodeNoSuchBucket},
+				{name: "copy object with invalid source", copySource: "testbucket", bucket: "testbucket1", wantCode: storage.ErrCodeInvalidArgument},
+			}
+			for _, tt := range copyTests {
+				t.Run(tt.name, func(t *testing.T) {
+					output, err := backend.CopyObject(ctx, &s3.CopyObjectInput{
+						Bucket:     aws.String(tt.bucket),
+						Key:        aws.String("newfile.txt"),
+						CopySource: aws.String(tt.copySource),
+					})
+					if errCode(err) != tt.wantCode || (err != nil) != (tt.wantCode != "") {
+						t.Fatalf("Backend.CopyObject() error = %v, wantCode %v", err, tt.wantCode)
+					}
+
+					if err != nil {
+						return
+					}
+
+					if *output.CopyObjectResult.ETag != md5ETag([]byte("Hello, World!")) {
+						t.Errorf("Backend.CopyObject() ETag = %v", *output.CopyObjectResult.ETag)
+					}
+				})
+			}
+
+			output, err := backend.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String("testbucket1"),
+				Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{
+					{Key: aws.String("newfile.txt")},
+					{Key: aws.String("notexistobject")},
+					{Key: aws.String(strings.Repeat("a", 1025))},
+				}},
+			})
+			if err != nil {
+				t.Fatalf("Backend.DeleteObjects() error = %v", err)
+			}
+
+			if len(output.Deleted) != 2 || len(output.Errors) != 1 || *output.Errors[0].Code != storage.ErrCodeKeyTooLong {
+				t.Errorf("Backend.DeleteObjects() = %v, want 2 deleted and 1 error", output)
+			}
+
+			_, err = backend.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String("testbucket1"),
+				Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: aws.String("")}}},
+			})
+			if err == nil {
+				t.Errorf("Backend.DeleteObjects() error = %v, wantErr %v", err, true)
+			}
+
+			tooMany := make([]*s3.ObjectIdentifier, 1001)
+			for i := range tooMany {
+				tooMany[i] = &s3.ObjectIdentifier{Key: aws.String(fmt.Sprintf("file%d", i))}
+			}
+
+			_, err = backend.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String("testbucket1"),
+				Delete: &s3.Delete{Objects: tooMany},
+			})
+			if errCode(err) != storage.ErrCodeMalformedXML {
+				t.Errorf("Backend.DeleteObjects() error = %v, wantCode %v", err, storage.ErrCodeMalformedXML)
+			}
+
+			_, err = backend.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String("testbucket1"), Key: aws.String("newfile.txt")})
+			if errCode(err) != storage.ErrCodeNotFound {
+				t.Errorf("Backend.HeadObject() error = %v, wantCode %v", err, storage.ErrCodeNotFound)
+			}
+		})
+	}
+}
+
+func TestBackend_UploadPartCopy(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+			data := []byte("Hello, World!")
+
+			_, err := backend.PutObject(ctx, &s3manager.UploadInput{
+				Bucket: aws.String("testbucket"),
+				Key:    aws.String("source.txt"),
+				Body:   bytes.NewReader(data),
+			})
+			if err != nil {
+				t.Fatalf("Backend.PutObject() error = %v", err)
+			}
+
+			upload, err := backend.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+				Bucket: aws.String("testbucket"),
+				Key:    aws.String("copy.txt"),
+			})
+			if err != nil {
+				t.Fatalf("Backend.CreateMultipartUpload() error = %v", err)
+			}
+
+			tests := []struct {
+				name        string
+				copySource  string
+				sourceRange string
+				ifMatch     string
+				want        []byte
+				wantCode    string
+			}{
+				{name: "copy range", copySource: "testbucket/source.txt", sourceRange: "bytes=0-4", want: data[:5]},
+				{name: "copy whole object", copySource: "testbucket/source.txt", ifMatch: md5ETag(data), want: data},
+				{name: "copy with ETag mismatch", copySource: "testbucket/source.txt", ifMatch: `"stale"`, wantCode: storage.ErrCodePreconditionFailed},
+				{name: "copy object that does not exist", copySource: "testbucket/notexist", wantCode: s3.ErrCodeNoSuchKey},
+				{name: "copy unsatisfiable range", copySource: "testbucket/source.txt", sourceRange: "bytes=100-200", wantCode: storage.ErrCodeInvalidRange},
+			}
+			for i, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					input := &s3.UploadPartCopyInput{
+						Bucket:     aws.String("testbucket"),
+						Key:        aws.String("copy.txt"),
+						UploadId:   upload.UploadId,
+						PartNumber: aws.Int64(int64(i + 1)),
+						CopySource: aws.String(tt.copySource),
+					}
+
+					if tt.sourceRange != "" {
+						input.CopySourceRange = aws.String(tt.sourceRange)
+					}
+
+					if tt.ifMatch != "" {
+						input.CopySourceIfMatch = aws.String(tt.ifMatch)
+					}
+
+					output, err := backend.UploadPartCopy(ctx, input)
+					if errCode(err) != tt.wantCode || (err != nil) != (tt.wantCode != "") {
+						t.Fatalf("Backend.UploadPartCopy() error = %v, wantCode %v", err, tt.wantCode)
+					}
+
+					if err != nil {
+						return
+					}
+
+					if *output.CopyPartResult.ETag != md5ETag(tt.want) {
+						t.Errorf("Backend.UploadPartCopy() ETag = %v, want %v", *output.CopyPartResult.ETag, md5ETag(tt.want))
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestBackend_ListObjects(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			keys := []string{"a.txt", "dir/b.txt", "dir/c.txt", "dir/sub/d.txt", "dir2/e.txt", "f.txt"}
+			for _, key := range keys {
+				_, err := backend.PutObject(ctx, &s3manager.UploadInput{
+					Bucket: aws.String("testbucket"),
+					Key:    aws.String(key),
+					Body:   strings.NewReader(key),
+				})
+				if err != nil {
+					t.Fatalf("Backend.PutObject() error = %v", err)
+				}
+			}
+
+			tests := []struct {
+				name         string
+				prefix       string
+				delimiter    string
+				maxKeys      int64
+				wantKeys     []string
+				wantPrefixes []string
+				wantCode     string
+			}{
+				{
+					name:     "list all objects",
+					wantKeys: keys,
+				},
+				{
+					name:         "list root folder",
+					delimiter:    "/",
+					wantKeys:     []string{"a.txt", "f.txt"},
+					wantPrefixes: []string{"dir/", "dir2/"},
+				},
+				{
+					name:         "list sub folder",
+					prefix:       "dir/",
+					delimiter:    "/",
+					wantKeys:     []string{"dir/b.txt", "dir/c.txt"},
+					wantPrefixes: []string{"dir/sub/"},
+				},
+				{
+					name:         "list root folder in pages",
+					delimiter:    "/",
+					maxKeys:      1,
+					wantKeys:     []string{"a.txt", "f.txt"},
+					wantPrefixes: []string{"dir/", "dir2/"},
+				},
+				{
+					name:     "list all objects in pages",
+					maxKeys:  4,
+					wantKeys: keys,
+				},
+				{
+					name:     "list prefix without matches",
+					prefix:   "nothing/",
+					wantKeys: nil,
+				},
+				{
+					name:     "list with negative max keys",
+					maxKeys:  -1,
+					wantCode: storage.ErrCodeInvalidArgument,
+				},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					input := &s3.ListObjectsV2Input{
+						Bucket:    aws.String("testbucket"),
+						Prefix:    aws.String(tt.prefix),
+						Delimiter: aws.String(tt.delimiter),
+					}
+					if tt.maxKeys != 0 {
+						input.MaxKeys = aws.Int64(tt.maxKeys)
+					}
+
+					var gotKeys, gotPrefixes []string
+					for {
+						output, err := backend.ListObjectsV2(ctx, input)
+						if errCode(err) != tt.wantCode || (err != nil) != (tt.wantCode != "") {
+							t.Fatalf("Backend.ListObjectsV2() error = %v, wantCode %v", err, tt.wantCode)
+						}
+
+						if err != nil {
+							return
+						}
+
+						if tt.maxKeys > 0 && aws.Int64Value(output.KeyCount) > tt.maxKeys {
+							t.Errorf("Backend.ListObjectsV2() KeyCount = %d, want at most %d", *output.KeyCount, tt.maxKeys)
+						}
+
+						for _, object := range output.Contents {
+							gotKeys = append(gotKeys, *object.Key)
+							if *object.Size != int64(len(*object.Key)) || *object.ETag != md5ETag([]byte(*object.Key)) {
+								t.Errorf("Backend.ListObjectsV2() object = %v, want size and ETag of its key", object)
+							}
+						}
+
+						for _, prefix := range output.CommonPrefixes {
+							gotPrefixes = append(gotPrefixes, *prefix.Prefix)
+						}
+
+						if !aws.BoolValue(output.IsTruncated) {
+							break
+						}
+						input.ContinuationToken = output.NextContinuationToken
+					}
+
+					if fmt.Sprint(gotKeys) != fmt.Sprint(tt.wantKeys) {
+						t.Errorf("Backend.ListObjectsV2() keys = %v, want %v", gotKeys, tt.wantKeys)
+					}
+
+					if fmt.Sprint(gotPrefixes) != fmt.Sprint(tt.wantPrefixes) {
+						t.Errorf("Backend.ListObjectsV2() common prefixes = %v, want %v", gotPrefixes, tt.wantPrefixes)
+					}
+				})
+			}
+
+			_, err := backend.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+				Bucket:            aws.String("testbucket"),
+				ContinuationToken: aws.String("!invalid"),
+			})
+			if errCode(err) != storage.ErrCodeInvalidArgument {
+				t.Errorf("Backend.ListObjectsV2() error = %v, wantCode %v", err, storage.ErrCodeInvalidArgument)
+			}
+
+			_, err = backend.ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: aws.String("notexistbucket")})
+			if errCode(err) != s3.ErrCodeNoSuchBucket {
+				t.Errorf("Backend.ListObjectsV2() error = %v, wantCode %v", err, s3.ErrCodeNoSuchBucket)
+			}
+		})
+	}
+}
+
+func TestFSBackend_ListObjectsCache(t *testing.T) {
+	root, err := ioutil.TempDir("", "upload-service-fs")
+	if err != nil {
+		t.Fatalf("Could not create temp dir with error: %v", err)
+	}
+	defer os.RemoveAll(root)
+
+	backend, err := storage.NewFSBackend(root)
+	if err != nil {
+		t.Fatalf("storage.NewFSBackend() error = %v", err)
+	}
+
+	ctx := context.Background()
+	bucket := aws.String("testbucket")
+	if _, err := backend.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: bucket}); err != nil {
+		t.Fatalf("Backend.CreateBucket() error = %v", err)
+	}
+
+	put := func(key string, data string) {
+		_, err := backend.PutObject(ctx, &s3manager.UploadInput{Bucket: bucket, Key: aws.String(key), Body: strings.NewReader(data)})
+		if err != nil {
+			t.Fatalf("Backend.PutObject() error = %v", err)
+		}
+	}
+
+	list := func() map[string]int64 {
+		output, err := backend.ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: bucket})
+		if err != nil {
+			t.Fatalf("Backend.ListObjectsV2() error = %v", err)
+		}
+
+		sizes := make(map[string]int64, len(output.Contents))
+		for _, object := range output.Contents {
+			sizes[*object.Key] = *object.Size
+		}
+
+		return sizes
+	}
+
+	put("a.txt", "a")
+	put("b.txt", "b")
+	if sizes := list(); len(sizes) != 2 || sizes["a.txt"] != 1 || sizes["b.txt"] != 1 {
+		t.Fatalf("Backend.ListObjectsV2() = %v, want a.txt and b.txt of 1 byte", sizes)
+	}
+
+	// Corrupt the trailer of a.txt's file without changing its size and modification time,
+	// a file that didn't change since the last list isn't read again.
+	sum := sha256.Sum256([]byte("a.txt"))
+	path := filepath.Join(backend.GetRoot(), *bucket, "objects", hex.EncodeToString(sum[:]))
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Could not stat file with error: %v", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Could not open file with error: %v", err)
+	}
+	_, err = f.WriteAt([]byte("x"), stat.Size()-9)
+	f.Close()
+	if err != nil {
+		t.Fatalf("Could not write file with error: %v", err)
+	}
+
+	if err := os.Chtimes(path, stat.ModTime(), stat.ModTime()); err != nil {
+		t.Fatalf("Could not change file times with error: %v", err)
+	}
+
+	if sizes := list(); len(sizes) != 2 || sizes["a.txt"] != 1 {
+		t.Errorf("Backend.ListObjectsV2() = %v, want a.txt listed from the cache", sizes)
+	}
+
+	// Replaced and deleted objects are listed as they are now.
+	put("b.txt", "bb")
+	_, err = backend.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: bucket,
+		Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: aws.String("a.txt")}}},
+	})
+	if err != nil {
+		t.Fatalf("Backend.DeleteObjects() error = %v", err)
+	}
+
+	if sizes := list(); len(sizes) != 1 || sizes["b.txt"] != 2 {
+		t.Errorf("Backend.ListObjectsV2() = %v, want only b.txt of 2 bytes", sizes)
+	}
+}
+
+func TestBackend_ListMultipartUploadsAndDeleteBucket(t *testing.T) {
+	for _, tb := range newTestBackends(t, "testbucket") {
+		backend := tb.backend
+		defer tb.cleanup()
+		t.Run(tb.name, func(t *testing.T) {
+			ctx := context.Background()
+			bucket := aws.String("testbucket")
+
+			uploadIDs := make(map[string]string)
+			for _, key := range []string{"dir/a", "dir/b", "c"} {
+				upload, err := backend.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+					Bucket: bucket,
+					Key:    aws.String(key),
+				})
+				if err != nil {
+					t.Fatalf("Backend.CreateMultipartUpload() error = %v", err)
+				}
+				uploadIDs[key] = *upload.UploadId
+			}
+
+			// List the uploads with a prefix, one upload in each page.
+			input := &s3.ListMultipartUploadsInput{Bucket: bucket, Prefix: aws.String("dir/"), MaxUploads: aws.Int64(1)}
+			var gotKeys []string
+			for {
+				output, err := backend.ListMultipartUploads(ctx, input)
+				if err != nil {
+					t.Fatalf("Backend.ListMultipartUploads() error = %v", err)
+				}
+
+				for _, upload := range output.Uploads {
+					gotKeys = append(gotKeys, *upload.Key)
+					if *upload.UploadId != uploadIDs[*upload.Key] {
+						t.Errorf("Backend.ListMultipartUploads() UploadId = %s, want %s", *upload.UploadId, uploadIDs[*upload.Key])
+					}
+				}
+
+				if !aws.BoolValue(output.IsTruncated) {
+					break
+				}
+				input.KeyMarker = output.NextKeyMarker
+				input.UploadIdMarker = output.NextUploadIdMarker
+			}
+
+			if fmt.Sprint(gotKeys) != fmt.Sprint([]string{"dir/a", "dir/b"}) {
+				t.Errorf("Backend.ListMultipartUploads() keys = %v, want %v", gotKeys, []string{"dir/a", "dir/b"})
+			}
+
+			_, err := backend.PutObject(ctx, &s3manager.UploadInput{Bucket: bucket, Key: aws.String("object"), Body: strings.NewReader("data")})
+			if err != nil {
+				t.Fatalf("Backend.PutObject() error = %v", err)
+			}
+
+			// The bucket can't be deleted until its objects are deleted and its uploads are aborted.
+			if _, err := backend.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: bucket}); errCode(err) != storage.ErrCodeBucketNotEmpty {
+				t.Errorf("Backend.DeleteBucket() error = %v, wantCode %v", err, storage.ErrCodeBucketNotEmpty)
+			}
+
+			_, err = backend.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+				Bucket: bucket,
+				Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: aws.String("object")}}},
+			})
+			if err != nil {
+				t.Fatalf("Backend.DeleteObjects() error = %v", err)
+			}
+
+			if _, err := backend.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: bucket}); errCode(err) != storage.ErrCodeBucketNotEmpty {
+				t.Errorf("Backend.DeleteBucket() error = %v, wantCode %v", err, storage.ErrCodeBucketNotEmpty)
+			}
+
+			for key, uploadID := range uploadIDs {
+				_, err := backend.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+					Bucket:   bucket,
+					Key:      aws.String(key),
+					UploadId: aws.String(uploadID),
+				})
+				if err != nil {
+					t.Fatalf("Backend.AbortMultipartUpload() error = %v", err)
+				}
+			}
+
+			if _, err := backend.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: bucket}); err != nil {
+				t.Errorf("Backend.DeleteBucket() error = %v", err)
+			}
+
+			if _, err := backend.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: bucket}); errCode(err) != storage.ErrCodeNotFound {
+				t.Errorf("Backend.HeadBucket() error = %v, wantCode %v", err, storage.ErrCodeNotFound)
+			}
+
+			if _, err := backend.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: bucket}); errCode(err) != s3.ErrCodeNoSuchBucket {
+				t.Errorf("Backend.DeleteBucket() error = %v, wantCode %v", err, s3.ErrCodeNoSuchBucket)
+			}
+		})
+	}
+}
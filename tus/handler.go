@@ -0,0 +1,691 @@
+package tus
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/meateam/upload-service/object"
+	"github.com/meateam/upload-service/session"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Version is the version of the tus protocol implemented by the handler.
+	Version = "1.0.0"
+
+	// Extensions are the tus protocol extensions implemented by the handler.
+	Extensions = "creation,termination,checksum,expiration"
+
+	// DefaultBasePath is the default path of the upload creation URL, under which the uploads' URLs are.
+	DefaultBasePath = "/files/"
+
+	// DefaultPartSize is the default size of the parts an upload's data is buffered into
+	// before it's uploaded to the storage, which is S3's minimal part size.
+	DefaultPartSize = 5 << 20 // 5MB
+
+	// DefaultMaxSize is the default maximal size of an upload, which is S3's maximal object size.
+	DefaultMaxSize = 5 << 40 // 5TB
+
+	// DefaultMaxBufferSize is the default maximal total size of the data buffered by a handler's uploads.
+	DefaultMaxBufferSize = 1 << 30 // 1GB
+
+	// OwnerHeader is the header of the user who makes a request. An upload is owned by the user
+	// who created it, and requests of other users for it are denied.
+	OwnerHeader = "X-Upload-Owner"
+
+	// StatusChecksumMismatch is the status of a PATCH request whose checksum doesn't match its body,
+	// defined by the checksum extension.
+	StatusChecksumMismatch = 460
+
+	// maxParts is the maximal number of parts of a multipart upload.
+	maxParts = 10000
+
+	// maxChecksumChunkSize is the maximal size of the body of a PATCH request with a checksum,
+	// which is held in memory until the checksum is verified.
+	maxChecksumChunkSize = 256 << 20 // 256MB
+
+	// offsetContentType is the content type of a PATCH request's body.
+	offsetContentType = "application/offset+octet-stream"
+)
+
+// Metadata keys of the upload creation request.
+const (
+	metadataBucket      = "bucket"
+	metadataKey         = "key"
+	metadataFileType    = "filetype"
+	metadataContentType = "contentType"
+)
+
+// checksumAlgorithms are the hashes of the checksum algorithms supported by the checksum extension.
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+// Handler is an HTTP handler of the tus resumable upload protocol, version 1.0.0, with the
+// creation, termination, checksum and expiration extensions. Each tus upload is a multipart upload
+// of the object service, whose object's bucket and key are given in the upload's metadata.
+// Data received by PATCH requests is buffered in memory into parts, which are uploaded to the
+// storage as they fill, so uploads should be routed to the same instance while they're in progress.
+// Buffered data that wasn't uploaded is lost when the service restarts, and the upload's offset
+// goes back to the end of the last uploaded part. PATCH requests fail with a 503 status while
+// the uploads buffer as much data as the handler allows.
+type Handler struct {
+	service  *object.Service
+	logger   *logrus.Logger
+	basePath string
+	partSize int64
+	maxSize  int64
+	buffers  *bufferLimit
+
+	// uploads are the uploads in progress and the completed uploads by upload ID,
+	// until they expire.
+	mu      sync.Mutex
+	uploads map[string]*upload
+}
+
+// Verify that Handler implements http.Handler.
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a Handler of tus uploads to service, whose creation URL is basePath.
+// An upload's data is uploaded in parts of partSize bytes, or larger parts if the upload
+// doesn't fit in 10,000 parts, and uploads larger than maxSize bytes are rejected.
+// An empty basePath, and a partSize or a maxSize that isn't positive are set to their default.
+func NewHandler(service *object.Service, logger *logrus.Logger, basePath string, partSize int64, maxSize int64) *Handler {
+	if basePath == "" {
+		basePath = DefaultBasePath
+	}
+
+	if !strings.HasSuffix(basePath, "/") {
+		basePath += "/"
+	}
+
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+
+	return &Handler{
+		service:  service,
+		logger:   logger,
+		basePath: basePath,
+		partSize: partSize,
+		maxSize:  maxSize,
+		buffers:  &bufferLimit{max: DefaultMaxBufferSize},
+		uploads:  make(map[string]*upload),
+	}
+}
+
+// SetMaxBufferSize sets the maximal total size of the data buffered by the handler's uploads,
+// a size that isn't positive is set to DefaultMaxBufferSize.
+func (h *Handler) SetMaxBufferSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxBufferSize
+	}
+
+	h.buffers.max = size
+}
+
+// Buffered returns the total size of the data buffered by the handler's uploads.
+func (h *Handler) Buffered() int64 {
+	return h.buffers.buffered()
+}
+
+// ServeHTTP handles a tus request. POST to the base path creates an upload, and HEAD, PATCH
+// and DELETE of an upload's URL return its offset, append data to it and terminate it.
+// OPTIONS returns the server's capabilities and answers CORS preflight requests.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Clients that can't send PATCH and DELETE requests send POST requests with the method overridden.
+	method := r.Method
+	if override := r.Header.Get("X-HTTP-Method-Override"); method == http.MethodPost && override != "" {
+		method = override
+	}
+
+	w.Header().Set("Tus-Resumable", Version)
+	if r.Header.Get("Origin") != "" {
+		h.setCORSHeaders(w, r)
+	}
+
+	if method == http.MethodOptions {
+		h.options(w)
+		return
+	}
+
+	if r.Header.Get("Tus-Resumable") != Version {
+		w.Header().Set("Tus-Version", Version)
+		h.fail(w, http.StatusPreconditionFailed, "unsupported tus version %q, expected %s", r.Header.Get("Tus-Resumable"), Version)
+
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, h.basePath) && r.URL.Path+"/" != h.basePath {
+		h.fail(w, http.StatusNotFound, "%s is not a tus upload URL", r.URL.Path)
+		return
+	}
+
+	uploadID := strings.TrimPrefix(r.URL.Path, h.basePath)
+	if uploadID == "" || r.URL.Path+"/" == h.basePath {
+		if method != http.MethodPost {
+			h.fail(w, http.StatusMethodNotAllowed, "method %s is not allowed on the creation URL", method)
+			return
+		}
+
+		h.create(w, r)
+
+		return
+	}
+
+	switch method {
+	case http.MethodHead:
+		h.head(w, r, uploadID)
+	case http.MethodPatch:
+		h.patch(w, r, uploadID)
+	case http.MethodDelete:
+		h.terminate(w, r, uploadID)
+	default:
+		h.fail(w, http.StatusMethodNotAllowed, "method %s is not allowed on an upload URL", method)
+	}
+}
+
+// options responds with the server's tus version, extensions, maximal upload size and checksum algorithms.
+func (h *Handler) options(w http.ResponseWriter) {
+	algorithms := make([]string, 0, len(checksumAlgorithms))
+	for algorithm := range checksumAlgorithms {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Strings(algorithms)
+
+	w.Header().Set("Tus-Version", Version)
+	w.Header().Set("Tus-Extension", Extensions)
+	w.Header().Set("Tus-Max-Size", strconv.FormatInt(h.maxSize, 10))
+	w.Header().Set("Tus-Checksum-Algorithm", strings.Join(algorithms, ","))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// create handles an upload creation request of the creation extension. The upload's object
+// is given by the bucket and key metadata, and its content type by the filetype or contentType
+// metadata. The rest of the metadata is stored as the object's metadata. The upload is owned
+// by the request's OwnerHeader, the same as the owner of an upload initiated over gRPC.
+// Responds with the upload's URL and the time it expires.
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Upload-Defer-Length") != "" {
+		h.fail(w, http.StatusBadRequest, "Upload-Defer-Length is not supported, Upload-Length is required")
+		return
+	}
+
+	length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil || length < 0 {
+		h.fail(w, http.StatusBadRequest, "Upload-Length %q is invalid", r.Header.Get("Upload-Length"))
+		return
+	}
+
+	if length > h.maxSize {
+		h.fail(w, http.StatusRequestEntityTooLarge, "Upload-Length %d exceeds the maximal size %d", length, h.maxSize)
+		return
+	}
+
+	metadata, err := parseMetadata(r.Header.Get("Upload-Metadata"))
+	if err != nil {
+		h.fail(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	bucket, key := metadata[metadataBucket], metadata[metadataKey]
+	if bucket == "" || key == "" {
+		h.fail(w, http.StatusBadRequest, "Upload-Metadata must have the %s and %s of the upload", metadataBucket, metadataKey)
+		return
+	}
+
+	contentType := metadata[metadataFileType]
+	if metadata[metadataContentType] != "" {
+		contentType = metadata[metadataContentType]
+	}
+
+	objectMetadata := make(map[string]string, len(metadata))
+	for name, value := range metadata {
+		switch name {
+		case metadataBucket, metadataKey, metadataFileType, metadataContentType:
+		default:
+			objectMetadata[name] = value
+		}
+	}
+
+	h.removeExpired()
+
+	result, uploadSession, err := h.service.UploadInitWithOptions(
+		r.Context(),
+		aws.String(key),
+		aws.String(bucket),
+		aws.String(contentType),
+		aws.StringMap(objectMetadata),
+		&object.UploadOptions{Owner: r.Header.Get(OwnerHeader), Size: length},
+	)
+	if err != nil {
+		h.failErr(w, err, "failed to create upload of %s/%s", bucket, key)
+		return
+	}
+
+	u := &upload{
+		id:       *result.UploadId,
+		key:      key,
+		bucket:   bucket,
+		owner:    uploadSession.Owner,
+		length:   length,
+		metadata: metadata,
+		expires:  uploadSession.Expires,
+		partSize: h.uploadPartSize(length),
+		limit:    h.buffers,
+	}
+
+	h.mu.Lock()
+	h.uploads[u.id] = u
+	h.mu.Unlock()
+
+	// An empty upload has all of its data, so it's completed right away.
+	if length == 0 {
+		u.mu.Lock()
+		err := u.flush(r.Context(), h.service, true)
+		u.mu.Unlock()
+
+		if err != nil {
+			h.failErr(w, err, "failed to complete upload %s", u.id)
+			return
+		}
+	}
+
+	w.Header().Set("Location", h.basePath+url.PathEscape(u.id))
+	w.Header().Set("Upload-Expires", u.expires.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// head responds with an upload's offset, length and metadata, and the time it expires.
+// A completed upload whose completion failed is completed again. A request of a user
+// who doesn't own the upload is forbidden, the same as PATCH and DELETE requests.
+func (h *Handler) head(w http.ResponseWriter, r *http.Request, uploadID string) {
+	u, err := h.getUpload(uploadID)
+	if err != nil {
+		h.failErr(w, err, "failed to get upload %s", uploadID)
+		return
+	}
+
+	if u == nil {
+		h.fail(w, http.StatusNotFound, "upload %s does not exist", uploadID)
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.ownedBy(r.Header.Get(OwnerHeader)) {
+		h.fail(w, http.StatusForbidden, "upload %s is owned by another user", uploadID)
+		return
+	}
+
+	if u.expired() {
+		h.fail(w, http.StatusGone, "upload %s expired", uploadID)
+		return
+	}
+
+	if !u.completed && u.offset() == u.length {
+		if err := u.flush(r.Context(), h.service, true); err != nil {
+			h.logger.Errorf("tus: failed to complete upload %s: %v", uploadID, err)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Upload-Offset", strconv.FormatInt(u.offset(), 10))
+	w.Header().Set("Upload-Length", strconv.FormatInt(u.length, 10))
+	if len(u.metadata) > 0 {
+		w.Header().Set("Upload-Metadata", formatMetadata(u.metadata))
+	}
+
+	if !u.completed {
+		w.Header().Set("Upload-Expires", u.expires.UTC().Format(http.TimeFormat))
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// patch appends the request's body to an upload at the request's offset, which must be the upload's
+// offset. A body with a checksum is verified before it's appended, and fails with
+// StatusChecksumMismatch if it doesn't match. Full parts are uploaded as they fill, and the upload
+// is completed once all of its data is received. Responds with the upload's new offset, and with
+// a 503 status if the handler's uploads buffer as much data as they're allowed to, in which case
+// the client retries from the new offset later.
+func (h *Handler) patch(w http.ResponseWriter, r *http.Request, uploadID string) {
+	if r.Header.Get("Content-Type") != offsetContentType {
+		h.fail(w, http.StatusUnsupportedMediaType, "Content-Type must be %s", offsetContentType)
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		h.fail(w, http.StatusBadRequest, "Upload-Offset %q is invalid", r.Header.Get("Upload-Offset"))
+		return
+	}
+
+	var bodyChecksum *checksum
+	if header := r.Header.Get("Upload-Checksum"); header != "" {
+		if bodyChecksum, err = parseChecksum(header); err != nil {
+			h.fail(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+	}
+
+	u, err := h.getUpload(uploadID)
+	if err != nil {
+		h.failErr(w, err, "failed to get upload %s", uploadID)
+		return
+	}
+
+	if u == nil {
+		h.fail(w, http.StatusNotFound, "upload %s does not exist", uploadID)
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.ownedBy(r.Header.Get(OwnerHeader)) {
+		h.fail(w, http.StatusForbidden, "upload %s is owned by another user", uploadID)
+		return
+	}
+
+	if u.expired() {
+		h.fail(w, http.StatusGone, "upload %s expired", uploadID)
+		return
+	}
+
+	if offset != u.offset() {
+		w.Header().Set("Upload-Offset", strconv.FormatInt(u.offset(), 10))
+		h.fail(w, http.StatusConflict, "Upload-Offset %d doesn't match the upload's offset %d", offset, u.offset())
+
+		return
+	}
+
+	var exceeded bool
+	if bodyChecksum != nil {
+		exceeded, err = u.appendVerified(r.Body, bodyChecksum)
+		if err == errChecksumMismatch {
+			h.fail(w, StatusChecksumMismatch, "Upload-Checksum doesn't match the request's body")
+			return
+		}
+
+		if err == errChunkTooLarge {
+			h.fail(w, http.StatusRequestEntityTooLarge, "a request with a checksum must have at most %d bytes", maxChecksumChunkSize)
+			return
+		}
+
+		if err == errBufferFull {
+			h.failBufferFull(w, u)
+			return
+		}
+	} else {
+		exceeded, err = u.appendBody(r.Context(), h.service, r.Body)
+	}
+
+	// Data received before the body failed is kept, and the client resumes after it.
+	if err != nil && err != errBufferFull {
+		h.logger.Errorf("tus: failed to read body of upload %s at offset %d: %v", uploadID, offset, err)
+	}
+
+	if flushErr := u.flush(r.Context(), h.service, u.offset() == u.length); flushErr != nil {
+		h.failErr(w, flushErr, "failed to upload data of upload %s", uploadID)
+		return
+	}
+
+	if err == errBufferFull {
+		h.failBufferFull(w, u)
+		return
+	}
+
+	if err != nil {
+		h.fail(w, http.StatusBadRequest, "failed to read request body: %v", err)
+		return
+	}
+
+	w.Header().Set("Upload-Offset", strconv.FormatInt(u.offset(), 10))
+	if exceeded {
+		h.fail(w, http.StatusRequestEntityTooLarge, "the request's body exceeds the upload's length %d", u.length)
+		return
+	}
+
+	if !u.completed {
+		w.Header().Set("Upload-Expires", u.expires.UTC().Format(http.TimeFormat))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// terminate aborts an upload in progress and frees its data, as defined by the termination
+// extension. A completed upload is forgotten, and its object is kept.
+func (h *Handler) terminate(w http.ResponseWriter, r *http.Request, uploadID string) {
+	u, err := h.getUpload(uploadID)
+	if err != nil {
+		h.failErr(w, err, "failed to get upload %s", uploadID)
+		return
+	}
+
+	if u == nil {
+		h.fail(w, http.StatusNotFound, "upload %s does not exist", uploadID)
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.ownedBy(r.Header.Get(OwnerHeader)) {
+		h.fail(w, http.StatusForbidden, "upload %s is owned by another user", uploadID)
+		return
+	}
+
+	if !u.completed {
+		_, err := h.service.UploadAbort(r.Context(), aws.String(u.id), aws.String(u.key), aws.String(u.bucket))
+		if err != nil && object.HTTPStatus(err) != http.StatusNotFound {
+			h.failErr(w, err, "failed to terminate upload %s", uploadID)
+			return
+		}
+	}
+
+	u.free()
+
+	h.mu.Lock()
+	delete(h.uploads, uploadID)
+	h.mu.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getUpload returns an upload by its ID, or nil if it doesn't exist. An upload that isn't
+// known to this instance, such as an upload created before the service restarted,
+// is restored from its session.
+func (h *Handler) getUpload(uploadID string) (*upload, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if u, ok := h.uploads[uploadID]; ok {
+		return u, nil
+	}
+
+	uploadSession, err := h.service.GetSessionStore().Get(uploadID)
+	if err == session.ErrNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Uploads without a length weren't created by tus.
+	if uploadSession.Size == 0 {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string, len(uploadSession.Metadata)+3)
+	for name, value := range uploadSession.Metadata {
+		metadata[name] = value
+	}
+
+	metadata[metadataBucket] = uploadSession.Bucket
+	metadata[metadataKey] = uploadSession.Key
+	if uploadSession.ContentType != "" {
+		metadata[metadataFileType] = uploadSession.ContentType
+	}
+
+	// The parts of a tus upload are uploaded in order, so the session's parts are its first parts.
+	u := &upload{
+		id:       uploadSession.UploadID,
+		key:      uploadSession.Key,
+		bucket:   uploadSession.Bucket,
+		owner:    uploadSession.Owner,
+		length:   uploadSession.Size,
+		metadata: metadata,
+		expires:  uploadSession.Expires,
+		partSize: h.uploadPartSize(uploadSession.Size),
+		limit:    h.buffers,
+		parts:    int64(len(uploadSession.Parts)),
+		uploaded: uploadSession.UploadedSize(),
+	}
+	h.uploads[uploadID] = u
+
+	return u, nil
+}
+
+// removeExpired forgets the uploads that expired, whose multipart uploads are aborted
+// by the upload reaper, and frees their buffers.
+func (h *Handler) removeExpired() {
+	var expired []*upload
+
+	h.mu.Lock()
+	now := time.Now()
+	for uploadID, u := range h.uploads {
+		if !now.Before(u.expires) {
+			expired = append(expired, u)
+			delete(h.uploads, uploadID)
+		}
+	}
+	h.mu.Unlock()
+
+	// An upload's mutex isn't locked while the handler's mutex is held, since terminate locks them in reverse.
+	for _, u := range expired {
+		u.mu.Lock()
+		u.free()
+		u.mu.Unlock()
+	}
+}
+
+// uploadPartSize returns the size of the parts of an upload of length bytes, which is the handler's
+// part size, or the smallest size that fits the upload in 10,000 parts if it's larger.
+func (h *Handler) uploadPartSize(length int64) int64 {
+	if minSize := (length + maxParts - 1) / maxParts; minSize > h.partSize {
+		return minSize
+	}
+
+	return h.partSize
+}
+
+// setCORSHeaders sets the headers that allow browsers to make tus requests from any origin,
+// and to read the tus response headers.
+func (h *Handler) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	w.Header().Set("Vary", "Origin")
+	w.Header().Set("Access-Control-Expose-Headers", "Location, Tus-Resumable, Tus-Version, Tus-Extension, "+
+		"Tus-Max-Size, Tus-Checksum-Algorithm, Upload-Offset, Upload-Length, Upload-Metadata, Upload-Expires")
+
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", "POST, HEAD, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Origin, X-Requested-With, X-HTTP-Method-Override, "+
+			"Content-Type, Tus-Resumable, Upload-Length, Upload-Offset, Upload-Metadata, Upload-Checksum, Upload-Defer-Length, "+
+			OwnerHeader)
+		w.Header().Set("Access-Control-Max-Age", "86400")
+	}
+}
+
+// fail responds with status and the formatted message.
+func (h *Handler) fail(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, fmt.Sprintf(format, args...))
+}
+
+// failBufferFull responds with a 503 status and the upload's offset, when the request's body can't
+// be buffered since the handler's uploads buffer as much data as they're allowed to.
+func (h *Handler) failBufferFull(w http.ResponseWriter, u *upload) {
+	w.Header().Set("Upload-Offset", strconv.FormatInt(u.offset(), 10))
+	w.Header().Set("Retry-After", "1")
+	h.fail(w, http.StatusServiceUnavailable, "the server buffers too much data of uploads, retry upload %s later", u.id)
+}
+
+// failErr logs an error of the object service and responds with its HTTP status.
+func (h *Handler) failErr(w http.ResponseWriter, err error, format string, args ...interface{}) {
+	message := fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err)
+	h.logger.Errorf("tus: %s", message)
+	h.fail(w, object.HTTPStatus(err), "%s", message)
+}
+
+// parseMetadata parses an Upload-Metadata header, which is a comma separated list of keys and
+// their base64 encoded values, separated by a space. A key may have no value.
+func parseMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("Upload-Metadata %q is invalid", header)
+		}
+
+		value := ""
+		if len(fields) == 2 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("Upload-Metadata value of %s is not base64 encoded", fields[0])
+			}
+
+			value = string(decoded)
+		}
+
+		metadata[fields[0]] = value
+	}
+
+	return metadata, nil
+}
+
+// formatMetadata returns the Upload-Metadata header of metadata, ordered by key.
+func formatMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if metadata[key] == "" {
+			pairs = append(pairs, key)
+			continue
+		}
+
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(metadata[key])))
+	}
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,461 @@
+package tus_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/meateam/upload-service/object"
+	"github.com/meateam/upload-service/storage"
+	"github.com/meateam/upload-service/tus"
+	"github.com/sirupsen/logrus"
+)
+
+// newTestServer creates a tus server of service.
+func newTestServer(service *object.Service) *httptest.Server {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	return httptest.NewServer(tus.NewHandler(service, logger, "", 0, 10<<20))
+}
+
+// tusRequest sends a tus request with the given headers and body, and returns its response.
+func tusRequest(t *testing.T, method string, url string, headers map[string]string, body []byte) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.NewRequest() error = %v", err)
+	}
+
+	req.Header.Set("Tus-Resumable", tus.Version)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s error = %v", method, url, err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+// createUpload creates a tus upload of length bytes to bucket and key, and returns its URL.
+func createUpload(t *testing.T, serverURL string, bucket string, key string, length int) string {
+	t.Helper()
+
+	resp := tusRequest(t, http.MethodPost, serverURL+tus.DefaultBasePath, map[string]string{
+		"Upload-Length": strconv.Itoa(length),
+		"Upload-Metadata": "bucket " + base64.StdEncoding.EncodeToString([]byte(bucket)) +
+			",key " + base64.StdEncoding.EncodeToString([]byte(key)) +
+			",filetype " + base64.StdEncoding.EncodeToString([]byte("text/plain")) +
+			",filename " + base64.StdEncoding.EncodeToString([]byte("file.txt")) +
+			",empty",
+	}, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	if resp.Header.Get("Upload-Expires") == "" {
+		t.Errorf("POST Upload-Expires is empty")
+	}
+
+	return serverURL + resp.Header.Get("Location")
+}
+
+// patch sends data at offset to an upload, with a checksum header if checksum isn't empty.
+func patch(t *testing.T, uploadURL string, offset int, data []byte, checksum string) *http.Response {
+	t.Helper()
+
+	headers := map[string]string{
+		"Content-Type":  "application/offset+octet-stream",
+		"Upload-Offset": strconv.Itoa(offset),
+	}
+	if checksum != "" {
+		headers["Upload-Checksum"] = checksum
+	}
+
+	return tusRequest(t, http.MethodPatch, uploadURL, headers, data)
+}
+
+// sha1Checksum returns the Upload-Checksum header of data.
+func sha1Checksum(data []byte) string {
+	sum := sha1.Sum(data)
+	return "sha1 " + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestHandler_Options(t *testing.T) {
+	server := newTestServer(object.NewService(storage.NewMemoryBackend()))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodOptions, server.URL+tus.DefaultBasePath, nil)
+	req.Header.Set("Origin", "https://example.com")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("OPTIONS error = %v", err)
+	}
+	resp.Body.Close()
+
+	want := map[string]string{
+		"Tus-Resumable":               tus.Version,
+		"Tus-Version":                 tus.Version,
+		"Tus-Extension":               tus.Extensions,
+		"Tus-Max-Size":                strconv.Itoa(10 << 20),
+		"Tus-Checksum-Algorithm":      "md5,sha1,sha256",
+		"Access-Control-Allow-Origin": "https://example.com",
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("OPTIONS status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	for name, value := range want {
+		if got := resp.Header.Get(name); got != value {
+			t.Errorf("OPTIONS %s = %q, want %q", name, got, value)
+		}
+	}
+}
+
+func TestHandler_Upload(t *testing.T) {
+	ctx := context.Background()
+	service := object.NewService(storage.NewMemoryBackend())
+	server := newTestServer(service)
+	defer server.Close()
+
+	// The data is buffered into a 5MB part and a last part.
+	data := make([]byte, tus.DefaultPartSize+(1<<20))
+	if _, err := rand.Read(data); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+
+	uploadURL := createUpload(t, server.URL, "tusbucket", "tusfile", len(data))
+	chunks := []struct {
+		name       string
+		offset     int
+		end        int
+		checksum   string
+		wantStatus int
+		wantOffset int
+	}{
+		{name: "first chunk", offset: 0, end: 3 << 20, wantStatus: http.StatusNoContent, wantOffset: 3 << 20},
+		{name: "wrong offset", offset: 0, end: 1 << 20, wantStatus: http.StatusConflict, wantOffset: 3 << 20},
+		{name: "checksum mismatch", offset: 3 << 20, end: 4 << 20, checksum: sha1Checksum([]byte("other")), wantStatus: tus.StatusChecksumMismatch},
+		{name: "chunk with checksum", offset: 3 << 20, end: 4 << 20, checksum: sha1Checksum(data[3<<20 : 4<<20]), wantStatus: http.StatusNoContent, wantOffset: 4 << 20},
+		{name: "last chunk", offset: 4 << 20, end: len(data), wantStatus: http.StatusNoContent, wantOffset: len(data)},
+	}
+	for _, chunk := range chunks {
+		resp := patch(t, uploadURL, chunk.offset, data[chunk.offset:chunk.end], chunk.checksum)
+		if resp.StatusCode != chunk.wantStatus {
+			t.Fatalf("PATCH %s status = %d, want %d", chunk.name, resp.StatusCode, chunk.wantStatus)
+		}
+
+		if chunk.wantOffset == 0 {
+			continue
+		}
+
+		if got := resp.Header.Get("Upload-Offset"); got != strconv.Itoa(chunk.wantOffset) {
+			t.Errorf("PATCH %s Upload-Offset = %s, want %d", chunk.name, got, chunk.wantOffset)
+		}
+	}
+
+	resp := tusRequest(t, http.MethodHead, uploadURL, nil, nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Upload-Offset") != strconv.Itoa(len(data)) ||
+		resp.Header.Get("Upload-Length") != strconv.Itoa(len(data)) {
+		t.Errorf("HEAD = %d with offset %s and length %s, want the completed upload",
+			resp.StatusCode, resp.Header.Get("Upload-Offset"), resp.Header.Get("Upload-Length"))
+	}
+
+	obj, err := service.GetBackend().GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String("tusbucket"), Key: aws.String("tusfile")})
+	if err != nil {
+		t.Fatalf("GetObject() error = %v", err)
+	}
+	defer obj.Body.Close()
+
+	got, err := ioutil.ReadAll(obj.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	if !bytes.Equal(got, data) {
+		t.Errorf("uploaded object has %d bytes that don't match the %d bytes sent", len(got), len(data))
+	}
+
+	if aws.StringValue(obj.ContentType) != "text/plain" || aws.StringValue(obj.Metadata["Filename"]) != "file.txt" {
+		t.Errorf("uploaded object content type = %s and metadata = %v, want the upload's metadata",
+			aws.StringValue(obj.ContentType), aws.StringValueMap(obj.Metadata))
+	}
+}
+
+func TestHandler_Resume(t *testing.T) {
+	service := object.NewService(storage.NewMemoryBackend())
+	server := newTestServer(service)
+
+	data := make([]byte, tus.DefaultPartSize+10)
+	uploadURL := createUpload(t, server.URL, "tusbucket", "resumedfile", len(data))
+	if resp := patch(t, uploadURL, 0, data[:tus.DefaultPartSize+5], ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("PATCH status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+	server.Close()
+
+	// A restarted server restores the upload from its session, without the data that wasn't uploaded.
+	server = newTestServer(service)
+	defer server.Close()
+
+	uploadURL = server.URL + uploadURL[len(server.URL):]
+	resp := tusRequest(t, http.MethodHead, uploadURL, nil, nil)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Upload-Offset") != strconv.Itoa(tus.DefaultPartSize) {
+		t.Fatalf("HEAD = %d with offset %s, want offset %d", resp.StatusCode, resp.Header.Get("Upload-Offset"), tus.DefaultPartSize)
+	}
+
+	if resp := patch(t, uploadURL, tus.DefaultPartSize, data[tus.DefaultPartSize:], ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("PATCH status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	obj, err := service.HeadObject(context.Background(), aws.String("resumedfile"), aws.String("tusbucket"))
+	if err != nil {
+		t.Fatalf("Service.HeadObject() error = %v", err)
+	}
+
+	if aws.Int64Value(obj.ContentLength) != int64(len(data)) {
+		t.Errorf("resumed object length = %d, want %d", aws.Int64Value(obj.ContentLength), len(data))
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	service := object.NewService(storage.NewMemoryBackend())
+	server := newTestServer(service)
+	defer server.Close()
+
+	uploadURL := createUpload(t, server.URL, "tusbucket", "errorfile", 10)
+	terminatedURL := createUpload(t, server.URL, "tusbucket", "terminatedfile", 10)
+	if resp := tusRequest(t, http.MethodDelete, terminatedURL, nil, nil); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	emptyURL := createUpload(t, server.URL, "tusbucket", "emptyfile", 0)
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		headers    map[string]string
+		body       []byte
+		noVersion  bool
+		wantStatus int
+	}{
+		{
+			name:       "missing tus version",
+			method:     http.MethodHead,
+			url:        uploadURL,
+			noVersion:  true,
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "create without length",
+			method:     http.MethodPost,
+			url:        server.URL + tus.DefaultBasePath,
+			headers:    map[string]string{"Upload-Metadata": "bucket dGVzdA==,key dGVzdA=="},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "create without bucket",
+			method:     http.MethodPost,
+			url:        server.URL + tus.DefaultBasePath,
+			headers:    map[string]string{"Upload-Length": "10", "Upload-Metadata": "key dGVzdA=="},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "create with invalid metadata",
+			method:     http.MethodPost,
+			url:        server.URL + tus.DefaultBasePath,
+			headers:    map[string]string{"Upload-Length": "10", "Upload-Metadata": "bucket !!!,key dGVzdA=="},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "create with reserved checksum metadata",
+			method:     http.MethodPost,
+			url:        server.URL + tus.DefaultBasePath,
+			headers:    map[string]string{"Upload-Length": "10", "Upload-Metadata": "bucket dGVzdA==,key dGVzdA==,checksum-sha256 YWI="},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "create larger than the maximal size",
+			method:     http.MethodPost,
+			url:        server.URL + tus.DefaultBasePath,
+			headers:    map[string]string{"Upload-Length": strconv.Itoa(10<<20 + 1), "Upload-Metadata": "bucket dGVzdA==,key dGVzdA=="},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "patch without offset content type",
+			method:     http.MethodPatch,
+			url:        uploadURL,
+			headers:    map[string]string{"Upload-Offset": "0"},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "patch with unsupported checksum",
+			method:     http.MethodPatch,
+			url:        uploadURL,
+			headers:    map[string]string{"Upload-Offset": "0", "Content-Type": "application/offset+octet-stream", "Upload-Checksum": "crc32 AAAA"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "patch beyond the length",
+			method:     http.MethodPatch,
+			url:        uploadURL,
+			headers:    map[string]string{"Upload-Offset": "0", "Content-Type": "application/offset+octet-stream"},
+			body:       bytes.Repeat([]byte("a"), 11),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "head of a terminated upload",
+			method:     http.MethodHead,
+			url:        terminatedURL,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "patch with method override",
+			method:     http.MethodPost,
+			url:        terminatedURL,
+			headers:    map[string]string{"X-HTTP-Method-Override": http.MethodPatch, "Upload-Offset": "0", "Content-Type": "application/offset+octet-stream"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "head of an empty upload",
+			method:     http.MethodHead,
+			url:        emptyURL,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "get an upload",
+			method:     http.MethodGet,
+			url:        uploadURL,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, bytes.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("http.NewRequest() error = %v", err)
+			}
+
+			if !tt.noVersion {
+				req.Header.Set("Tus-Resumable", tus.Version)
+			}
+
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("%s error = %v", tt.method, err)
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("%s status = %d, want %d", tt.method, resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+
+	if _, err := service.HeadObject(context.Background(), aws.String("emptyfile"), aws.String("tusbucket")); err != nil {
+		t.Errorf("Service.HeadObject() of the empty upload error = %v", err)
+	}
+}
+
+func TestHandler_BufferLimit(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	handler := tus.NewHandler(object.NewService(storage.NewMemoryBackend()), logger, "", 0, 10<<20)
+	handler.SetMaxBufferSize(1 << 20)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	firstURL := createUpload(t, server.URL, "tusbucket", "firstfile", 1<<20)
+	if resp := patch(t, firstURL, 0, make([]byte, 512<<10), ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("PATCH status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	// The second upload's data is buffered until the handler's limit is reached.
+	secondURL := createUpload(t, server.URL, "tusbucket", "secondfile", 1<<20)
+	resp := patch(t, secondURL, 0, make([]byte, 1<<20), "")
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Upload-Offset") != strconv.Itoa(512<<10) {
+		t.Fatalf("PATCH = %d with offset %s, want %d with offset %d",
+			resp.StatusCode, resp.Header.Get("Upload-Offset"), http.StatusServiceUnavailable, 512<<10)
+	}
+
+	if resp := patch(t, secondURL, 512<<10, make([]byte, 16), sha1Checksum(make([]byte, 16))); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("PATCH with checksum status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	// Terminating the first upload frees its buffer, and the second upload is resumed and completed.
+	if resp := tusRequest(t, http.MethodDelete, firstURL, nil, nil); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	if resp := patch(t, secondURL, 512<<10, make([]byte, 512<<10), ""); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("PATCH status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	if buffered := handler.Buffered(); buffered != 0 {
+		t.Errorf("Handler.Buffered() = %d, want 0 once the uploads are done", buffered)
+	}
+}
+
+func TestHandler_Owner(t *testing.T) {
+	service := object.NewService(storage.NewMemoryBackend())
+	server := newTestServer(service)
+	defer server.Close()
+
+	resp := tusRequest(t, http.MethodPost, server.URL+tus.DefaultBasePath, map[string]string{
+		"Upload-Length":   "10",
+		"Upload-Metadata": "bucket " + base64.StdEncoding.EncodeToString([]byte("tusbucket")) + ",key " + base64.StdEncoding.EncodeToString([]byte("ownedfile")),
+		tus.OwnerHeader:   "alice",
+	}, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+
+	uploadURL := server.URL + resp.Header.Get("Location")
+	uploadID := resp.Header.Get("Location")[len(tus.DefaultBasePath):]
+	if uploadSession, err := service.GetSessionStore().Get(uploadID); err != nil || uploadSession.Owner != "alice" {
+		t.Fatalf("upload session = %+v, error = %v, want owner alice", uploadSession, err)
+	}
+
+	for _, owner := range []string{"", "bob"} {
+		headers := map[string]string{tus.OwnerHeader: owner}
+		if resp := tusRequest(t, http.MethodHead, uploadURL, headers, nil); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("HEAD by %q status = %d, want %d", owner, resp.StatusCode, http.StatusForbidden)
+		}
+
+		headers["Content-Type"] = "application/offset+octet-stream"
+		headers["Upload-Offset"] = "0"
+		if resp := tusRequest(t, http.MethodPatch, uploadURL, headers, make([]byte, 10)); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("PATCH by %q status = %d, want %d", owner, resp.StatusCode, http.StatusForbidden)
+		}
+
+		if resp := tusRequest(t, http.MethodDelete, uploadURL, headers, nil); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("DELETE by %q status = %d, want %d", owner, resp.StatusCode, http.StatusForbidden)
+		}
+	}
+
+	headers := map[string]string{tus.OwnerHeader: "alice", "Content-Type": "application/offset+octet-stream", "Upload-Offset": "0"}
+	if resp := tusRequest(t, http.MethodPatch, uploadURL, headers, make([]byte, 10)); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("PATCH by the owner status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
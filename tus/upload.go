@@ -0,0 +1,308 @@
+package tus
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/meateam/upload-service/object"
+)
+
+// readChunkSize is the size of the chunks a PATCH request's body is read in.
+const readChunkSize = 32 << 10 // 32KB
+
+var (
+	// errChecksumMismatch is returned when a PATCH request's checksum doesn't match its body.
+	errChecksumMismatch = errors.New("checksum mismatch")
+
+	// errChunkTooLarge is returned when the body of a PATCH request with a checksum is too large.
+	errChunkTooLarge = errors.New("chunk too large")
+
+	// errBufferFull is returned when a PATCH request's body can't be buffered since the uploads
+	// of the handler buffer as much data as they're allowed to.
+	errBufferFull = errors.New("buffer full")
+)
+
+// bufferLimit limits the total size of the data buffered by the uploads of a handler.
+type bufferLimit struct {
+	max  int64
+	used int64 // Updated atomically.
+}
+
+// reserve reserves up to n bytes of the limit, and returns the number of bytes reserved,
+// which is less than n if there aren't n bytes left.
+func (l *bufferLimit) reserve(n int64) int64 {
+	for {
+		used := atomic.LoadInt64(&l.used)
+		reserved := n
+		if left := l.max - used; reserved > left {
+			reserved = left
+		}
+
+		if reserved <= 0 {
+			return 0
+		}
+
+		if atomic.CompareAndSwapInt64(&l.used, used, used+reserved) {
+			return reserved
+		}
+	}
+}
+
+// release releases n reserved bytes of the limit.
+func (l *bufferLimit) release(n int64) {
+	atomic.AddInt64(&l.used, -n)
+}
+
+// buffered returns the number of reserved bytes.
+func (l *bufferLimit) buffered() int64 {
+	return atomic.LoadInt64(&l.used)
+}
+
+// checksum is the checksum of a PATCH request's body, given by its Upload-Checksum header.
+type checksum struct {
+	newHash func() hash.Hash
+	value   []byte
+}
+
+// parseChecksum parses an Upload-Checksum header, which is the checksum's algorithm
+// and its base64 encoded value, separated by a space.
+func parseChecksum(header string) (*checksum, error) {
+	fields := strings.Fields(header)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("Upload-Checksum %q is invalid", header)
+	}
+
+	newHash, ok := checksumAlgorithms[fields[0]]
+	if !ok {
+		return nil, fmt.Errorf("checksum algorithm %s is not supported", fields[0])
+	}
+
+	value, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("Upload-Checksum value is not base64 encoded")
+	}
+
+	return &checksum{newHash: newHash, value: value}, nil
+}
+
+// upload is a tus upload, which is a multipart upload of the object service.
+// Its data is buffered until there's enough of it for a part, and the upload is completed
+// when all of its data is uploaded. Buffered data is reserved from limit until its part
+// is uploaded. An upload's mutex must be held while it's used.
+type upload struct {
+	mu       sync.Mutex
+	id       string
+	key      string
+	bucket   string
+	owner    string
+	length   int64
+	metadata map[string]string
+	expires  time.Time
+	partSize int64
+	limit    *bufferLimit
+
+	// parts is the number of parts uploaded, and uploaded is their total size in bytes.
+	parts    int64
+	uploaded int64
+
+	// buffer is the data received after the uploaded parts.
+	buffer []byte
+
+	// completed is true once the multipart upload is completed.
+	completed bool
+}
+
+// offset returns the number of bytes of the upload that were received.
+func (u *upload) offset() int64 {
+	return u.uploaded + int64(len(u.buffer))
+}
+
+// ownedBy returns true if the upload has no owner or owner is its owner.
+func (u *upload) ownedBy(owner string) bool {
+	return u.owner == "" || u.owner == owner
+}
+
+// expired returns true if the upload expired before it was completed.
+func (u *upload) expired() bool {
+	return !u.completed && !time.Now().Before(u.expires)
+}
+
+// appendBody appends body to the buffer up to the upload's length, uploading full parts as they fill.
+// Returns true if body has more data than the upload's length, and errBufferFull if the handler's
+// buffer limit is reached before body is read. Data read before an error is kept.
+func (u *upload) appendBody(ctx context.Context, service *object.Service, body io.Reader) (bool, error) {
+	chunk := make([]byte, readChunkSize+1)
+	for {
+		remaining := u.length - u.offset()
+		size := remaining
+		if size > readChunkSize {
+			size = readChunkSize
+		}
+
+		reserved := u.limit.reserve(size)
+		if reserved == 0 && size > 0 {
+			return false, errBufferFull
+		}
+
+		// A byte more than the rest of the upload is read, to tell if body exceeds its length.
+		readSize := reserved
+		if reserved == remaining {
+			readSize = reserved + 1
+		}
+
+		n, err := body.Read(chunk[:readSize])
+		if int64(n) > reserved {
+			u.buffer = append(u.buffer, chunk[:reserved]...)
+			return true, nil
+		}
+
+		u.limit.release(reserved - int64(n))
+		u.buffer = append(u.buffer, chunk[:n]...)
+		if err == io.EOF {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if int64(len(u.buffer)) >= u.partSize {
+			if err := u.flush(ctx, service, false); err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+// appendVerified reads body, and appends it to the buffer up to the upload's length if it matches
+// checksum. Returns errChecksumMismatch if it doesn't match, errChunkTooLarge if body has more
+// than maxChecksumChunkSize bytes, and errBufferFull if the handler's buffer limit is reached
+// before body is read. Returns true if body has more data than the upload's length.
+func (u *upload) appendVerified(body io.Reader, checksum *checksum) (bool, error) {
+	data, err := u.readReserved(body)
+	if err != nil {
+		return false, err
+	}
+
+	if len(data) > maxChecksumChunkSize {
+		u.limit.release(int64(len(data)))
+		return false, errChunkTooLarge
+	}
+
+	hash := checksum.newHash()
+	hash.Write(data)
+	if !bytes.Equal(hash.Sum(nil), checksum.value) {
+		u.limit.release(int64(len(data)))
+		return false, errChecksumMismatch
+	}
+
+	exceeded := false
+	if remaining := u.length - u.offset(); int64(len(data)) > remaining {
+		u.limit.release(int64(len(data)) - remaining)
+		data = data[:remaining]
+		exceeded = true
+	}
+
+	u.buffer = append(u.buffer, data...)
+
+	return exceeded, nil
+}
+
+// readReserved reads up to maxChecksumChunkSize+1 bytes of body, reserving them from the handler's
+// buffer limit as they're read. The returned data stays reserved, and nothing does if it fails.
+func (u *upload) readReserved(body io.Reader) ([]byte, error) {
+	var data []byte
+	chunk := make([]byte, readChunkSize)
+	for int64(len(data)) <= maxChecksumChunkSize {
+		reserved := u.limit.reserve(readChunkSize)
+		if reserved == 0 {
+			u.limit.release(int64(len(data)))
+			return nil, errBufferFull
+		}
+
+		n, err := body.Read(chunk[:reserved])
+		u.limit.release(reserved - int64(n))
+		data = append(data, chunk[:n]...)
+		if err == io.EOF {
+			return data, nil
+		}
+
+		if err != nil {
+			u.limit.release(int64(len(data)))
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// free drops the data in the buffer and releases it from the handler's buffer limit.
+func (u *upload) free() {
+	u.limit.release(int64(len(u.buffer)))
+	u.buffer = nil
+}
+
+// flush uploads the full parts in the buffer. If final is true then the rest of the buffer
+// is uploaded as the last part, and the upload is completed. Data stays in the buffer until
+// its part is uploaded, so a flush that failed can be retried.
+func (u *upload) flush(ctx context.Context, service *object.Service, final bool) error {
+	for int64(len(u.buffer)) >= u.partSize {
+		if err := u.uploadPart(ctx, service, u.partSize); err != nil {
+			return err
+		}
+	}
+
+	if !final || u.completed {
+		return nil
+	}
+
+	// An upload must have at least one part, even if it's empty.
+	if len(u.buffer) > 0 || u.parts == 0 {
+		if err := u.uploadPart(ctx, service, int64(len(u.buffer))); err != nil {
+			return err
+		}
+	}
+
+	_, err := service.UploadComplete(ctx, aws.String(u.id), aws.String(u.key), aws.String(u.bucket), nil, aws.Int64(u.length), nil)
+	if err != nil {
+		return err
+	}
+
+	u.completed = true
+
+	return nil
+}
+
+// uploadPart uploads the first size bytes of the buffer as the upload's next part.
+func (u *upload) uploadPart(ctx context.Context, service *object.Service, size int64) error {
+	_, err := service.UploadPart(
+		ctx,
+		aws.String(u.id),
+		aws.String(u.key),
+		aws.String(u.bucket),
+		aws.Int64(u.parts+1),
+		bytes.NewReader(u.buffer[:size]),
+	)
+	if err != nil {
+		return err
+	}
+
+	u.parts++
+	u.uploaded += size
+	u.limit.release(size)
+
+	// The rest of the buffer is copied, so the uploaded part's memory is freed.
+	u.buffer = append([]byte(nil), u.buffer[size:]...)
+
+	return nil
+}